@@ -31,6 +31,42 @@ type AppConfig struct {
 	DataOrigin     DataOrigin     `yaml:"dataOrigin"`
 	Model          ModelConfig    `yaml:"model"`
 	Strategy       StrategyConfig `yaml:"strategy"`
+	// Join configures how the model outputs are paired with the reference data. Defaults to pairing the same time.
+	Join *JoinConfig `yaml:"join,omitempty"`
+}
+
+type JoinConfig struct {
+	// Mode is how the packets are paired. "exact"|"asOf"|"multi", defaults to "exact".
+	// "asOf" pairs each model output with the latest reference data at or before it within Tolerance.
+	// "multi" joins the reference data "ref", the model output "model" and the outputs of Inputs
+	// into a tuple keyed by their names, and the strategy input type must be "tuple".
+	Mode string `yaml:"mode"`
+	// Strategy is how the inputs are joined in "multi" mode. "inner"|"left"|"asOf", defaults to "inner".
+	// "left" and "asOf" emit a tuple at every reference data.
+	Strategy string `yaml:"strategy,omitempty"`
+	// Inputs are the models run alongside Model on the same fetched data in "multi" mode.
+	Inputs []JoinInputConfig `yaml:"inputs,omitempty"`
+	// Tolerance is the maximum distance from a model output back to its reference data in "asOf" mode,
+	// or from a reference data back to the other inputs in "multi" mode with the "asOf" strategy. Example: "30s"
+	Tolerance string `yaml:"tolerance,omitempty"`
+	// Unmatched is the policy of the model outputs without reference data in "asOf" mode. "drop"|"fail", defaults to "drop".
+	Unmatched string `yaml:"unmatched,omitempty"`
+	// MaxBuffer is the maximum number of packets the joiner buffers for each input. Zero means unlimited.
+	MaxBuffer int `yaml:"maxBuffer,omitempty"`
+	// Overflow is the policy applied when a buffer is full. "block"|"dropOldest"|"fail", defaults to "block".
+	Overflow string `yaml:"overflow,omitempty"`
+	// AllowedLateness is how long the joiner keeps joining the packets behind the watermark of their input.
+	// Later packets are routed to the side output of the joiner. Example: "5s"
+	AllowedLateness string `yaml:"allowedLateness,omitempty"`
+}
+
+type JoinInputConfig struct {
+	// Name is the key of the output of the model in the tuple. It must not be "ref" or "model".
+	Name string `yaml:"name"`
+	// ID is the ID of the model. Its output type is the same as Model.
+	ID string `yaml:"ID"`
+	// Runtime is where the model is executed. "kserve"|"native", defaults to the runtime of Model.
+	Runtime string `yaml:"runtime,omitempty"`
 }
 
 type DataOrigin struct {
@@ -39,6 +75,57 @@ type DataOrigin struct {
 	ProductType    string    `yaml:"productType"`
 	StartTimestamp int64     `yaml:"startTimestamp"`
 	EndTimestamp   int64     `yaml:"endTimestamp"`
+	// AdditionalTimeFrames are longer time frames of the same product delivered alongside TimeFrame.
+	// Each of them must be a multiple of TimeFrame.
+	AdditionalTimeFrames []TimeFrame `yaml:"additionalTimeFrames,omitempty"`
+	// ReplaySpeed replays past data from StartTimestamp to EndTimestamp at the given pace in a realtimeTrade task.
+	// Examples: "1x", "10x", "max".
+	ReplaySpeed string `yaml:"replaySpeed,omitempty"`
+	// BypassCache makes the fetcher read past data from the database without the local cache.
+	BypassCache bool `yaml:"bypassCache,omitempty"`
+	// Synthetic configures the generator of synthetic data, which is used when ProductType is "synthetic".
+	Synthetic SyntheticConfig `yaml:"synthetic,omitempty"`
+	// Bar configures how bars are built from trade prints, which is used when ProductType is "tick".
+	Bar BarConfig `yaml:"bar,omitempty"`
+	// Validation enables the data quality validation of the fetched data.
+	Validation *ValidationConfig `yaml:"validation,omitempty"`
+	// Watermark inserts event-time watermarks into the fetched data in a pipeline with a model.
+	Watermark *WatermarkConfig `yaml:"watermark,omitempty"`
+}
+
+type WatermarkConfig struct {
+	// Delay is how far the watermark lags behind the latest fetched data. Example: "5s"
+	Delay string `yaml:"delay"`
+}
+
+type BarConfig struct {
+	// Type is the type of bar. "time"|"tick"|"volume"|"dollar"
+	// Time bars use TimeFrame of DataOrigin.
+	Type string `yaml:"type"`
+	// Threshold is the number of trades, size or value closing a tick, volume or dollar bar.
+	Threshold float64 `yaml:"threshold"`
+}
+
+type ValidationConfig struct {
+	// Policies maps a type of violation to the policy applied to it.
+	// Types: "nan"|"volume"|"ohlc"|"order"|"duplicate"|"spike"
+	// Policies: "drop"|"repair"|"fail"
+	Policies map[string]string `yaml:"policies"`
+	// SpikeZScore is the z-score of log return beyond which a price is considered a spike.
+	// Zero disables the spike check.
+	SpikeZScore float64 `yaml:"spikeZScore"`
+	// SpikeWindow is the number of latest log returns used to compute the z-score.
+	SpikeWindow int `yaml:"spikeWindow"`
+}
+
+type SyntheticConfig struct {
+	// Process is the stochastic process generating prices. "gbm"|"ou"|"regime"|"jump"
+	Process string `yaml:"process"`
+	// Seed makes every run reproducible. A random seed is used if omitted.
+	Seed *int64 `yaml:"seed"`
+	// NumOfGeneration is the number of bars to generate when EndTimestamp is omitted.
+	NumOfGeneration int                `yaml:"numOfGeneration"`
+	Params          map[string]float64 `yaml:"params"`
 }
 
 type TimeFrame struct {
@@ -46,14 +133,68 @@ type TimeFrame struct {
 }
 
 type ModelConfig struct {
-	ID         string             `yaml:"ID"`
-	BatchSize  int                `yaml:"batchSize"`
-	OutputType string             `yaml:"outputType"`
-	Params     map[string]float32 `yaml:"params"`
+	ID         string `yaml:"ID"`
+	BatchSize  int    `yaml:"batchSize"`
+	OutputType string `yaml:"outputType"`
+	// Params is sent to the inference service as the parameters of every inference request.
+	// A value is a string, a bool, an integer or a floating point number.
+	Params map[string]any `yaml:"params"`
+
+	// Runtime is where the model is executed. "kserve"|"native", defaults to "kserve".
+	Runtime string `yaml:"runtime,omitempty"`
+	// Concurrency is the number of inference requests in flight at once. Defaults to 1.
+	Concurrency int `yaml:"concurrency,omitempty"`
+	// Features specifies the input tensors of the model.
+	Features *FeatureConfig `yaml:"features,omitempty"`
+	// Labels names the classes or bins of "probeDist" output in the order of the outputs.
+	Labels []string `yaml:"labels,omitempty"`
+	// Softmax converts the outputs of "probeDist" from logits into probabilities.
+	Softmax bool `yaml:"softmax,omitempty"`
+	// BypassCache makes the executer send every inference request to KServe without the local cache.
+	BypassCache bool `yaml:"bypassCache,omitempty"`
+	// Breaker configures the circuit breaker around inference. No breaker if it is nil.
+	Breaker *BreakerConfig `yaml:"breaker,omitempty"`
+	// Fallback is the policy applied when an inference fails. "fail"|"skip"|"lastKnown", defaults to "fail".
+	Fallback string `yaml:"fallback,omitempty"`
+	// Shadow configures a model run alongside the model for comparison. No shadow model if it is nil.
+	Shadow *ShadowConfig `yaml:"shadow,omitempty"`
+}
+
+type ShadowConfig struct {
+	// ID is the ID of the shadow model. Its output type is the same as the model.
+	ID string `yaml:"ID"`
+	// Runtime is where the shadow model is executed. "kserve"|"native", defaults to the runtime of the model.
+	Runtime string `yaml:"runtime,omitempty"`
+}
+
+type BreakerConfig struct {
+	// FailureThreshold is the number of consecutive failed inferences that opens the breaker.
+	FailureThreshold int `yaml:"failureThreshold"`
+	// OpenTimeout is how long the breaker stays open before probing KServe. Example: "30s". Defaults to 30s.
+	OpenTimeout string `yaml:"openTimeout"`
+}
+
+type FeatureConfig struct {
+	// Fields is the fields of each bar fed into the model.
+	// "open"|"high"|"low"|"close"|"volume"|"return"|"logReturn"|"range"|"sma:{period}"|"ema:{period}"|"rsi:{period}"
+	// For the product type "quote", the fields of each quote "mid"|"spread"|"imbalance" are used instead.
+	// Defaults to ["high", "low", "open", "close"].
+	Fields []string `yaml:"fields"`
+	// Window is the number of bars in an input tensor. Defaults to BatchSize.
+	Window int `yaml:"window"`
+	// Stride is the number of bars between successive input tensors. Defaults to 1.
+	Stride int `yaml:"stride"`
+	// Layout is "row" for the shape {window, fields} or "column" for the shape {fields, window}. Defaults to "row".
+	Layout string `yaml:"layout"`
+	// Normalization maps a field to the normalization over each window. "none"|"zscore"|"minmax"
+	Normalization map[string]string `yaml:"normalization"`
 }
 
 type StrategyConfig struct {
 	ID        string             `yaml:"ID"`
 	InputType string             `yaml:"inputType"`
 	Params    map[string]float32 `yaml:"params"`
+	// InputWindow is the number of the latest closes in a "valueList" adapted from the "candlestick" output of the model.
+	// Defaults to 1.
+	InputWindow int `yaml:"inputWindow,omitempty"`
 }
@@ -30,7 +30,7 @@ func TestUnmarshal(t *testing.T) {
 		ID:         "goooo",
 		BatchSize:  100,
 		OutputType: "candlestick",
-		Params: map[string]float32{
+		Params: map[string]any{
 			"param1": 3.14,
 		},
 	}, AppConfig.Model)
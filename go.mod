@@ -6,8 +6,6 @@ require (
 	github.com/Goboolean/common v0.0.0-20231130153141-cb54596b217d
 	github.com/Goboolean/fetch-system.IaC v0.0.0-20240709073651-acf8ff66d5b2
 	github.com/cenkalti/backoff v2.2.1+incompatible
-	github.com/docker/docker v27.0.3+incompatible
-	github.com/docker/go-connections v0.5.0
 	github.com/google/wire v0.6.0
 	github.com/influxdata/influxdb-client-go/v2 v2.13.0
 	github.com/sirupsen/logrus v1.9.3
@@ -15,6 +13,8 @@ require (
 	github.com/testcontainers/testcontainers-go v0.32.0
 	go.uber.org/mock v0.4.0
 	golang.org/x/sync v0.7.0
+	google.golang.org/grpc v1.59.0
+	google.golang.org/protobuf v1.34.2
 	gopkg.in/yaml.v3 v3.0.1
 )
 
@@ -31,6 +31,8 @@ require (
 	github.com/cpuguy83/dockercfg v0.3.1 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/distribution/reference v0.6.0 // indirect
+	github.com/docker/docker v27.0.3+incompatible // indirect
+	github.com/docker/go-connections v0.5.0 // indirect
 	github.com/docker/go-units v0.5.0 // indirect
 	github.com/felixge/httpsnoop v1.0.4 // indirect
 	github.com/go-logr/logr v1.4.1 // indirect
@@ -68,7 +70,6 @@ require (
 	golang.org/x/crypto v0.22.0 // indirect
 	golang.org/x/net v0.23.0 // indirect
 	golang.org/x/sys v0.19.0 // indirect
+	golang.org/x/text v0.16.0 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20231016165738-49dd2c1f3d0b // indirect
-	google.golang.org/grpc v1.59.0 // indirect
-	google.golang.org/protobuf v1.34.2 // indirect
 )
@@ -1,3 +0,0 @@
-package algorithm
-
-//이 패키지에 알고리즘 관련 공통모듈 구현
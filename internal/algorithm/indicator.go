@@ -0,0 +1,38 @@
+// Package algorithm implements the common modules of trading algorithms shared by the strategies.
+//
+// The indicators are computed incrementally from a stream of bars in O(1) time per bar.
+// Every indicator has the same API: Update feeds the latest bar, Ready reports whether enough bars are fed,
+// and Value returns the indicator. The indicators with several lines provide the others by their own methods.
+package algorithm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Goboolean/core-system.worker/internal/model"
+)
+
+var ErrInvalidParam = errors.New("algorithm: invalid parameter")
+
+// Indicator is an indicator computed incrementally from a stream of bars.
+type Indicator interface {
+	// Update feeds the latest bar.
+	Update(bar *model.StockAggregate)
+	// Value returns the indicator of the bars fed so far. It is 0 until Ready reports true.
+	Value() float64
+	// Ready reports whether enough bars are fed to compute the indicator.
+	Ready() bool
+}
+
+func checkPeriod(name string, period int) error {
+	if period <= 0 {
+		return fmt.Errorf("%w: %s must be positive, got %d", ErrInvalidParam, name, period)
+	}
+	return nil
+}
+
+// trueRange returns the true range of bar, which is the range of bar extended to the previous close.
+func trueRange(bar *model.StockAggregate, prevClose float64) float64 {
+	high, low := float64(bar.High), float64(bar.Low)
+	return max(high, prevClose) - min(low, prevClose)
+}
@@ -0,0 +1,54 @@
+package algorithm_test
+
+import (
+	"math/rand"
+
+	"github.com/Goboolean/core-system.worker/internal/algorithm"
+	"github.com/Goboolean/core-system.worker/internal/model"
+)
+
+// bars are the bars the reference values are computed from.
+// The reference values are computed by recomputing each indicator from its definition over all the bars fed so far.
+var bars = []model.StockAggregate{
+	{Open: 10, High: 11, Low: 9, Close: 10, Volume: 100},
+	{Open: 10, High: 12, Low: 10, Close: 11, Volume: 200},
+	{Open: 11, High: 13, Low: 10, Close: 12, Volume: 150},
+	{Open: 12, High: 12, Low: 9, Close: 10, Volume: 300},
+	{Open: 10, High: 11, Low: 8, Close: 9, Volume: 250},
+	{Open: 9, High: 12, Low: 9, Close: 12, Volume: 100},
+	{Open: 12, High: 14, Low: 11, Close: 13, Volume: 200},
+}
+
+// feed feeds bars to the indicator one by one and returns the result of f after each bar,
+// with the number of bars fed when the indicator gets ready. It is 0 if the indicator is never ready.
+func feed(indicator algorithm.Indicator, f func() float64) ([]float64, int) {
+	res := make([]float64, len(bars))
+	readyAt := 0
+	for i := range bars {
+		indicator.Update(&bars[i])
+		res[i] = f()
+		if readyAt == 0 && indicator.Ready() {
+			readyAt = i + 1
+		}
+	}
+	return res, readyAt
+}
+
+// randomBars creates a random walk of n bars around a large price,
+// which makes the accumulated error of the incremental computation noticeable.
+func randomBars(n int) []model.StockAggregate {
+	r := rand.New(rand.NewSource(42))
+	res := make([]model.StockAggregate, n)
+	price := 100000.0
+	for i := range res {
+		price += r.Float64() - 0.5
+		res[i] = model.StockAggregate{
+			Open:   float32(price),
+			High:   float32(price + r.Float64()),
+			Low:    float32(price - r.Float64()),
+			Close:  float32(price + r.Float64() - 0.5),
+			Volume: float32(r.Intn(1000)),
+		}
+	}
+	return res
+}
@@ -0,0 +1,185 @@
+package algorithm
+
+import (
+	"fmt"
+
+	"github.com/Goboolean/core-system.worker/internal/model"
+)
+
+// RSI is the relative strength index of the closes with Wilder's smoothing.
+// It is ready after period + 1 bars, since each change needs the previous close.
+type RSI struct {
+	gain wilder
+	loss wilder
+
+	prevClose float64
+	started   bool
+}
+
+// NewRSI creates new instance of RSI
+func NewRSI(period int) (*RSI, error) {
+	if err := checkPeriod("period", period); err != nil {
+		return nil, err
+	}
+	return &RSI{
+		gain: wilder{period: period},
+		loss: wilder{period: period},
+	}, nil
+}
+
+func (r *RSI) Update(bar *model.StockAggregate) {
+	r.Add(float64(bar.Close))
+}
+
+// Add feeds the latest value of a series other than the closes.
+func (r *RSI) Add(v float64) {
+	change := v - r.prevClose
+	started := r.started
+	r.prevClose, r.started = v, true
+	if !started {
+		return
+	}
+
+	r.gain.push(max(change, 0))
+	r.loss.push(max(-change, 0))
+}
+
+func (r *RSI) Value() float64 {
+	if !r.Ready() {
+		return 0
+	}
+
+	if r.loss.value == 0 {
+		return 100
+	}
+	return 100 - 100/(1+r.gain.value/r.loss.value)
+}
+
+func (r *RSI) Ready() bool {
+	return r.gain.ready()
+}
+
+// MACD is the moving average convergence divergence of the closes.
+// Value is the MACD line, the fast EMA minus the slow EMA, and Signal is the EMA of the MACD line.
+// It is ready when the signal line is, after slow + signal - 1 bars.
+type MACD struct {
+	fast   EMA
+	slow   EMA
+	signal EMA
+}
+
+// NewMACD creates new instance of MACD. The periods commonly used are 12, 26 and 9.
+func NewMACD(fast, slow, signal int) (*MACD, error) {
+	if err := checkPeriod("fast", fast); err != nil {
+		return nil, err
+	}
+	if err := checkPeriod("slow", slow); err != nil {
+		return nil, err
+	}
+	if err := checkPeriod("signal", signal); err != nil {
+		return nil, err
+	}
+
+	if fast >= slow {
+		return nil, fmt.Errorf("%w: fast %d must be less than slow %d", ErrInvalidParam, fast, slow)
+	}
+
+	return &MACD{
+		fast:   newEMA(fast),
+		slow:   newEMA(slow),
+		signal: newEMA(signal),
+	}, nil
+}
+
+func (m *MACD) Update(bar *model.StockAggregate) {
+	m.Add(float64(bar.Close))
+}
+
+// Add feeds the latest value of a series other than the closes.
+func (m *MACD) Add(v float64) {
+	m.fast.Add(v)
+	m.slow.Add(v)
+	if m.slow.Ready() {
+		m.signal.Add(m.fast.Value() - m.slow.Value())
+	}
+}
+
+func (m *MACD) Value() float64 {
+	if !m.Ready() {
+		return 0
+	}
+	return m.fast.Value() - m.slow.Value()
+}
+
+// Signal returns the signal line.
+func (m *MACD) Signal() float64 {
+	return m.signal.Value()
+}
+
+// Histogram returns the MACD line minus the signal line.
+func (m *MACD) Histogram() float64 {
+	return m.Value() - m.Signal()
+}
+
+func (m *MACD) Ready() bool {
+	return m.signal.Ready()
+}
+
+// Stochastic is the stochastic oscillator of the bars.
+// Value is %K, the position of the close within the range of the latest kPeriod bars in percent,
+// and D is %D, the simple moving average of %K over dPeriod bars.
+// %K is 50 when the range is zero. It is ready when %D is, after kPeriod + dPeriod - 1 bars.
+type Stochastic struct {
+	highest extremum
+	lowest  extremum
+	d       SMA
+	k       float64
+}
+
+// NewStochastic creates new instance of Stochastic. The periods commonly used are 14 and 3.
+func NewStochastic(kPeriod, dPeriod int) (*Stochastic, error) {
+	if err := checkPeriod("kPeriod", kPeriod); err != nil {
+		return nil, err
+	}
+	if err := checkPeriod("dPeriod", dPeriod); err != nil {
+		return nil, err
+	}
+
+	return &Stochastic{
+		highest: newMax(kPeriod),
+		lowest:  newMin(kPeriod),
+		d:       SMA{w: newWindow(dPeriod)},
+	}, nil
+}
+
+func (s *Stochastic) Update(bar *model.StockAggregate) {
+	s.highest.push(float64(bar.High))
+	s.lowest.push(float64(bar.Low))
+	if s.highest.count < s.highest.period {
+		return
+	}
+
+	high, low := s.highest.value(), s.lowest.value()
+	if high == low {
+		s.k = 50
+	} else {
+		s.k = 100 * (float64(bar.Close) - low) / (high - low)
+	}
+	s.d.Add(s.k)
+}
+
+func (s *Stochastic) Value() float64 {
+	if !s.Ready() {
+		return 0
+	}
+	return s.k
+}
+
+// D returns %D.
+func (s *Stochastic) D() float64 {
+	return s.d.Value()
+}
+
+func (s *Stochastic) Ready() bool {
+	return s.d.Ready()
+}
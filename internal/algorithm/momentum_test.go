@@ -0,0 +1,113 @@
+package algorithm_test
+
+import (
+	"testing"
+
+	"github.com/Goboolean/core-system.worker/internal/algorithm"
+	"github.com/stretchr/testify/suite"
+)
+
+type MomentumTestSuite struct {
+	suite.Suite
+}
+
+func (suite *MomentumTestSuite) TestRSI_ShouldSmoothGainsAndLosses() {
+	//arrange
+	rsi, err := algorithm.NewRSI(3)
+	suite.Require().NoError(err)
+
+	//act
+	res, readyAt := feed(rsi, rsi.Value)
+
+	//assert
+	// 처음 3개 변화 (+1, +1, -2)의 평균 상승폭과 하락폭은 모두 2/3이다.
+	suite.Equal(4, readyAt)
+	suite.InDeltaSlice([]float64{0, 0, 0, 50, 400.0 / 11, 500.0 / 7, 77.6}, res, 1e-9)
+}
+
+func (suite *MomentumTestSuite) TestRSI_ShouldBe100_WhenThereIsNoLoss() {
+	//arrange
+	rsi, err := algorithm.NewRSI(2)
+	suite.Require().NoError(err)
+
+	//act
+	for _, e := range []float64{1, 2, 3, 3} {
+		rsi.Add(e)
+	}
+
+	//assert
+	suite.True(rsi.Ready())
+	suite.Equal(100.0, rsi.Value())
+}
+
+func (suite *MomentumTestSuite) TestMACD_ShouldSubtractSlowFromFast() {
+	//arrange
+	macd, err := algorithm.NewMACD(2, 3, 2)
+	suite.Require().NoError(err)
+
+	//act
+	line, readyAt := feed(macd, macd.Value)
+
+	//assert
+	suite.Equal(4, readyAt)
+	suite.InDeltaSlice([]float64{0, 0, 0, 0, -0.25, 7.0 / 24, 65.0 / 144}, line, 1e-9)
+	suite.InDelta(0.3564814814814816, macd.Signal(), 1e-9)
+	suite.InDelta(65.0/144-0.3564814814814816, macd.Histogram(), 1e-9)
+}
+
+func (suite *MomentumTestSuite) TestStochastic_ShouldLocateCloseInRange() {
+	//arrange
+	stochastic, err := algorithm.NewStochastic(3, 2)
+	suite.Require().NoError(err)
+
+	//act
+	k, readyAt := feed(stochastic, stochastic.Value)
+
+	//assert
+	// %K는 %D가 계산되는 4번째 bar부터 반환된다. 4번째 bar의 범위는 9~13이므로 %K = 100 * (10 - 9) / 4 = 25이다.
+	suite.Equal(4, readyAt)
+	suite.InDeltaSlice([]float64{0, 0, 0, 25, 20, 100, 250.0 / 3}, k, 1e-9)
+	suite.InDelta((100+250.0/3)/2, stochastic.D(), 1e-9)
+}
+
+func (suite *MomentumTestSuite) TestStochastic_ShouldMatchRecomputation_WhenStreamIsLong() {
+	//arrange
+	period := 14
+	stochastic, err := algorithm.NewStochastic(period, 1)
+	suite.Require().NoError(err)
+	stream := randomBars(10000)
+
+	for i := range stream {
+		//act
+		stochastic.Update(&stream[i])
+		if i < period-1 {
+			continue
+		}
+
+		//assert
+		high, low := float64(stream[i].High), float64(stream[i].Low)
+		for _, e := range stream[i-period+1 : i+1] {
+			high, low = max(high, float64(e.High)), min(low, float64(e.Low))
+		}
+		suite.Require().InDelta(100*(float64(stream[i].Close)-low)/(high-low), stochastic.Value(), 1e-6, i)
+	}
+}
+
+func (suite *MomentumTestSuite) TestNew_ShouldReturnError_WhenParamsAreInvalid() {
+	//act
+	_, rsiErr := algorithm.NewRSI(0)
+	_, fastErr := algorithm.NewMACD(0, 26, 9)
+	_, orderErr := algorithm.NewMACD(26, 12, 9)
+	_, signalErr := algorithm.NewMACD(12, 26, -1)
+	_, kErr := algorithm.NewStochastic(0, 3)
+	_, dErr := algorithm.NewStochastic(14, 0)
+
+	//assert
+	for _, err := range []error{rsiErr, fastErr, orderErr, signalErr, kErr, dErr} {
+		suite.ErrorIs(err, algorithm.ErrInvalidParam)
+	}
+}
+
+func TestMomentum(t *testing.T) {
+	suite.Run(t, new(MomentumTestSuite))
+}
@@ -0,0 +1,128 @@
+package algorithm
+
+import "github.com/Goboolean/core-system.worker/internal/model"
+
+// SMA is the simple moving average of the closes of the latest period bars.
+type SMA struct {
+	w window
+}
+
+// NewSMA creates new instance of SMA
+func NewSMA(period int) (*SMA, error) {
+	if err := checkPeriod("period", period); err != nil {
+		return nil, err
+	}
+	return &SMA{w: newWindow(period)}, nil
+}
+
+func (s *SMA) Update(bar *model.StockAggregate) {
+	s.Add(float64(bar.Close))
+}
+
+// Add feeds the latest value of a series other than the closes.
+func (s *SMA) Add(v float64) {
+	s.w.push(v)
+}
+
+func (s *SMA) Value() float64 {
+	if !s.Ready() {
+		return 0
+	}
+	return s.w.mean
+}
+
+func (s *SMA) Ready() bool {
+	return s.w.full()
+}
+
+// EMA is the exponential moving average of the closes with the smoothing factor 2 / (period + 1).
+// It is seeded by the simple moving average of the first period closes.
+type EMA struct {
+	seed  SMA
+	alpha float64
+	value float64
+	ready bool
+}
+
+// NewEMA creates new instance of EMA
+func NewEMA(period int) (*EMA, error) {
+	if err := checkPeriod("period", period); err != nil {
+		return nil, err
+	}
+	e := newEMA(period)
+	return &e, nil
+}
+
+func newEMA(period int) EMA {
+	return EMA{
+		seed:  SMA{w: newWindow(period)},
+		alpha: 2 / float64(period+1),
+	}
+}
+
+func (e *EMA) Update(bar *model.StockAggregate) {
+	e.Add(float64(bar.Close))
+}
+
+// Add feeds the latest value of a series other than the closes.
+func (e *EMA) Add(v float64) {
+	if e.ready {
+		e.value += e.alpha * (v - e.value)
+		return
+	}
+
+	e.seed.Add(v)
+	e.value, e.ready = e.seed.Value(), e.seed.Ready()
+}
+
+func (e *EMA) Value() float64 {
+	return e.value
+}
+
+func (e *EMA) Ready() bool {
+	return e.ready
+}
+
+// WMA is the linearly weighted moving average of the closes of the latest period bars.
+// The latest close has the weight period, and the oldest one has the weight 1.
+type WMA struct {
+	w window
+	// sum and weighted are the sum and the weighted sum of the values in the window.
+	sum      float64
+	weighted float64
+}
+
+// NewWMA creates new instance of WMA
+func NewWMA(period int) (*WMA, error) {
+	if err := checkPeriod("period", period); err != nil {
+		return nil, err
+	}
+	return &WMA{w: newWindow(period)}, nil
+}
+
+func (w *WMA) Update(bar *model.StockAggregate) {
+	w.Add(float64(bar.Close))
+}
+
+// Add feeds the latest value of a series other than the closes.
+func (w *WMA) Add(v float64) {
+	n := len(w.w.values)
+	old := w.w.values[w.w.count%n]
+	w.w.push(v)
+
+	// 새 값이 들어오면 기존 값의 가중치가 1씩 줄어든다. 창이 차기 전에는 비어 있는 자리를 0으로 본다.
+	w.weighted += float64(n)*v - w.sum
+	w.sum += v - old
+}
+
+func (w *WMA) Value() float64 {
+	if !w.Ready() {
+		return 0
+	}
+	n := float64(len(w.w.values))
+	return w.weighted / (n * (n + 1) / 2)
+}
+
+func (w *WMA) Ready() bool {
+	return w.w.full()
+}
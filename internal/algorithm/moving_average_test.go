@@ -0,0 +1,96 @@
+package algorithm_test
+
+import (
+	"testing"
+
+	"github.com/Goboolean/core-system.worker/internal/algorithm"
+	"github.com/stretchr/testify/suite"
+)
+
+type MovingAverageTestSuite struct {
+	suite.Suite
+}
+
+func (suite *MovingAverageTestSuite) TestSMA_ShouldAverageLatestCloses() {
+	//arrange
+	sma, err := algorithm.NewSMA(3)
+	suite.Require().NoError(err)
+
+	//act
+	res, readyAt := feed(sma, sma.Value)
+
+	//assert
+	suite.Equal(3, readyAt)
+	suite.InDeltaSlice([]float64{0, 0, 11, 11, 31.0 / 3, 31.0 / 3, 34.0 / 3}, res, 1e-9)
+}
+
+func (suite *MovingAverageTestSuite) TestEMA_ShouldBeSeededBySMA() {
+	//arrange
+	ema, err := algorithm.NewEMA(3)
+	suite.Require().NoError(err)
+
+	//act
+	res, readyAt := feed(ema, ema.Value)
+
+	//assert
+	// 첫 값은 처음 3개 종가의 평균이고, 이후에는 0.5씩 새 종가 쪽으로 움직인다.
+	suite.Equal(3, readyAt)
+	suite.InDeltaSlice([]float64{0, 0, 11, 10.5, 9.75, 10.875, 11.9375}, res, 1e-9)
+}
+
+func (suite *MovingAverageTestSuite) TestWMA_ShouldWeighLatestCloseMost() {
+	//arrange
+	wma, err := algorithm.NewWMA(3)
+	suite.Require().NoError(err)
+
+	//act
+	res, readyAt := feed(wma, wma.Value)
+
+	//assert
+	// (10 + 2 * 11 + 3 * 12) / 6 = 68 / 6
+	suite.Equal(3, readyAt)
+	suite.InDeltaSlice([]float64{0, 0, 68.0 / 6, 65.0 / 6, 59.0 / 6, 64.0 / 6, 12}, res, 1e-9)
+}
+
+func (suite *MovingAverageTestSuite) TestSMAAndWMA_ShouldMatchRecomputation_WhenStreamIsLong() {
+	//arrange
+	period := 20
+	sma, err := algorithm.NewSMA(period)
+	suite.Require().NoError(err)
+	wma, err := algorithm.NewWMA(period)
+	suite.Require().NoError(err)
+	stream := randomBars(100000)
+
+	//act
+	for i := range stream {
+		sma.Update(&stream[i])
+		wma.Update(&stream[i])
+	}
+
+	//assert
+	var sum, weighted float64
+	for i, e := range stream[len(stream)-period:] {
+		sum += float64(e.Close)
+		weighted += float64(i+1) * float64(e.Close)
+	}
+	suite.InDelta(sum/float64(period), sma.Value(), 1e-6)
+	suite.InDelta(weighted/float64(period*(period+1)/2), wma.Value(), 1e-6)
+}
+
+func (suite *MovingAverageTestSuite) TestNew_ShouldReturnError_WhenPeriodIsNotPositive() {
+	for _, period := range []int{0, -1} {
+		//act
+		_, smaErr := algorithm.NewSMA(period)
+		_, emaErr := algorithm.NewEMA(period)
+		_, wmaErr := algorithm.NewWMA(period)
+
+		//assert
+		suite.ErrorIs(smaErr, algorithm.ErrInvalidParam)
+		suite.ErrorIs(emaErr, algorithm.ErrInvalidParam)
+		suite.ErrorIs(wmaErr, algorithm.ErrInvalidParam)
+	}
+}
+
+func TestMovingAverage(t *testing.T) {
+	suite.Run(t, new(MovingAverageTestSuite))
+}
@@ -0,0 +1,98 @@
+package algorithm
+
+import (
+	"math"
+
+	"github.com/Goboolean/core-system.worker/internal/model"
+)
+
+// ADX is the average directional index of the bars by Wilder.
+//
+// The directional movements and the true range of each bar from the previous one are smoothed by Wilder's moving average,
+// and their ratios are the directional indicators PlusDI and MinusDI.
+// Value is ADX, Wilder's moving average of DX = 100 * |+DI - -DI| / (+DI + -DI).
+// DI is ready after period + 1 bars, and ADX is ready after 2 * period bars.
+type ADX struct {
+	plusDM  wilder
+	minusDM wilder
+	tr      wilder
+	adx     wilder
+
+	prev    model.StockAggregate
+	started bool
+}
+
+// NewADX creates new instance of ADX. The period commonly used is 14.
+func NewADX(period int) (*ADX, error) {
+	if err := checkPeriod("period", period); err != nil {
+		return nil, err
+	}
+	return &ADX{
+		plusDM:  wilder{period: period},
+		minusDM: wilder{period: period},
+		tr:      wilder{period: period},
+		adx:     wilder{period: period},
+	}, nil
+}
+
+func (a *ADX) Update(bar *model.StockAggregate) {
+	prev, started := a.prev, a.started
+	a.prev, a.started = *bar, true
+	if !started {
+		return
+	}
+
+	up := float64(bar.High - prev.High)
+	down := float64(prev.Low - bar.Low)
+	plus, minus := 0.0, 0.0
+	if up > down && up > 0 {
+		plus = up
+	}
+	if down > up && down > 0 {
+		minus = down
+	}
+
+	a.plusDM.push(plus)
+	a.minusDM.push(minus)
+	a.tr.push(trueRange(bar, float64(prev.Close)))
+
+	if a.tr.ready() {
+		a.adx.push(a.dx())
+	}
+}
+
+// dx returns the directional movement index of the latest bar.
+func (a *ADX) dx() float64 {
+	plus, minus := a.PlusDI(), a.MinusDI()
+	if plus+minus == 0 {
+		return 0
+	}
+	return 100 * math.Abs(plus-minus) / (plus + minus)
+}
+
+func (a *ADX) Value() float64 {
+	if !a.Ready() {
+		return 0
+	}
+	return a.adx.value
+}
+
+// PlusDI returns the positive directional indicator.
+func (a *ADX) PlusDI() float64 {
+	if !a.tr.ready() || a.tr.value == 0 {
+		return 0
+	}
+	return 100 * a.plusDM.value / a.tr.value
+}
+
+// MinusDI returns the negative directional indicator.
+func (a *ADX) MinusDI() float64 {
+	if !a.tr.ready() || a.tr.value == 0 {
+		return 0
+	}
+	return 100 * a.minusDM.value / a.tr.value
+}
+
+func (a *ADX) Ready() bool {
+	return a.adx.ready()
+}
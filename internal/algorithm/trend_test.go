@@ -0,0 +1,44 @@
+package algorithm_test
+
+import (
+	"testing"
+
+	"github.com/Goboolean/core-system.worker/internal/algorithm"
+	"github.com/stretchr/testify/suite"
+)
+
+type TrendTestSuite struct {
+	suite.Suite
+}
+
+func (suite *TrendTestSuite) TestADX_ShouldSmoothDirectionalIndex() {
+	//arrange
+	adx, err := algorithm.NewADX(3)
+	suite.Require().NoError(err)
+	di, err := algorithm.NewADX(3)
+	suite.Require().NoError(err)
+
+	//act
+	res, readyAt := feed(adx, adx.Value)
+	plusDI, _ := feed(di, di.PlusDI)
+
+	//assert
+	// +DM은 1, 1, 0, 0, 1, 2이고 -DM은 0, 0, 1, 1, 0, 0이다.
+	// DX는 4번째 bar부터 100/3, 100/9, 700/27, 1700/27이다.
+	suite.InDeltaSlice([]float64{0, 0, 0, 25, 16, 1700.0 / 77, 1760.0 / 47}, plusDI, 1e-9)
+	suite.InDelta(400.0/47, di.MinusDI(), 1e-9)
+	suite.Equal(6, readyAt)
+	suite.InDeltaSlice([]float64{0, 0, 0, 0, 0, 1900.0 / 81, 8900.0 / 243}, res, 1e-9)
+}
+
+func (suite *TrendTestSuite) TestNewADX_ShouldReturnError_WhenPeriodIsNotPositive() {
+	//act
+	_, err := algorithm.NewADX(0)
+
+	//assert
+	suite.ErrorIs(err, algorithm.ErrInvalidParam)
+}
+
+func TestTrend(t *testing.T) {
+	suite.Run(t, new(TrendTestSuite))
+}
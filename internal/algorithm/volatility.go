@@ -0,0 +1,138 @@
+package algorithm
+
+import (
+	"fmt"
+
+	"github.com/Goboolean/core-system.worker/internal/model"
+)
+
+// Bollinger is the Bollinger bands of the closes.
+// Value is the middle band, the simple moving average of the latest period closes,
+// and the upper and the lower bands are k population standard deviations away from it.
+type Bollinger struct {
+	w window
+	k float64
+}
+
+// NewBollinger creates new instance of Bollinger. The parameters commonly used are 20 and 2.
+func NewBollinger(period int, k float64) (*Bollinger, error) {
+	if err := checkPeriod("period", period); err != nil {
+		return nil, err
+	}
+	if k <= 0 {
+		return nil, fmt.Errorf("%w: k must be positive, got %v", ErrInvalidParam, k)
+	}
+	return &Bollinger{w: newWindow(period), k: k}, nil
+}
+
+func (b *Bollinger) Update(bar *model.StockAggregate) {
+	b.Add(float64(bar.Close))
+}
+
+// Add feeds the latest value of a series other than the closes.
+func (b *Bollinger) Add(v float64) {
+	b.w.push(v)
+}
+
+func (b *Bollinger) Value() float64 {
+	if !b.Ready() {
+		return 0
+	}
+	return b.w.mean
+}
+
+// Upper returns the upper band.
+func (b *Bollinger) Upper() float64 {
+	if !b.Ready() {
+		return 0
+	}
+	return b.w.mean + b.k*b.w.std()
+}
+
+// Lower returns the lower band.
+func (b *Bollinger) Lower() float64 {
+	if !b.Ready() {
+		return 0
+	}
+	return b.w.mean - b.k*b.w.std()
+}
+
+func (b *Bollinger) Ready() bool {
+	return b.w.full()
+}
+
+// ATR is the average true range of the bars with Wilder's smoothing.
+// The true range needs the previous close, so the first bar only sets it and ATR is ready after period + 1 bars.
+type ATR struct {
+	tr wilder
+
+	prevClose float64
+	started   bool
+}
+
+// NewATR creates new instance of ATR
+func NewATR(period int) (*ATR, error) {
+	if err := checkPeriod("period", period); err != nil {
+		return nil, err
+	}
+	return &ATR{tr: wilder{period: period}}, nil
+}
+
+func (a *ATR) Update(bar *model.StockAggregate) {
+	if a.started {
+		a.tr.push(trueRange(bar, a.prevClose))
+	}
+	a.prevClose, a.started = float64(bar.Close), true
+}
+
+func (a *ATR) Value() float64 {
+	if !a.Ready() {
+		return 0
+	}
+	return a.tr.value
+}
+
+func (a *ATR) Ready() bool {
+	return a.tr.ready()
+}
+
+// ZScore is the rolling z-score of the close, the distance of the latest close from the mean of the latest period closes
+// in population standard deviations. It is 0 when the standard deviation is zero.
+type ZScore struct {
+	w      window
+	latest float64
+}
+
+// NewZScore creates new instance of ZScore
+func NewZScore(period int) (*ZScore, error) {
+	if err := checkPeriod("period", period); err != nil {
+		return nil, err
+	}
+	return &ZScore{w: newWindow(period)}, nil
+}
+
+func (z *ZScore) Update(bar *model.StockAggregate) {
+	z.Add(float64(bar.Close))
+}
+
+// Add feeds the latest value of a series other than the closes.
+func (z *ZScore) Add(v float64) {
+	z.w.push(v)
+	z.latest = v
+}
+
+func (z *ZScore) Value() float64 {
+	if !z.Ready() {
+		return 0
+	}
+
+	std := z.w.std()
+	if std == 0 {
+		return 0
+	}
+	return (z.latest - z.w.mean) / std
+}
+
+func (z *ZScore) Ready() bool {
+	return z.w.full()
+}
@@ -0,0 +1,115 @@
+package algorithm_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Goboolean/core-system.worker/internal/algorithm"
+	"github.com/stretchr/testify/suite"
+)
+
+type VolatilityTestSuite struct {
+	suite.Suite
+}
+
+func (suite *VolatilityTestSuite) TestBollinger_ShouldBandMiddleByStandardDeviations() {
+	//arrange
+	bollinger, err := algorithm.NewBollinger(3, 2)
+	suite.Require().NoError(err)
+
+	//act
+	middle, readyAt := feed(bollinger, bollinger.Value)
+
+	//assert
+	// 마지막 3개 종가 (9, 12, 13)의 표준편차는 sqrt(26 / 9)이다.
+	suite.Equal(3, readyAt)
+	suite.InDeltaSlice([]float64{0, 0, 11, 11, 31.0 / 3, 31.0 / 3, 34.0 / 3}, middle, 1e-9)
+	suite.InDelta(34.0/3+2*math.Sqrt(26.0/9), bollinger.Upper(), 1e-9)
+	suite.InDelta(34.0/3-2*math.Sqrt(26.0/9), bollinger.Lower(), 1e-9)
+}
+
+func (suite *VolatilityTestSuite) TestATR_ShouldSmoothTrueRanges() {
+	//arrange
+	atr, err := algorithm.NewATR(3)
+	suite.Require().NoError(err)
+
+	//act
+	res, readyAt := feed(atr, atr.Value)
+
+	//assert
+	// true range는 2, 3, 3, 3, 3, 3이다.
+	suite.Equal(4, readyAt)
+	suite.InDeltaSlice([]float64{0, 0, 0, 8.0 / 3, 25.0 / 9, 77.0 / 27, 235.0 / 81}, res, 1e-9)
+}
+
+func (suite *VolatilityTestSuite) TestZScore_ShouldMeasureDistanceFromMean() {
+	//arrange
+	zscore, err := algorithm.NewZScore(3)
+	suite.Require().NoError(err)
+
+	//act
+	res, readyAt := feed(zscore, zscore.Value)
+
+	//assert
+	suite.Equal(3, readyAt)
+	suite.InDeltaSlice([]float64{
+		0, 0,
+		1.224744871391589, -1.224744871391589, -1.069044967649698,
+		1.3363062095621216, 0.9805806756909198,
+	}, res, 1e-9)
+}
+
+func (suite *VolatilityTestSuite) TestZScore_ShouldBeZero_WhenValuesAreConstant() {
+	//arrange
+	zscore, err := algorithm.NewZScore(2)
+	suite.Require().NoError(err)
+
+	//act
+	zscore.Add(1)
+	zscore.Add(1)
+
+	//assert
+	suite.True(zscore.Ready())
+	suite.Equal(0.0, zscore.Value())
+}
+
+func (suite *VolatilityTestSuite) TestZScore_ShouldMatchRecomputation_WhenStreamIsLong() {
+	//arrange
+	period := 20
+	zscore, err := algorithm.NewZScore(period)
+	suite.Require().NoError(err)
+	stream := randomBars(100000)
+
+	//act
+	for i := range stream {
+		zscore.Update(&stream[i])
+	}
+
+	//assert
+	var mean, variance float64
+	last := stream[len(stream)-period:]
+	for _, e := range last {
+		mean += float64(e.Close) / float64(period)
+	}
+	for _, e := range last {
+		variance += math.Pow(float64(e.Close)-mean, 2) / float64(period)
+	}
+	suite.InDelta((float64(last[period-1].Close)-mean)/math.Sqrt(variance), zscore.Value(), 1e-6)
+}
+
+func (suite *VolatilityTestSuite) TestNew_ShouldReturnError_WhenParamsAreInvalid() {
+	//act
+	_, periodErr := algorithm.NewBollinger(0, 2)
+	_, kErr := algorithm.NewBollinger(20, 0)
+	_, atrErr := algorithm.NewATR(-1)
+	_, zscoreErr := algorithm.NewZScore(0)
+
+	//assert
+	for _, err := range []error{periodErr, kErr, atrErr, zscoreErr} {
+		suite.ErrorIs(err, algorithm.ErrInvalidParam)
+	}
+}
+
+func TestVolatility(t *testing.T) {
+	suite.Run(t, new(VolatilityTestSuite))
+}
@@ -0,0 +1,70 @@
+package algorithm
+
+import "github.com/Goboolean/core-system.worker/internal/model"
+
+// OBV is the on-balance volume of the bars.
+// The volume of a bar is added when the close rises from the previous one and subtracted when it falls.
+// OBV starts from 0 at the first bar.
+type OBV struct {
+	value     float64
+	prevClose float32
+	started   bool
+}
+
+// NewOBV creates new instance of OBV
+func NewOBV() *OBV {
+	return &OBV{}
+}
+
+func (o *OBV) Update(bar *model.StockAggregate) {
+	switch {
+	case !o.started:
+	case bar.Close > o.prevClose:
+		o.value += float64(bar.Volume)
+	case bar.Close < o.prevClose:
+		o.value -= float64(bar.Volume)
+	}
+	o.prevClose, o.started = bar.Close, true
+}
+
+func (o *OBV) Value() float64 {
+	return o.value
+}
+
+func (o *OBV) Ready() bool {
+	return o.started
+}
+
+// VWAP is the volume weighted average of the typical prices (high + low + close) / 3 of the bars since the session started.
+// It is ready once a bar with volume is fed.
+type VWAP struct {
+	priceVolume float64
+	volume      float64
+}
+
+// NewVWAP creates new instance of VWAP
+func NewVWAP() *VWAP {
+	return &VWAP{}
+}
+
+func (v *VWAP) Update(bar *model.StockAggregate) {
+	typical := (float64(bar.High) + float64(bar.Low) + float64(bar.Close)) / 3
+	v.priceVolume += typical * float64(bar.Volume)
+	v.volume += float64(bar.Volume)
+}
+
+// Reset starts a new session.
+func (v *VWAP) Reset() {
+	v.priceVolume, v.volume = 0, 0
+}
+
+func (v *VWAP) Value() float64 {
+	if !v.Ready() {
+		return 0
+	}
+	return v.priceVolume / v.volume
+}
+
+func (v *VWAP) Ready() bool {
+	return v.volume > 0
+}
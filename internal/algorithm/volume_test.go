@@ -0,0 +1,58 @@
+package algorithm_test
+
+import (
+	"testing"
+
+	"github.com/Goboolean/core-system.worker/internal/algorithm"
+	"github.com/stretchr/testify/suite"
+)
+
+type VolumeTestSuite struct {
+	suite.Suite
+}
+
+func (suite *VolumeTestSuite) TestOBV_ShouldAccumulateVolumeByDirectionOfClose() {
+	//arrange
+	obv := algorithm.NewOBV()
+
+	//act
+	res, readyAt := feed(obv, obv.Value)
+
+	//assert
+	suite.Equal(1, readyAt)
+	suite.Equal([]float64{0, 200, 350, 50, -200, -100, 100}, res)
+}
+
+func (suite *VolumeTestSuite) TestVWAP_ShouldWeighTypicalPricesByVolume() {
+	//arrange
+	vwap := algorithm.NewVWAP()
+
+	//act
+	res, readyAt := feed(vwap, vwap.Value)
+
+	//assert
+	// 2번째 bar까지: (10 * 100 + 11 * 200) / 300
+	suite.Equal(1, readyAt)
+	suite.InDeltaSlice([]float64{
+		10, 32.0 / 3, 11, 10.733333333333333, 10.383333333333335,
+		10.43939393939394, 10.782051282051283,
+	}, res, 1e-9)
+}
+
+func (suite *VolumeTestSuite) TestVWAP_ShouldStartOver_WhenReset() {
+	//arrange
+	vwap := algorithm.NewVWAP()
+	vwap.Update(&bars[0])
+
+	//act
+	vwap.Reset()
+
+	//assert
+	suite.False(vwap.Ready())
+	vwap.Update(&bars[1])
+	suite.InDelta(11, vwap.Value(), 1e-9)
+}
+
+func TestVolume(t *testing.T) {
+	suite.Run(t, new(VolumeTestSuite))
+}
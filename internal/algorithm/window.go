@@ -0,0 +1,114 @@
+package algorithm
+
+import "math"
+
+// window is a ring buffer of the latest values.
+// It keeps the mean and the sum of squared deviations of the values by Welford's method,
+// which stays accurate when the values are large compared to their deviations.
+type window struct {
+	values []float64
+	count  int
+
+	mean float64
+	m2   float64
+}
+
+func newWindow(period int) window {
+	return window{values: make([]float64, period)}
+}
+
+// push adds v to the window and drops the oldest value once the window is full.
+func (w *window) push(v float64) {
+	i := w.count % len(w.values)
+	old := w.values[i]
+	w.values[i] = v
+	w.count++
+
+	if w.count <= len(w.values) {
+		delta := v - w.mean
+		w.mean += delta / float64(w.count)
+		w.m2 += delta * (v - w.mean)
+		return
+	}
+
+	prevMean := w.mean
+	w.mean += (v - old) / float64(len(w.values))
+	w.m2 += (v - old) * (v - w.mean + old - prevMean)
+}
+
+func (w *window) full() bool {
+	return w.count >= len(w.values)
+}
+
+// std returns the population standard deviation of the values.
+func (w *window) std() float64 {
+	n := min(w.count, len(w.values))
+	if n == 0 {
+		return 0
+	}
+	// 누적 오차로 음수가 되는 것을 막는다.
+	return math.Sqrt(max(w.m2, 0) / float64(n))
+}
+
+// extremum tracks the maximum or the minimum of the latest values by a monotonic deque.
+// Each value enters and leaves the deque at most once, so push takes amortized O(1) time.
+type extremum struct {
+	period int
+	// better reports whether a is to be kept over b.
+	better func(a, b float64) bool
+
+	deque []indexedValue
+	count int
+}
+
+type indexedValue struct {
+	index int
+	value float64
+}
+
+func newMax(period int) extremum {
+	return extremum{period: period, better: func(a, b float64) bool { return a >= b }}
+}
+
+func newMin(period int) extremum {
+	return extremum{period: period, better: func(a, b float64) bool { return a <= b }}
+}
+
+func (e *extremum) push(v float64) {
+	for len(e.deque) > 0 && !e.better(e.deque[len(e.deque)-1].value, v) {
+		e.deque = e.deque[:len(e.deque)-1]
+	}
+	e.deque = append(e.deque, indexedValue{index: e.count, value: v})
+	e.count++
+
+	if e.deque[0].index <= e.count-1-e.period {
+		e.deque = e.deque[1:]
+	}
+}
+
+func (e *extremum) value() float64 {
+	if len(e.deque) == 0 {
+		return 0
+	}
+	return e.deque[0].value
+}
+
+// wilder is Wilder's moving average seeded by the simple average of the first period values.
+type wilder struct {
+	period int
+	value  float64
+	count  int
+}
+
+func (w *wilder) push(v float64) {
+	w.count++
+	if w.count <= w.period {
+		w.value += (v - w.value) / float64(w.count)
+		return
+	}
+	w.value += (v - w.value) / float64(w.period)
+}
+
+func (w *wilder) ready() bool {
+	return w.count >= w.period
+}
@@ -0,0 +1,368 @@
+package barcache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	infraModel "github.com/Goboolean/fetch-system.IaC/pkg/model"
+)
+
+const indexFileName = "index.json"
+
+// resolution is the smallest interval between two bars that the cache distinguishes.
+// The data source truncates the start of a query to seconds.
+const resolution = time.Second
+
+var ErrInvalidOpts = errors.New("bar cache: invalid options")
+
+// Source is a data source containing past trading data.
+type Source interface {
+	FetchLimitedTradeAfter(ctx context.Context, productID string, timeFrame string, start time.Time, limit int) ([]*infraModel.StockAggregate, error)
+	Close() error
+}
+
+// Opts is the options of Cache.
+type Opts struct {
+	// Dir is the directory where the cached bars are stored.
+	Dir string
+	// MaxBytes is the upper bound of the total size of the cached bars.
+	// The least recently used ranges are evicted when it is exceeded.
+	// Zero means unlimited.
+	MaxBytes int64
+}
+
+// Cache is a Source that keeps the bars fetched from another Source on the local disk.
+//
+// Cached bars are stored per time range of a product and time frame.
+// A range guarantees that it contains every bar whose time is in the range,
+// so repeated requests are served from the disk
+// and only the part of a request that is not covered by any range is fetched from the source.
+//
+// The index of the ranges is loaded once when the Cache is created and kept in memory.
+// It is written back to the disk only when the Cache is closed,
+// so two Caches must not share the same directory at the same time.
+type Cache struct {
+	source Source
+	opts   Opts
+
+	mu    sync.Mutex
+	idx   index
+	dirty bool
+}
+
+// segment is a time range of a series whose bars are all stored in File.
+type segment struct {
+	From       time.Time `json:"from"`
+	To         time.Time `json:"to"`
+	File       string    `json:"file"`
+	Size       int64     `json:"size"`
+	LastAccess time.Time `json:"lastAccess"`
+}
+
+func (s *segment) contains(t time.Time) bool {
+	return !t.Before(s.From) && !t.After(s.To)
+}
+
+// index maps the key of a series to its segments sorted by From.
+type index map[string][]*segment
+
+// New creates a new Cache in front of source.
+func New(source Source, opts Opts) (*Cache, error) {
+	if opts.Dir == "" || opts.MaxBytes < 0 {
+		return nil, ErrInvalidOpts
+	}
+
+	if err := os.MkdirAll(opts.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create bar cache: %w", err)
+	}
+
+	c := &Cache{
+		source: source,
+		opts:   opts,
+	}
+
+	idx, err := c.loadIndex()
+	if err != nil {
+		return nil, fmt.Errorf("create bar cache: %w", err)
+	}
+	c.idx = idx
+
+	return c, nil
+}
+
+// FetchLimitedTradeAfter returns at most limit bars of the product whose time is at or after start.
+// Bars covered by cached ranges are read from the disk and the others are fetched from the source and cached.
+func (c *Cache) FetchLimitedTradeAfter(ctx context.Context, productID string, timeFrame string, start time.Time, limit int) ([]*infraModel.StockAggregate, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// 읽기만 해도 LastAccess가 바뀌므로 항상 index를 다시 저장해야 한다.
+	c.dirty = true
+
+	idx := c.idx
+	key := seriesKey(productID, timeFrame)
+	res := make([]*infraModel.StockAggregate, 0, limit)
+	cur := start.Truncate(resolution)
+
+	for len(res) < limit {
+		if seg := findSegment(idx[key], cur); seg != nil {
+			bars, err := c.readSegment(seg)
+			if errors.Is(err, os.ErrNotExist) {
+				// 저장되지 않은 index가 가리키던 파일은 없을 수 있으므로 다시 가져온다.
+				removeSegment(idx, key, seg)
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("fetch cached trade: %w", err)
+			}
+
+			for _, e := range bars {
+				if len(res) == limit {
+					break
+				}
+				if !e.Time.Before(cur) {
+					res = append(res, e)
+				}
+			}
+			cur = seg.To.Add(resolution)
+			continue
+		}
+
+		need := limit - len(res)
+		bars, err := c.source.FetchLimitedTradeAfter(ctx, productID, timeFrame, cur, need)
+		if err != nil {
+			return nil, err
+		}
+		if len(bars) == 0 {
+			break
+		}
+
+		// The fetched bars must not overlap the next cached range.
+		exhausted := len(bars) < need
+		to := bars[len(bars)-1].Time
+		if next := nextSegment(idx[key], cur); next != nil && !next.From.After(to) {
+			i := sort.Search(len(bars), func(i int) bool { return !bars[i].Time.Before(next.From) })
+			bars = bars[:i]
+			to = next.From.Add(-resolution)
+			exhausted = false
+		}
+
+		if len(bars) > 0 {
+			if err := c.writeSegment(idx, key, productID, timeFrame, cur, to, bars); err != nil {
+				return nil, fmt.Errorf("fetch cached trade: %w", err)
+			}
+		}
+		res = append(res, bars...)
+
+		if exhausted {
+			break
+		}
+		cur = to.Add(resolution)
+	}
+
+	if err := c.evict(idx); err != nil {
+		return nil, fmt.Errorf("fetch cached trade: %w", err)
+	}
+
+	return res, nil
+}
+
+// Close writes the index back to the disk and closes the source.
+func (c *Cache) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var err error
+	if c.dirty {
+		if err = c.saveIndex(c.idx); err != nil {
+			err = fmt.Errorf("close bar cache: %w", err)
+		} else {
+			c.dirty = false
+		}
+	}
+
+	return errors.Join(err, c.source.Close())
+}
+
+// Size returns the total size of the cached bars.
+func (c *Cache) Size() (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var total int64
+	for _, segs := range c.idx {
+		for _, s := range segs {
+			total += s.Size
+		}
+	}
+	return total, nil
+}
+
+func seriesKey(productID, timeFrame string) string {
+	return productID + "." + timeFrame
+}
+
+// segmentFileName returns a content address of the given range of the series.
+func segmentFileName(productID, timeFrame string, from, to time.Time) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%s\x00%d\x00%d", productID, timeFrame, from.UnixNano(), to.UnixNano())))
+	return hex.EncodeToString(sum[:]) + ".json"
+}
+
+func findSegment(segs []*segment, t time.Time) *segment {
+	for _, s := range segs {
+		if s.contains(t) {
+			return s
+		}
+	}
+	return nil
+}
+
+// nextSegment returns the first segment starting after t.
+func nextSegment(segs []*segment, t time.Time) *segment {
+	for _, s := range segs {
+		if s.From.After(t) {
+			return s
+		}
+	}
+	return nil
+}
+
+func (c *Cache) readSegment(s *segment) ([]*infraModel.StockAggregate, error) {
+	b, err := os.ReadFile(filepath.Join(c.opts.Dir, s.File))
+	if err != nil {
+		return nil, err
+	}
+
+	var bars []*infraModel.StockAggregate
+	if err := json.Unmarshal(b, &bars); err != nil {
+		return nil, err
+	}
+
+	s.LastAccess = time.Now()
+	return bars, nil
+}
+
+func (c *Cache) writeSegment(idx index, key, productID, timeFrame string, from, to time.Time, bars []*infraModel.StockAggregate) error {
+	b, err := json.Marshal(bars)
+	if err != nil {
+		return err
+	}
+
+	name := segmentFileName(productID, timeFrame, from, to)
+	if err := writeFileAtomic(filepath.Join(c.opts.Dir, name), b); err != nil {
+		return err
+	}
+
+	segs := append(idx[key], &segment{
+		From:       from,
+		To:         to,
+		File:       name,
+		Size:       int64(len(b)),
+		LastAccess: time.Now(),
+	})
+	sort.Slice(segs, func(i, j int) bool { return segs[i].From.Before(segs[j].From) })
+	idx[key] = segs
+	return nil
+}
+
+// evict removes the least recently used segments until the total size fits in MaxBytes.
+func (c *Cache) evict(idx index) error {
+	if c.opts.MaxBytes == 0 {
+		return nil
+	}
+
+	type entry struct {
+		key string
+		seg *segment
+	}
+
+	var total int64
+	entries := make([]entry, 0)
+	for k, segs := range idx {
+		for _, s := range segs {
+			total += s.Size
+			entries = append(entries, entry{key: k, seg: s})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].seg.LastAccess.Before(entries[j].seg.LastAccess)
+	})
+
+	for _, e := range entries {
+		if total <= c.opts.MaxBytes {
+			break
+		}
+
+		if err := os.Remove(filepath.Join(c.opts.Dir, e.seg.File)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+
+		removeSegment(idx, e.key, e.seg)
+		total -= e.seg.Size
+	}
+
+	return nil
+}
+
+func removeSegment(idx index, key string, seg *segment) {
+	segs := idx[key]
+	for i, s := range segs {
+		if s == seg {
+			idx[key] = append(segs[:i], segs[i+1:]...)
+			return
+		}
+	}
+}
+
+func (c *Cache) loadIndex() (index, error) {
+	b, err := os.ReadFile(filepath.Join(c.opts.Dir, indexFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return index{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	idx := index{}
+	if err := json.Unmarshal(b, &idx); err != nil {
+		return nil, err
+	}
+	return idx, nil
+}
+
+func (c *Cache) saveIndex(idx index) error {
+	b, err := json.Marshal(idx)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(c.opts.Dir, indexFileName), b)
+}
+
+// writeFileAtomic writes data to a temporary file and renames it to name,
+// so that readers never see a partially written file.
+func writeFileAtomic(name string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(name), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), name)
+}
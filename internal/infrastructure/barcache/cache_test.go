@@ -0,0 +1,230 @@
+package barcache_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Goboolean/core-system.worker/internal/infrastructure/barcache"
+	infraModel "github.com/Goboolean/fetch-system.IaC/pkg/model"
+	"github.com/stretchr/testify/suite"
+)
+
+// countingSource serves bars from memory and records the requests it received.
+type countingSource struct {
+	bars     []*infraModel.StockAggregate
+	requests []request
+}
+
+type request struct {
+	start time.Time
+	limit int
+}
+
+func (s *countingSource) FetchLimitedTradeAfter(ctx context.Context, productID string, timeFrame string, start time.Time, limit int) ([]*infraModel.StockAggregate, error) {
+	s.requests = append(s.requests, request{start: start, limit: limit})
+
+	res := make([]*infraModel.StockAggregate, 0, limit)
+	for _, e := range s.bars {
+		if len(res) == limit {
+			break
+		}
+		if !e.Time.Before(start) {
+			res = append(res, e)
+		}
+	}
+	return res, nil
+}
+
+func (s *countingSource) Close() error {
+	return nil
+}
+
+func newCountingSource(start time.Time, num int) *countingSource {
+	bars := make([]*infraModel.StockAggregate, num)
+	for i := 0; i < num; i++ {
+		bars[i] = &infraModel.StockAggregate{
+			Open:   float64(i),
+			Close:  float64(i),
+			High:   float64(i),
+			Low:    float64(i),
+			Volume: float64(i),
+			Time:   start.Add(time.Duration(i) * time.Minute).UTC(),
+		}
+	}
+	return &countingSource{bars: bars}
+}
+
+type CacheTestSuite struct {
+	suite.Suite
+	start  time.Time
+	source *countingSource
+}
+
+func (suite *CacheTestSuite) SetupTest() {
+	suite.start = time.Unix(1720396800, 0).UTC()
+	suite.source = newCountingSource(suite.start, 100)
+}
+
+func (suite *CacheTestSuite) newCache(opts barcache.Opts) *barcache.Cache {
+	if opts.Dir == "" {
+		opts.Dir = suite.T().TempDir()
+	}
+	c, err := barcache.New(suite.source, opts)
+	suite.Require().NoError(err)
+	return c
+}
+
+func (suite *CacheTestSuite) TestCache_ShouldServeFromDisk_WhenSameRangeIsRequestedAgain() {
+	//arrange
+	dir := suite.T().TempDir()
+	first := suite.newCache(barcache.Opts{Dir: dir})
+
+	exp, err := first.FetchLimitedTradeAfter(context.Background(), "stock.aapl.usa", "1m", suite.start, 30)
+	suite.Require().NoError(err)
+	suite.Require().Len(suite.source.requests, 1)
+	suite.Require().NoError(first.Close())
+
+	//act
+	// 새로 생성한 Cache도 디스크에 저장된 데이터를 사용해야 한다.
+	second := suite.newCache(barcache.Opts{Dir: dir})
+	res, err := second.FetchLimitedTradeAfter(context.Background(), "stock.aapl.usa", "1m", suite.start, 30)
+
+	//assert
+	suite.NoError(err)
+	suite.Equal(exp, res)
+	suite.Len(suite.source.requests, 1)
+}
+
+func (suite *CacheTestSuite) TestCache_ShouldFetchOnlyUncoveredPart_WhenRangeOverlapsPartially() {
+	//arrange
+	c := suite.newCache(barcache.Opts{})
+	_, err := c.FetchLimitedTradeAfter(context.Background(), "stock.aapl.usa", "1m", suite.start.Add(10*time.Minute), 10)
+	suite.Require().NoError(err)
+
+	//act
+	res, err := c.FetchLimitedTradeAfter(context.Background(), "stock.aapl.usa", "1m", suite.start, 30)
+
+	//assert
+	suite.NoError(err)
+	suite.Equal(suite.source.bars[:30], res)
+	suite.Equal([]request{
+		{start: suite.start.Add(10 * time.Minute), limit: 10},
+		// [0, 10) 구간만 가져온 뒤 캐시된 [10, 20) 구간 이후부터 다시 가져온다.
+		{start: suite.start, limit: 30},
+		{start: suite.start.Add(19*time.Minute + time.Second), limit: 10},
+	}, suite.source.requests)
+
+	// 모든 구간이 캐시됐으므로 source에 요청하지 않아야 한다.
+	res, err = c.FetchLimitedTradeAfter(context.Background(), "stock.aapl.usa", "1m", suite.start.Add(5*time.Minute), 20)
+	suite.NoError(err)
+	suite.Equal(suite.source.bars[5:25], res)
+	suite.Len(suite.source.requests, 3)
+}
+
+func (suite *CacheTestSuite) TestCache_ShouldReturnRemainingData_WhenSourceIsExhausted() {
+	//arrange
+	c := suite.newCache(barcache.Opts{})
+
+	//act
+	res, err := c.FetchLimitedTradeAfter(context.Background(), "stock.aapl.usa", "1m", suite.start.Add(90*time.Minute), 20)
+
+	//assert
+	suite.NoError(err)
+	suite.Equal(suite.source.bars[90:], res)
+}
+
+func (suite *CacheTestSuite) TestCache_ShouldSeparateSeries_WhenProductOrTimeFrameDiffers() {
+	//arrange
+	c := suite.newCache(barcache.Opts{})
+	_, err := c.FetchLimitedTradeAfter(context.Background(), "stock.aapl.usa", "1m", suite.start, 10)
+	suite.Require().NoError(err)
+
+	//act
+	_, err = c.FetchLimitedTradeAfter(context.Background(), "stock.goog.usa", "1m", suite.start, 10)
+	suite.Require().NoError(err)
+	_, err = c.FetchLimitedTradeAfter(context.Background(), "stock.aapl.usa", "5m", suite.start, 10)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.Len(suite.source.requests, 3)
+}
+
+func (suite *CacheTestSuite) TestCache_ShouldEvictLeastRecentlyUsedRange_WhenSizeExceedsMaxBytes() {
+	//arrange
+	probe := suite.newCache(barcache.Opts{})
+	_, err := probe.FetchLimitedTradeAfter(context.Background(), "stock.aapl.usa", "1m", suite.start, 10)
+	suite.Require().NoError(err)
+	size, err := probe.Size()
+	suite.Require().NoError(err)
+
+	c := suite.newCache(barcache.Opts{MaxBytes: size + size/2})
+	suite.source.requests = nil
+
+	//act
+	_, err = c.FetchLimitedTradeAfter(context.Background(), "stock.aapl.usa", "1m", suite.start, 10)
+	suite.Require().NoError(err)
+	_, err = c.FetchLimitedTradeAfter(context.Background(), "stock.aapl.usa", "1m", suite.start.Add(50*time.Minute), 10)
+	suite.Require().NoError(err)
+	_, err = c.FetchLimitedTradeAfter(context.Background(), "stock.aapl.usa", "1m", suite.start, 10)
+	suite.Require().NoError(err)
+
+	//assert
+	total, err := c.Size()
+	suite.NoError(err)
+	suite.LessOrEqual(total, size+size/2)
+	// 첫 번째 구간은 두 번째 구간을 저장할 때 제거되므로 다시 가져와야 한다.
+	suite.Len(suite.source.requests, 3)
+}
+
+func (suite *CacheTestSuite) TestCache_ShouldWriteIndexOnlyOnClose_WhenManyRangesAreFetched() {
+	//arrange
+	dir := suite.T().TempDir()
+	c := suite.newCache(barcache.Opts{Dir: dir})
+
+	//act
+	for i := 0; i < 10; i++ {
+		_, err := c.FetchLimitedTradeAfter(context.Background(), "stock.aapl.usa", "1m", suite.start.Add(time.Duration(i*10)*time.Minute), 10)
+		suite.Require().NoError(err)
+	}
+
+	//assert
+	_, err := os.Stat(filepath.Join(dir, "index.json"))
+	suite.ErrorIs(err, os.ErrNotExist)
+
+	suite.Require().NoError(c.Close())
+	_, err = os.Stat(filepath.Join(dir, "index.json"))
+	suite.NoError(err)
+}
+
+func (suite *CacheTestSuite) TestCache_ShouldFetchAgain_WhenCachedFileIsMissing() {
+	//arrange
+	dir := suite.T().TempDir()
+	first := suite.newCache(barcache.Opts{Dir: dir})
+	_, err := first.FetchLimitedTradeAfter(context.Background(), "stock.aapl.usa", "1m", suite.start, 10)
+	suite.Require().NoError(err)
+	suite.Require().NoError(first.Close())
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	suite.Require().NoError(err)
+	for _, f := range files {
+		if filepath.Base(f) != "index.json" {
+			suite.Require().NoError(os.Remove(f))
+		}
+	}
+
+	//act
+	second := suite.newCache(barcache.Opts{Dir: dir})
+	res, err := second.FetchLimitedTradeAfter(context.Background(), "stock.aapl.usa", "1m", suite.start, 10)
+
+	//assert
+	suite.NoError(err)
+	suite.Equal(suite.source.bars[:10], res)
+	suite.Len(suite.source.requests, 2)
+}
+
+func TestCache(t *testing.T) {
+	suite.Run(t, new(CacheTestSuite))
+}
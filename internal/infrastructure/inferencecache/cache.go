@@ -0,0 +1,489 @@
+// Package inferencecache keeps the responses of an inference service on the local disk,
+// so that repeated runs sending the same inference requests do not reach the service.
+package inferencecache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Goboolean/core-system.worker/internal/infrastructure/kserve"
+	log "github.com/sirupsen/logrus"
+)
+
+// latestVersion is the directory of the responses of a model whose version is not specified.
+const latestVersion = "_"
+
+// servedVersionFileName records the version that served the responses of latestVersion.
+const servedVersionFileName = "served_version"
+
+// versionFileName records the version of the model that the last Cache used.
+const versionFileName = "version"
+
+// metadataTimeout bounds the request of the model metadata in New.
+const metadataTimeout = 10 * time.Second
+
+var ErrInvalidOpts = errors.New("inference cache: invalid options")
+
+// Opts is the options of Cache.
+type Opts struct {
+	// Dir is the directory where the responses are stored.
+	Dir string
+	// TTL is how long a response is served from the disk. Zero means forever.
+	TTL time.Duration
+	// MaxBytes is the upper bound of the total size of the responses in Dir.
+	// The least recently used responses are evicted when it is exceeded.
+	// Zero means unlimited.
+	MaxBytes int64
+}
+
+// Stats is the number of requests served from the disk and sent to the service.
+type Stats struct {
+	Hits   int64
+	Misses int64
+}
+
+// HitRatio returns the ratio of the requests served from the disk. It returns 0 if there is no request.
+func (s Stats) HitRatio() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// Cache is a kserve.Client that keeps the responses of another client on the local disk.
+//
+// A response is keyed by the model ID, the model version and the hash of the request except its ID,
+// so a request is served from the disk only if the same model received exactly the same inputs, outputs and parameters.
+// Failed requests are not cached.
+//
+// If the model version is not specified, the responses are stored as those of the latest version
+// and dropped as soon as the service reports that another version serves the model,
+// either in the model metadata when the Cache is created or in the response of a request.
+type Cache struct {
+	client  kserve.Client
+	modelID string
+	version string
+	opts    Opts
+
+	// dir is the directory of the responses of the model version.
+	dir string
+
+	mu sync.Mutex
+	// entries maps the path of each response in opts.Dir to its size and last access.
+	entries map[string]*entry
+	total   int64
+	served  string
+	// changed reports whether the model version differs from the one that the last Cache used.
+	changed bool
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+type entry struct {
+	size       int64
+	lastAccess time.Time
+}
+
+// record is a response stored on the disk.
+type record struct {
+	Created  time.Time                 `json:"created"`
+	Response *kserve.InferenceResponse `json:"response"`
+}
+
+// New creates a new Cache of the model in front of client.
+// An empty modelVersion means the latest version of the model.
+// In that case, if client is a kserve.ModelInspector, New asks it for the version that serves the model
+// and drops the cached responses of the latest version if they were served by another version.
+func New(client kserve.Client, modelID, modelVersion string, opts Opts) (*Cache, error) {
+	if opts.Dir == "" || modelID == "" || opts.TTL < 0 || opts.MaxBytes < 0 {
+		return nil, ErrInvalidOpts
+	}
+
+	version := modelVersion
+	if version == "" {
+		version = latestVersion
+	}
+
+	c := &Cache{
+		client:  client,
+		modelID: modelID,
+		version: modelVersion,
+		opts:    opts,
+		dir:     filepath.Join(opts.Dir, url.PathEscape(modelID), url.PathEscape(version)),
+		entries: make(map[string]*entry),
+	}
+
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create inference cache: %w", err)
+	}
+
+	if b, err := os.ReadFile(filepath.Join(c.dir, servedVersionFileName)); err == nil {
+		c.served = string(b)
+	}
+
+	if err := c.scan(); err != nil {
+		return nil, fmt.Errorf("create inference cache: %w", err)
+	}
+
+	current := modelVersion
+	if current == "" {
+		current = c.resolveServed()
+		if current != "" {
+			if err := c.setServed(current); err != nil {
+				return nil, fmt.Errorf("create inference cache: %w", err)
+			}
+		}
+	}
+
+	if err := c.recordVersion(current); err != nil {
+		return nil, fmt.Errorf("create inference cache: %w", err)
+	}
+
+	return c, nil
+}
+
+// resolveServed asks the client for the version that serves the model.
+// It returns an empty string if the client is not a kserve.ModelInspector or the service does not report exactly one version.
+func (c *Cache) resolveServed() string {
+	inspector, ok := c.client.(kserve.ModelInspector)
+	if !ok {
+		return ""
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), metadataTimeout)
+	defer cancel()
+
+	meta, err := inspector.ModelMetadata(ctx)
+	if err != nil {
+		// 메타데이터를 얻지 못해도 응답의 버전으로 다시 확인하므로 캐시는 사용할 수 있다.
+		log.WithError(err).Warn("Failed to get the served version of the model")
+		return ""
+	}
+	// 여러 버전이 서비스 중이면 어떤 버전이 응답할지 알 수 없으므로 응답의 버전으로 확인한다.
+	if len(meta.Versions) != 1 {
+		return ""
+	}
+	return meta.Versions[0]
+}
+
+// recordVersion compares the version with the one that the last Cache of the model used and records it.
+func (c *Cache) recordVersion(version string) error {
+	if version == "" {
+		return nil
+	}
+
+	name := filepath.Join(filepath.Dir(c.dir), versionFileName)
+	if b, err := os.ReadFile(name); err == nil && string(b) != version {
+		c.changed = true
+	}
+	return writeFileAtomic(name, []byte(version))
+}
+
+// Infer returns the cached response of the request if there is one and sends the request to the client otherwise.
+func (c *Cache) Infer(ctx context.Context, req *kserve.InferenceRequest) (*kserve.InferenceResponse, error) {
+	key, err := requestKey(req)
+	if err != nil {
+		return nil, fmt.Errorf("inference: %w", err)
+	}
+
+	if res, ok := c.load(key); ok {
+		c.hits.Add(1)
+		res.ID = req.ID
+		return res, nil
+	}
+	c.misses.Add(1)
+
+	res, err := c.client.Infer(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 캐시에 저장하지 못해도 추론 결과는 유효하다.
+	if err := c.store(key, res); err != nil {
+		log.WithError(err).Warn("Failed to store the inference response in the cache")
+	}
+	return res, nil
+}
+
+// RequestInference sends a single FP32 input tensor and returns the first output tensor as float32.
+func (c *Cache) RequestInference(ctx context.Context, shape []int, input []float32) ([]float32, error) {
+	return kserve.RequestInference(ctx, c, shape, input)
+}
+
+// ModelReady asks the client whether the model is ready. Readiness is never cached.
+// It returns errors.ErrUnsupported if the client is not a kserve.ModelInspector.
+func (c *Cache) ModelReady(ctx context.Context) (bool, error) {
+	inspector, ok := c.client.(kserve.ModelInspector)
+	if !ok {
+		return false, fmt.Errorf("model ready: %w", errors.ErrUnsupported)
+	}
+	return inspector.ModelReady(ctx)
+}
+
+// ModelMetadata asks the client for the metadata of the model. Metadata is never cached.
+// It returns errors.ErrUnsupported if the client is not a kserve.ModelInspector.
+func (c *Cache) ModelMetadata(ctx context.Context) (*kserve.ModelMetadata, error) {
+	inspector, ok := c.client.(kserve.ModelInspector)
+	if !ok {
+		return nil, fmt.Errorf("model metadata: %w", errors.ErrUnsupported)
+	}
+	return inspector.ModelMetadata(ctx)
+}
+
+// VersionChanged reports whether the version of the model differs from the one that the last Cache of the model used.
+// The version of a Cache whose model version is not specified is the version that the service reported in New.
+func (c *Cache) VersionChanged() bool {
+	return c.changed
+}
+
+// Invalidate removes the cached responses of every version of the model other than the version of c.
+func (c *Cache) Invalidate() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	modelDir := filepath.Dir(c.dir)
+	versions, err := os.ReadDir(modelDir)
+	if err != nil {
+		return fmt.Errorf("invalidate inference cache: %w", err)
+	}
+
+	for _, e := range versions {
+		if !e.IsDir() || filepath.Join(modelDir, e.Name()) == c.dir {
+			continue
+		}
+
+		if err := c.removeDir(filepath.Join(modelDir, e.Name())); err != nil {
+			return fmt.Errorf("invalidate inference cache: %w", err)
+		}
+	}
+	return nil
+}
+
+// Stats returns the number of hits and misses of c.
+func (c *Cache) Stats() Stats {
+	return Stats{Hits: c.hits.Load(), Misses: c.misses.Load()}
+}
+
+// Close reports the hit ratio of c and closes the client if it is an io.Closer.
+func (c *Cache) Close() error {
+	stats := c.Stats()
+	log.WithFields(log.Fields{
+		"modelID":  c.modelID,
+		"hits":     stats.Hits,
+		"misses":   stats.Misses,
+		"hitRatio": stats.HitRatio(),
+	}).Info("Inference cache is closed")
+
+	if closer, ok := c.client.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// requestKey returns the hash of the request except its ID.
+// encoding/json sorts the keys of maps, so the same request always has the same key.
+func requestKey(req *kserve.InferenceRequest) (string, error) {
+	b, err := json.Marshal(kserve.InferenceRequest{
+		Parameters: req.Parameters,
+		Inputs:     req.Inputs,
+		Outputs:    req.Outputs,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func (c *Cache) load(key string) (*kserve.InferenceResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	name := filepath.Join(c.dir, key+".json")
+	e, ok := c.entries[name]
+	if !ok {
+		return nil, false
+	}
+
+	b, err := os.ReadFile(name)
+	if err != nil {
+		_ = c.remove(name)
+		return nil, false
+	}
+
+	var r record
+	if err := json.Unmarshal(b, &r); err != nil || r.Response == nil {
+		_ = c.remove(name)
+		return nil, false
+	}
+
+	if c.opts.TTL > 0 && time.Since(r.Created) > c.opts.TTL {
+		_ = c.remove(name)
+		return nil, false
+	}
+
+	// 다음 실행에서도 LRU 순서를 유지하도록 수정 시각을 갱신한다.
+	e.lastAccess = time.Now()
+	_ = os.Chtimes(name, e.lastAccess, e.lastAccess)
+	return r.Response, true
+}
+
+func (c *Cache) store(key string, res *kserve.InferenceResponse) error {
+	b, err := json.Marshal(record{Created: time.Now(), Response: res})
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.version == "" && res.ModelVersion != "" {
+		if err := c.setServed(res.ModelVersion); err != nil {
+			return err
+		}
+	}
+
+	name := filepath.Join(c.dir, key+".json")
+	if err := writeFileAtomic(name, b); err != nil {
+		return err
+	}
+
+	if e, ok := c.entries[name]; ok {
+		c.total -= e.size
+	}
+	c.entries[name] = &entry{size: int64(len(b)), lastAccess: time.Now()}
+	c.total += int64(len(b))
+
+	return c.evict()
+}
+
+// setServed records the version that serves the latest model.
+// If another version served the cached responses, they are dropped.
+// The caller must hold c.mu or own c exclusively.
+func (c *Cache) setServed(version string) error {
+	if version == c.served {
+		return nil
+	}
+
+	if c.served != "" {
+		if err := c.removeDir(c.dir); err != nil {
+			return err
+		}
+		if err := os.MkdirAll(c.dir, 0o755); err != nil {
+			return err
+		}
+	}
+
+	if err := writeFileAtomic(filepath.Join(c.dir, servedVersionFileName), []byte(version)); err != nil {
+		return err
+	}
+	c.served = version
+	return nil
+}
+
+// scan loads the size and the last access of every response in opts.Dir.
+func (c *Cache) scan() error {
+	return filepath.WalkDir(c.opts.Dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() || !strings.HasSuffix(d.Name(), ".json") {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		c.entries[path] = &entry{size: info.Size(), lastAccess: info.ModTime()}
+		c.total += info.Size()
+		return nil
+	})
+}
+
+// evict removes the least recently used responses until the total size fits in MaxBytes.
+func (c *Cache) evict() error {
+	if c.opts.MaxBytes == 0 || c.total <= c.opts.MaxBytes {
+		return nil
+	}
+
+	names := make([]string, 0, len(c.entries))
+	for k := range c.entries {
+		names = append(names, k)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return c.entries[names[i]].lastAccess.Before(c.entries[names[j]].lastAccess)
+	})
+
+	for _, name := range names {
+		if c.total <= c.opts.MaxBytes {
+			break
+		}
+
+		if err := c.remove(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// remove removes the response of the path and forgets it.
+func (c *Cache) remove(name string) error {
+	if e, ok := c.entries[name]; ok {
+		c.total -= e.size
+		delete(c.entries, name)
+	}
+
+	if err := os.Remove(name); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// removeDir removes the directory and forgets the responses in it.
+func (c *Cache) removeDir(dir string) error {
+	for name := range c.entries {
+		if strings.HasPrefix(name, dir+string(filepath.Separator)) {
+			c.total -= c.entries[name].size
+			delete(c.entries, name)
+		}
+	}
+	return os.RemoveAll(dir)
+}
+
+// writeFileAtomic writes data to a temporary file and renames it to name,
+// so that readers never see a partially written file.
+func writeFileAtomic(name string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(name), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), name)
+}
@@ -0,0 +1,261 @@
+package inferencecache_test
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Goboolean/core-system.worker/internal/infrastructure/inferencecache"
+	"github.com/Goboolean/core-system.worker/internal/infrastructure/kserve"
+	"github.com/stretchr/testify/suite"
+)
+
+var errUnavailable = errors.New("unavailable")
+
+// countingClient doubles the first input and records the number of requests it received.
+type countingClient struct {
+	version  string
+	requests int
+	fail     bool
+}
+
+func (c *countingClient) Infer(ctx context.Context, req *kserve.InferenceRequest) (*kserve.InferenceResponse, error) {
+	c.requests++
+	if c.fail {
+		return nil, errUnavailable
+	}
+
+	in := req.Inputs[0].Data.([]float32)
+	out := make([]float32, len(in))
+	for i, e := range in {
+		out[i] = e * 2
+	}
+
+	return &kserve.InferenceResponse{
+		ModelName:    "model",
+		ModelVersion: c.version,
+		ID:           req.ID,
+		Outputs: []kserve.Tensor{
+			{Name: "output", Shape: []int64{int64(len(out))}, Datatype: kserve.FP32, Data: out},
+		},
+	}, nil
+}
+
+func (c *countingClient) RequestInference(ctx context.Context, shape []int, input []float32) ([]float32, error) {
+	return kserve.RequestInference(ctx, c, shape, input)
+}
+
+// inspectingClient is a countingClient that also reports its version in the model metadata.
+type inspectingClient struct {
+	*countingClient
+}
+
+func (c inspectingClient) ModelReady(ctx context.Context) (bool, error) {
+	return true, nil
+}
+
+func (c inspectingClient) ModelMetadata(ctx context.Context) (*kserve.ModelMetadata, error) {
+	return &kserve.ModelMetadata{Name: "model", Versions: []string{c.version}}, nil
+}
+
+type CacheTestSuite struct {
+	suite.Suite
+	client *countingClient
+}
+
+func (suite *CacheTestSuite) SetupTest() {
+	suite.client = &countingClient{}
+}
+
+func (suite *CacheTestSuite) newCache(dir, version string, opts inferencecache.Opts) *inferencecache.Cache {
+	opts.Dir = dir
+	c, err := inferencecache.New(suite.client, "model", version, opts)
+	suite.Require().NoError(err)
+	return c
+}
+
+func (suite *CacheTestSuite) infer(c *inferencecache.Cache, input ...float32) []float32 {
+	out, err := c.RequestInference(context.Background(), []int{len(input)}, input)
+	suite.Require().NoError(err)
+	return out
+}
+
+func (suite *CacheTestSuite) TestCache_ShouldServeFromDisk_WhenSameRequestIsSentAgain() {
+	//arrange
+	dir := suite.T().TempDir()
+	first := suite.newCache(dir, "1", inferencecache.Opts{})
+	suite.infer(first, 1, 2)
+
+	//act
+	// 새로 생성한 Cache도 디스크에 저장된 응답을 사용해야 한다.
+	second := suite.newCache(dir, "1", inferencecache.Opts{})
+	res, err := second.Infer(context.Background(), &kserve.InferenceRequest{
+		ID:     "another",
+		Inputs: []kserve.Tensor{{Name: kserve.DefaultInputName, Shape: []int64{2}, Datatype: kserve.FP32, Data: []float32{1, 2}}},
+	})
+
+	//assert
+	suite.NoError(err)
+	suite.Equal("another", res.ID)
+	suite.Equal([]float32{2, 4}, res.Outputs[0].Data)
+	suite.Equal(1, suite.client.requests)
+	suite.Equal(inferencecache.Stats{Hits: 1, Misses: 0}, second.Stats())
+	suite.Equal(1.0, second.Stats().HitRatio())
+}
+
+func (suite *CacheTestSuite) TestCache_ShouldSendRequest_WhenInputOrModelVersionDiffers() {
+	//arrange
+	dir := suite.T().TempDir()
+	c := suite.newCache(dir, "1", inferencecache.Opts{})
+
+	//act
+	suite.infer(c, 1, 2)
+	suite.infer(c, 1, 3)
+	suite.infer(suite.newCache(dir, "2", inferencecache.Opts{}), 1, 2)
+
+	//assert
+	suite.Equal(3, suite.client.requests)
+	suite.Equal(inferencecache.Stats{Hits: 0, Misses: 2}, c.Stats())
+}
+
+func (suite *CacheTestSuite) TestCache_ShouldNotCacheFailure() {
+	//arrange
+	c := suite.newCache(suite.T().TempDir(), "1", inferencecache.Opts{})
+	suite.client.fail = true
+	_, err := c.RequestInference(context.Background(), []int{1}, []float32{1})
+	suite.Require().ErrorIs(err, errUnavailable)
+
+	//act
+	suite.client.fail = false
+	res := suite.infer(c, 1)
+
+	//assert
+	suite.Equal([]float32{2}, res)
+	suite.Equal(2, suite.client.requests)
+}
+
+func (suite *CacheTestSuite) TestCache_ShouldSendRequest_WhenResponseIsExpired() {
+	//arrange
+	c := suite.newCache(suite.T().TempDir(), "1", inferencecache.Opts{TTL: time.Millisecond})
+	suite.infer(c, 1)
+
+	//act
+	time.Sleep(10 * time.Millisecond)
+	suite.infer(c, 1)
+
+	//assert
+	suite.Equal(2, suite.client.requests)
+}
+
+func (suite *CacheTestSuite) TestCache_ShouldEvictLeastRecentlyUsedResponse_WhenSizeExceedsMaxBytes() {
+	//arrange
+	// 응답 하나의 크기를 잰다.
+	sizeDir := suite.T().TempDir()
+	suite.infer(suite.newCache(sizeDir, "1", inferencecache.Opts{}), 1)
+	var size int64
+	suite.Require().NoError(filepath.WalkDir(sizeDir, func(path string, d fs.DirEntry, err error) error {
+		if filepath.Ext(path) == ".json" {
+			info, _ := d.Info()
+			size = info.Size()
+		}
+		return err
+	}))
+
+	c := suite.newCache(suite.T().TempDir(), "1", inferencecache.Opts{MaxBytes: size * 3 / 2})
+	suite.infer(c, 1)
+	suite.infer(c, 2)
+	suite.client.requests = 0
+
+	//act
+	suite.infer(c, 2)
+	suite.infer(c, 1)
+
+	//assert
+	suite.Equal(1, suite.client.requests)
+}
+
+func (suite *CacheTestSuite) TestCache_ShouldDropResponses_WhenServedVersionOfLatestModelChanges() {
+	//arrange
+	dir := suite.T().TempDir()
+	suite.client.version = "1"
+	c := suite.newCache(dir, "", inferencecache.Opts{})
+	suite.infer(c, 1)
+	suite.infer(c, 1)
+	suite.Require().Equal(1, suite.client.requests)
+
+	//act
+	suite.client.version = "2"
+	suite.infer(c, 2)
+	suite.infer(suite.newCache(dir, "", inferencecache.Opts{}), 1)
+
+	//assert
+	suite.Equal(3, suite.client.requests)
+}
+
+func (suite *CacheTestSuite) TestNew_ShouldDropResponses_WhenModelMetadataReportsAnotherServedVersion() {
+	//arrange
+	dir := suite.T().TempDir()
+	client := inspectingClient{suite.client}
+	suite.client.version = "1"
+	first, err := inferencecache.New(client, "model", "", inferencecache.Opts{Dir: dir})
+	suite.Require().NoError(err)
+	suite.infer(first, 1)
+
+	//act
+	// 캐시된 요청만 보내더라도 새 버전의 응답을 받아야 한다.
+	suite.client.version = "2"
+	second, err := inferencecache.New(client, "model", "", inferencecache.Opts{Dir: dir})
+	suite.Require().NoError(err)
+	suite.infer(second, 1)
+
+	//assert
+	suite.Equal(2, suite.client.requests)
+	suite.True(second.VersionChanged())
+}
+
+func (suite *CacheTestSuite) TestVersionChanged_ShouldReportWhetherVersionDiffersFromLastCache() {
+	//arrange
+	dir := suite.T().TempDir()
+
+	//act
+	first := suite.newCache(dir, "1", inferencecache.Opts{})
+	second := suite.newCache(dir, "1", inferencecache.Opts{})
+	third := suite.newCache(dir, "2", inferencecache.Opts{})
+
+	//assert
+	suite.False(first.VersionChanged())
+	suite.False(second.VersionChanged())
+	suite.True(third.VersionChanged())
+}
+
+func (suite *CacheTestSuite) TestInvalidate_ShouldRemoveResponsesOfOtherVersions() {
+	//arrange
+	dir := suite.T().TempDir()
+	suite.infer(suite.newCache(dir, "1", inferencecache.Opts{}), 1)
+	current := suite.newCache(dir, "2", inferencecache.Opts{})
+	suite.infer(current, 1)
+
+	//act
+	err := current.Invalidate()
+
+	//assert
+	suite.NoError(err)
+	suite.infer(current, 1)
+	suite.infer(suite.newCache(dir, "1", inferencecache.Opts{}), 1)
+	suite.Equal(3, suite.client.requests)
+}
+
+func (suite *CacheTestSuite) TestNew_ShouldReturnError_WhenOptsAreInvalid() {
+	//act
+	_, err := inferencecache.New(suite.client, "model", "", inferencecache.Opts{})
+
+	//assert
+	suite.ErrorIs(err, inferencecache.ErrInvalidOpts)
+}
+
+func TestCache(t *testing.T) {
+	suite.Run(t, new(CacheTestSuite))
+}
@@ -0,0 +1,105 @@
+package influx
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Goboolean/core-system.worker/internal/model"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/api"
+)
+
+// QuoteReader reads past top-of-book quotes.
+// Quotes of a product are stored in the measurement "{productID}.quote"
+// with the fields "bidPrice", "bidSize", "askPrice" and "askSize".
+type QuoteReader struct {
+	client influxdb2.Client
+	reader api.QueryAPI
+	bucket string
+}
+
+func NewQuoteReader(o *Opts) (*QuoteReader, error) {
+
+	if o.URL == "" {
+		return nil, fmt.Errorf("create influx db client: Required field Url is blank")
+	}
+
+	if o.Token == "" {
+		return nil, fmt.Errorf("create influx db client: Required field Token is blank")
+	}
+
+	if o.Org == "" {
+		return nil, fmt.Errorf("create influx db client: Required field Org is blank")
+	}
+
+	if o.BucketName == "" {
+		return nil, fmt.Errorf("create influx db client: Required field BucketName is blank")
+	}
+
+	client := influxdb2.NewClient(o.URL, o.Token)
+
+	return &QuoteReader{
+		client: client,
+		reader: client.QueryAPI(o.Org),
+		bucket: o.BucketName,
+	}, nil
+}
+
+// FetchLimitedQuoteAfter fetches at most limit quotes of the product whose time is after start.
+func (r *QuoteReader) FetchLimitedQuoteAfter(ctx context.Context, productID string, start time.Time, limit int) ([]*model.Quote, error) {
+	queryRes, err := r.reader.Query(ctx, fmt.Sprintf(
+		`from(bucket:"%s")
+			|> range(start:time(v:%d))
+			|> filter(fn: (r) => r._measurement == "%s.quote")
+			|> filter(fn: (r) => (r._field == "bidPrice" or r._field == "bidSize" or r._field == "askPrice" or r._field == "askSize"))
+			|> pivot(rowKey:["_time"], columnKey: ["_field"], valueColumn: "_value")
+			|> filter(fn: (r) => r._time > time(v:%d))
+			|> limit(n:%d)`,
+		r.bucket, start.UnixNano(), productID, start.UnixNano(), limit))
+	if err != nil {
+		return nil, fmt.Errorf("fetch quote: %w", err)
+	}
+
+	// When query result is empty but error is not occurred
+	if queryRes == nil {
+		return make([]*model.Quote, 0), nil
+	}
+
+	data := make([]*model.Quote, 0, limit)
+	for queryRes.Next() {
+		record := queryRes.Record()
+		quote := &model.Quote{Time: record.Time()}
+
+		for _, field := range []struct {
+			key    string
+			target *float32
+		}{
+			{"bidPrice", &quote.BidPrice},
+			{"bidSize", &quote.BidSize},
+			{"askPrice", &quote.AskPrice},
+			{"askSize", &quote.AskSize},
+		} {
+			val, ok := record.ValueByKey(field.key).(float64)
+			if !ok {
+				return nil, fmt.Errorf(`fetch quote: can't extract "%s"`, field.key)
+			}
+			*field.target = float32(val)
+		}
+
+		data = append(data, quote)
+	}
+
+	if queryRes.Err() != nil {
+		return nil, fmt.Errorf("fetch quote: %w", queryRes.Err())
+	}
+
+	return data, nil
+}
+
+// Close closes the reader.
+func (r *QuoteReader) Close() error {
+	r.client.Close()
+	return nil
+}
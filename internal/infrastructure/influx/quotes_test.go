@@ -0,0 +1,68 @@
+package influx_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Goboolean/core-system.worker/internal/infrastructure/influx"
+	influxutil "github.com/Goboolean/core-system.worker/test/util/influx"
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuoteReader(t *testing.T) {
+	t.Run("저장된 quote를 시간 순서대로 limit개씩 읽어야 한다.", func(t *testing.T) {
+		//arrange
+		if err := influxutil.RecreateBucket(rawInfluxDBClient, org, annotationBucket); err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+
+		num := 10
+		start := time.Now().Add(-time.Hour).Truncate(time.Second)
+		writer := rawInfluxDBClient.WriteAPIBlocking(org, annotationBucket)
+		for i := 0; i < num; i++ {
+			err := writer.WritePoint(context.Background(), write.NewPoint(
+				fmt.Sprintf("%s.quote", productID),
+				map[string]string{},
+				map[string]interface{}{
+					"bidPrice": float64(100 + i),
+					"bidSize":  float64(10),
+					"askPrice": float64(101 + i),
+					"askSize":  float64(20),
+				},
+				start.Add(time.Duration(i)*time.Millisecond*500),
+			))
+			if err != nil {
+				t.Error(err)
+				t.FailNow()
+			}
+		}
+
+		reader, err := influx.NewQuoteReader(&influx.Opts{
+			URL:        url,
+			Token:      token,
+			Org:        org,
+			BucketName: annotationBucket,
+		})
+		if err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+		defer reader.Close()
+
+		//act
+		res, err := reader.FetchLimitedQuoteAfter(context.Background(), productID, start, 5)
+
+		//assert
+		assert.NoError(t, err)
+		assert.Len(t, res, 5)
+		for i, e := range res {
+			assert.Equal(t, float32(101+i), e.BidPrice)
+			assert.Equal(t, float32(102+i), e.AskPrice)
+			assert.True(t, e.Time.After(start))
+		}
+	})
+}
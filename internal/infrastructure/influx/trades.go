@@ -0,0 +1,101 @@
+package influx
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Goboolean/core-system.worker/internal/model"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/api"
+)
+
+// TradeReader reads past trade prints.
+// Trade prints of a product are stored in the measurement "{productID}.t"
+// with the fields "price" and "size".
+type TradeReader struct {
+	client influxdb2.Client
+	reader api.QueryAPI
+	bucket string
+}
+
+func NewTradeReader(o *Opts) (*TradeReader, error) {
+
+	if o.URL == "" {
+		return nil, fmt.Errorf("create influx db client: Required field Url is blank")
+	}
+
+	if o.Token == "" {
+		return nil, fmt.Errorf("create influx db client: Required field Token is blank")
+	}
+
+	if o.Org == "" {
+		return nil, fmt.Errorf("create influx db client: Required field Org is blank")
+	}
+
+	if o.BucketName == "" {
+		return nil, fmt.Errorf("create influx db client: Required field BucketName is blank")
+	}
+
+	client := influxdb2.NewClient(o.URL, o.Token)
+
+	return &TradeReader{
+		client: client,
+		reader: client.QueryAPI(o.Org),
+		bucket: o.BucketName,
+	}, nil
+}
+
+// FetchLimitedTradePrintAfter fetches at most limit trade prints of the product whose time is after start.
+func (r *TradeReader) FetchLimitedTradePrintAfter(ctx context.Context, productID string, start time.Time, limit int) ([]*model.Trade, error) {
+	queryRes, err := r.reader.Query(ctx, fmt.Sprintf(
+		`from(bucket:"%s")
+			|> range(start:time(v:%d))
+			|> filter(fn: (r) => r._measurement == "%s.t")
+			|> filter(fn: (r) => (r._field == "price" or r._field == "size"))
+			|> pivot(rowKey:["_time"], columnKey: ["_field"], valueColumn: "_value")
+			|> filter(fn: (r) => r._time > time(v:%d))
+			|> limit(n:%d)`,
+		r.bucket, start.UnixNano(), productID, start.UnixNano(), limit))
+	if err != nil {
+		return nil, fmt.Errorf("fetch trade print: %w", err)
+	}
+
+	// When query result is empty but error is not occurred
+	if queryRes == nil {
+		return make([]*model.Trade, 0), nil
+	}
+
+	data := make([]*model.Trade, 0, limit)
+	for queryRes.Next() {
+		record := queryRes.Record()
+
+		price, ok := record.ValueByKey("price").(float64)
+		if !ok {
+			return nil, fmt.Errorf(`fetch trade print: can't extract "price"`)
+		}
+		size, ok := record.ValueByKey("size").(float64)
+		if !ok {
+			return nil, fmt.Errorf(`fetch trade print: can't extract "size"`)
+		}
+
+		data = append(data, &model.Trade{
+			Time:  record.Time(),
+			Price: float32(price),
+			Size:  float32(size),
+		})
+	}
+
+	if queryRes.Err() != nil {
+		return nil, fmt.Errorf("fetch trade print: %w", queryRes.Err())
+	}
+
+	return data, nil
+}
+
+// Close closes the reader.
+func (r *TradeReader) Close() error {
+	r.client.Close()
+	return nil
+}
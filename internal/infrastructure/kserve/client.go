@@ -10,46 +10,83 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/Goboolean/common/pkg/resolver"
 )
 
-var defaultKSserveClient = &ClientImpl{
-	host:   "",
-	param1: 0.0,
-	param2: 1.0,
-	http: &http.Client{
-		Transport: &http.Transport{
-			IdleConnTimeout: 600 * time.Second,
-		},
-	},
-}
+const (
+	DefaultInputName = "input"
+	DefaultTimeout   = 60 * time.Second
+)
+
+const (
+	ProtocolREST = "rest"
+	ProtocolGRPC = "grpc"
+)
 
 // Client is an interface that defines the role of sending and receiving requests to KServe.
 type Client interface {
-	SetModelName(name string)
+	// Infer sends an inference request of the Open Inference Protocol v2 and returns the response.
+	Infer(ctx context.Context, req *InferenceRequest) (*InferenceResponse, error)
+
+	// RequestInference sends a single FP32 input tensor and returns the first output tensor as float32.
 	RequestInference(ctx context.Context, shape []int, input []float32) (output []float32, err error)
 }
 
+// ModelInspector is implemented by the clients that can check the model before requesting inferences.
+type ModelInspector interface {
+	// ModelReady reports whether the model is ready to serve inferences.
+	ModelReady(ctx context.Context) (bool, error)
+
+	// ModelMetadata returns the metadata of the model.
+	ModelMetadata(ctx context.Context) (*ModelMetadata, error)
+}
+
 // ClientImpl is a struct that represents the implementation of the KServeClient interface.
 type ClientImpl struct {
-	modelID           string
-	host              string
+	modelID string
+	// modelEndpoint is {host}/v2/models/{model}[/versions/{version}]
+	modelEndpoint     *url.URL
 	inferenceEndpoint *url.URL
 
-	param1 float32
-	param2 float32
-
 	http *http.Client
 }
 
+// New creates a client of the protocol selected by the config.
+//
+// Config list
+// "protocol": (optional) ProtocolREST or ProtocolGRPC. Defaults to ProtocolREST.
+// The other keys are those of NewClient or NewGRPCClient.
+func New(c *resolver.ConfigMap) (Client, error) {
+	protocol, exists, err := c.GetStringKeyOptional("protocol")
+	if err != nil {
+		return nil, fmt.Errorf("create kserve client: %w", err)
+	}
+
+	if !exists || protocol == "" {
+		protocol = ProtocolREST
+	}
+
+	switch protocol {
+	case ProtocolREST:
+		return NewClient(c)
+	case ProtocolGRPC:
+		return NewGRPCClient(c)
+	default:
+		return nil, fmt.Errorf("create kserve client: %w: unknown protocol %q", ErrInvalidConfig, protocol)
+	}
+}
+
 // NewClient creates a new instance of KServeClientImpl.
+//
+// Config list
+// "host": The address of the inference service. The scheme defaults to http if omitted. Example: "http://kserve:8080"
+// "modelID": The name of the model to request inferences.
+// "modelVersion": (optional) The version of the model.
 func NewClient(c *resolver.ConfigMap) (*ClientImpl, error) {
 
-	//default value
-	instance := defaultKSserveClient
-
 	id, err := c.GetStringKey("modelID")
 	if err != nil {
 		return nil, fmt.Errorf("create kserve client: %w", err)
@@ -60,72 +97,214 @@ func NewClient(c *resolver.ConfigMap) (*ClientImpl, error) {
 		return nil, fmt.Errorf("create kserve client: %w", err)
 	}
 
-	param1, err := c.GetFloatKey("param1")
+	version, _, err := c.GetStringKeyOptional("modelVersion")
 	if err != nil {
 		return nil, fmt.Errorf("create kserve client: %w", err)
 	}
 
-	param2, err := c.GetFloatKey("param2")
+	endpoint, err := generateModelUrl(host, id, version)
 	if err != nil {
 		return nil, fmt.Errorf("create kserve client: %w", err)
 	}
 
-	instance.host = host
-	instance.modelID = id
-	instance.param1 = float32(param1)
-	instance.param2 = float32(param2)
-
-	return instance, nil
-}
-
-// SetModelName sets the model name for the KServeClientImpl instance.
-func (c *ClientImpl) SetModelName(name string) {
-	c.inferenceEndpoint = generateInferenceUrl(c.host, name)
+	return &ClientImpl{
+		modelID:           id,
+		modelEndpoint:     endpoint,
+		inferenceEndpoint: endpoint.JoinPath("infer"),
+		http: &http.Client{
+			Timeout: DefaultTimeout,
+			Transport: &http.Transport{
+				IdleConnTimeout: 600 * time.Second,
+			},
+		},
+	}, nil
 }
 
-// RequestInference sends an inference request to KServe and returns the output and any error that occurred.
-func (c *ClientImpl) RequestInference(ctx context.Context, shape []int, input []float32) ([]float32, error) {
-	bodyJson, err := json.Marshal(
-		InferenceReq{
-			Name:     "input",
-			Shape:    shape,
-			DataType: "FP32",
-			Data:     input,
-		})
+// Infer sends an inference request of the Open Inference Protocol v2 and returns the response.
+//
+// If the service responds with an error status, Infer returns *InferenceError.
+func (c *ClientImpl) Infer(ctx context.Context, inferenceReq *InferenceRequest) (*InferenceResponse, error) {
+	for i := range inferenceReq.Inputs {
+		if err := inferenceReq.Inputs[i].Validate(); err != nil {
+			return nil, fmt.Errorf("inference: %w", err)
+		}
+	}
 
+	body, err := json.Marshal(inferenceReq)
 	if err != nil {
-		return nil, fmt.Errorf("inference: falid to create request body  %w", err)
+		return nil, fmt.Errorf("inference: failed to create request body: %w", err)
 	}
 
-	req := &http.Request{
-		Method: http.MethodPost,
-		URL:    c.inferenceEndpoint,
-		Body:   io.NopCloser(bytes.NewBuffer(bodyJson)),
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.inferenceEndpoint.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("inference: %w", err)
 	}
-	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
 
 	res, err := c.http.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("inference: %w", err)
 	}
+	defer res.Body.Close()
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("inference: failed to read response body: %w", err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("inference: %w", newInferenceError(res.StatusCode, b))
+	}
+
+	var out InferenceResponse
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil, fmt.Errorf("inference: %w: %w", ErrInvalidResponse, err)
+	}
+
+	return &out, nil
+}
+
+// ModelReady reports whether the model is ready to serve inferences.
+//
+// The service reports that the model is not ready with a status of 4xx or 503.
+// If the model is not found, ModelReady returns *InferenceError wrapping ErrModelNotFound.
+func (c *ClientImpl) ModelReady(ctx context.Context) (bool, error) {
+	statusCode, b, err := c.get(ctx, c.modelEndpoint.JoinPath("ready"))
+	if err != nil {
+		return false, fmt.Errorf("model ready: %w", err)
+	}
+
+	switch {
+	case statusCode == http.StatusOK:
+		// 본문이 없는 서비스도 있으므로 200이면 준비된 것으로 본다.
+		res := struct {
+			Ready *bool `json:"ready"`
+		}{}
+		if err := json.Unmarshal(b, &res); err == nil && res.Ready != nil {
+			return *res.Ready, nil
+		}
+		return true, nil
+	case statusCode == http.StatusNotFound:
+		return false, fmt.Errorf("model ready: %w", newInferenceError(statusCode, b))
+	case statusCode == http.StatusServiceUnavailable || (statusCode >= 400 && statusCode < 500):
+		return false, nil
+	default:
+		return false, fmt.Errorf("model ready: %w", newInferenceError(statusCode, b))
+	}
+}
+
+// ModelMetadata returns the metadata of the model.
+//
+// If the service responds with an error status, ModelMetadata returns *InferenceError.
+func (c *ClientImpl) ModelMetadata(ctx context.Context) (*ModelMetadata, error) {
+	statusCode, b, err := c.get(ctx, c.modelEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("model metadata: %w", err)
+	}
 
-	var b []byte
-	var out InferenceRes
-	if _, err := res.Body.Read(b); err != nil {
-		return nil, fmt.Errorf("inference: falid to read responce body %w", err)
+	if statusCode != http.StatusOK {
+		return nil, fmt.Errorf("model metadata: %w", newInferenceError(statusCode, b))
 	}
 
+	var out ModelMetadata
 	if err := json.Unmarshal(b, &out); err != nil {
-		return nil, fmt.Errorf("inference: falid to unmarshal responce %w", err)
+		return nil, fmt.Errorf("model metadata: %w: %w", ErrInvalidResponse, err)
+	}
+
+	return &out, nil
+}
+
+// get sends a GET request to the endpoint and returns the status and the body of the response.
+func (c *ClientImpl) get(ctx context.Context, endpoint *url.URL) (int, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer res.Body.Close()
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return res.StatusCode, b, nil
+}
+
+// newInferenceError creates *InferenceError from the status and the body of a failed response.
+func newInferenceError(statusCode int, body []byte) *InferenceError {
+	var e errorResponse
+	if err := json.Unmarshal(body, &e); err != nil || e.Error == "" {
+		e.Error = strings.TrimSpace(string(body))
+	}
+	return &InferenceError{StatusCode: statusCode, Message: e.Error}
+}
 
+// RequestInference sends a single FP32 input tensor and returns the first output tensor as float32.
+func (c *ClientImpl) RequestInference(ctx context.Context, shape []int, input []float32) ([]float32, error) {
+	return RequestInference(ctx, c, shape, input)
+}
+
+// RequestInference sends a single FP32 input tensor through c.Infer and returns the first output tensor as float32.
+// It is the implementation of Client.RequestInference shared by the clients.
+func RequestInference(ctx context.Context, c Client, shape []int, input []float32) ([]float32, error) {
+	return RequestInferenceWithParameters(ctx, c, shape, input, nil)
+}
+
+// RequestInferenceWithParameters is RequestInference sending the parameters of the request as well.
+func RequestInferenceWithParameters(ctx context.Context, c Client, shape []int, input []float32, parameters map[string]any) ([]float32, error) {
+	s := make([]int64, len(shape))
+	for i, e := range shape {
+		s[i] = int64(e)
+	}
+
+	res, err := c.Infer(ctx, &InferenceRequest{
+		Parameters: parameters,
+		Inputs: []Tensor{{
+			Name:     DefaultInputName,
+			Shape:    s,
+			Datatype: FP32,
+			Data:     input,
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.Outputs) == 0 {
+		return nil, fmt.Errorf("inference: %w: no output", ErrInvalidResponse)
 	}
 
-	return out.Outputs[0].Data.([]float32), nil
+	out, err := res.Outputs[0].AsFloat32()
+	if err != nil {
+		return nil, fmt.Errorf("inference: %w", err)
+	}
+	return out, nil
 }
 
-func generateInferenceUrl(host, modelName string) *url.URL {
-	return &url.URL{
-		Host: host,
-		Path: fmt.Sprintf("v2/models/%s/infer", modelName),
+// generateModelUrl builds the endpoint of the model: {host}/v2/models/{model}[/versions/{version}]
+func generateModelUrl(host, modelName, version string) (*url.URL, error) {
+	if host == "" || modelName == "" {
+		return nil, ErrInvalidConfig
 	}
+
+	if !strings.Contains(host, "://") {
+		host = "http://" + host
+	}
+
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+
+	segments := []string{"v2", "models", modelName}
+	if version != "" {
+		segments = append(segments, "versions", version)
+	}
+
+	return u.JoinPath(segments...), nil
 }
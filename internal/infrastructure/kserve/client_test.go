@@ -0,0 +1,234 @@
+package kserve_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Goboolean/common/pkg/resolver"
+	"github.com/Goboolean/core-system.worker/internal/infrastructure/kserve"
+	"github.com/Goboolean/core-system.worker/internal/infrastructure/kserve/kservetest"
+	"github.com/stretchr/testify/suite"
+)
+
+type ClientTestSuite struct {
+	suite.Suite
+	server *kservetest.Server
+}
+
+func (suite *ClientTestSuite) SetupTest() {
+	suite.server = kservetest.NewServer()
+}
+
+func (suite *ClientTestSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+func (suite *ClientTestSuite) newClient(config resolver.ConfigMap) *kserve.ClientImpl {
+	if _, ok := config["host"]; !ok {
+		config["host"] = suite.server.URL
+	}
+	c, err := kserve.NewClient(&config)
+	suite.Require().NoError(err)
+	return c
+}
+
+func (suite *ClientTestSuite) TestInfer_ShouldSendAndReceiveMultipleTensors_WhenEveryDatatypeIsUsed() {
+	//arrange
+	suite.server.AddModel("model", kservetest.Echo)
+	c := suite.newClient(resolver.ConfigMap{"modelID": "model"})
+
+	inputs := []kserve.Tensor{
+		{Name: "bool", Shape: []int64{2}, Datatype: kserve.BOOL, Data: []bool{true, false}},
+		{Name: "uint8", Shape: []int64{2}, Datatype: kserve.UINT8, Data: []uint8{0, 255}},
+		{Name: "uint16", Shape: []int64{1}, Datatype: kserve.UINT16, Data: []uint16{65535}},
+		{Name: "uint32", Shape: []int64{1}, Datatype: kserve.UINT32, Data: []uint32{4294967295}},
+		{Name: "uint64", Shape: []int64{1}, Datatype: kserve.UINT64, Data: []uint64{18446744073709551615}},
+		{Name: "int8", Shape: []int64{2}, Datatype: kserve.INT8, Data: []int8{-128, 127}},
+		{Name: "int16", Shape: []int64{1}, Datatype: kserve.INT16, Data: []int16{-32768}},
+		{Name: "int32", Shape: []int64{1}, Datatype: kserve.INT32, Data: []int32{-2147483648}},
+		{Name: "int64", Shape: []int64{1}, Datatype: kserve.INT64, Data: []int64{-9223372036854775808}},
+		{Name: "fp16", Shape: []int64{1}, Datatype: kserve.FP16, Data: []float32{0.5}},
+		{Name: "fp32", Shape: []int64{2, 2}, Datatype: kserve.FP32, Data: []float32{1, 2, 3, 4}},
+		{Name: "fp64", Shape: []int64{1}, Datatype: kserve.FP64, Data: []float64{0.1}},
+		{Name: "bytes", Shape: []int64{2}, Datatype: kserve.BYTES, Data: []string{"a", "b"}},
+	}
+
+	//act
+	res, err := c.Infer(context.Background(), &kserve.InferenceRequest{
+		ID:         "42",
+		Parameters: map[string]any{"temperature": 0.5},
+		Inputs:     inputs,
+	})
+
+	//assert
+	suite.Require().NoError(err)
+	suite.Equal("model", res.ModelName)
+	suite.Equal("42", res.ID)
+	suite.Equal(inputs, res.Outputs)
+	suite.Equal(map[string]any{"temperature": 0.5}, suite.server.Requests()[0].Parameters)
+}
+
+func (suite *ClientTestSuite) TestInfer_ShouldSendRequestOfOpenInferenceProtocol() {
+	//arrange
+	var body map[string]any
+	suite.server.AddModel("model", func(req *kserve.InferenceRequest) (*kserve.InferenceResponse, error) {
+		b, _ := json.Marshal(req)
+		json.Unmarshal(b, &body)
+		return &kserve.InferenceResponse{}, nil
+	})
+	c := suite.newClient(resolver.ConfigMap{"modelID": "model"})
+
+	//act
+	_, err := c.RequestInference(context.Background(), []int{1, 2}, []float32{1, 2})
+
+	//assert
+	suite.Require().ErrorIs(err, kserve.ErrInvalidResponse)
+	suite.Equal(map[string]any{
+		"inputs": []any{map[string]any{
+			"name":     "input",
+			"shape":    []any{1.0, 2.0},
+			"datatype": "FP32",
+			"data":     []any{1.0, 2.0},
+		}},
+	}, body)
+}
+
+func (suite *ClientTestSuite) TestInfer_ShouldDecodeNestedData_WhenResponseDataIsNotFlattened() {
+	//arrange
+	var tensor kserve.Tensor
+
+	//act
+	err := json.Unmarshal([]byte(`{"name":"out","shape":[2,2],"datatype":"INT32","data":[[1,2],[3,4]]}`), &tensor)
+
+	//assert
+	suite.NoError(err)
+	suite.Equal([]int32{1, 2, 3, 4}, tensor.Data)
+}
+
+func (suite *ClientTestSuite) TestInfer_ShouldReturnInvalidTensor_WhenDataDoesNotMatchShapeOrDatatype() {
+	//arrange
+	suite.server.AddModel("model", kservetest.Echo)
+	c := suite.newClient(resolver.ConfigMap{"modelID": "model"})
+
+	for _, tensor := range []kserve.Tensor{
+		{Name: "shape", Shape: []int64{3}, Datatype: kserve.FP32, Data: []float32{1, 2}},
+		{Name: "type", Shape: []int64{2}, Datatype: kserve.INT32, Data: []float32{1, 2}},
+	} {
+		//act
+		_, err := c.Infer(context.Background(), &kserve.InferenceRequest{Inputs: []kserve.Tensor{tensor}})
+
+		//assert
+		suite.ErrorIs(err, kserve.ErrInvalidTensor, tensor.Name)
+	}
+	suite.Empty(suite.server.Requests())
+}
+
+func (suite *ClientTestSuite) TestInfer_ShouldReturnTypedError_WhenServiceRespondsWithError() {
+	//arrange
+	suite.server.AddModel("ready", func(req *kserve.InferenceRequest) (*kserve.InferenceResponse, error) {
+		return nil, &kserve.InferenceError{StatusCode: http.StatusBadRequest, Message: "bad input"}
+	})
+	suite.server.AddModel("notReady", kservetest.Echo)
+	suite.server.SetReady("notReady", false)
+
+	for _, tc := range []struct {
+		model string
+		err   error
+	}{
+		{model: "ready", err: kserve.ErrBadRequest},
+		{model: "notReady", err: kserve.ErrModelNotReady},
+		{model: "unknown", err: kserve.ErrModelNotFound},
+	} {
+		c := suite.newClient(resolver.ConfigMap{"modelID": tc.model})
+
+		//act
+		_, err := c.RequestInference(context.Background(), []int{1}, []float32{1})
+
+		//assert
+		suite.ErrorIs(err, tc.err, tc.model)
+		var inferenceErr *kserve.InferenceError
+		suite.True(errors.As(err, &inferenceErr), tc.model)
+	}
+}
+
+func (suite *ClientTestSuite) TestModelReady_ShouldReportReadinessOfModel() {
+	//arrange
+	suite.server.AddModel("ready", kservetest.Echo)
+	suite.server.AddModel("notReady", kservetest.Echo)
+	suite.server.SetReady("notReady", false)
+
+	//act
+	ready, readyErr := suite.newClient(resolver.ConfigMap{"modelID": "ready"}).ModelReady(context.Background())
+	notReady, notReadyErr := suite.newClient(resolver.ConfigMap{"modelID": "notReady"}).ModelReady(context.Background())
+	_, unknownErr := suite.newClient(resolver.ConfigMap{"modelID": "unknown"}).ModelReady(context.Background())
+
+	//assert
+	suite.NoError(readyErr)
+	suite.True(ready)
+	suite.NoError(notReadyErr)
+	suite.False(notReady)
+	suite.ErrorIs(unknownErr, kserve.ErrModelNotFound)
+}
+
+func (suite *ClientTestSuite) TestModelMetadata_ShouldReturnMetadataOfModel() {
+	//arrange
+	metadata := kserve.ModelMetadata{
+		Name:     "model",
+		Versions: []string{"1", "2"},
+		Platform: "onnxruntime",
+		Inputs:   []kserve.TensorMetadata{{Name: "input", Datatype: kserve.FP32, Shape: []int64{-1, 4}}},
+		Outputs:  []kserve.TensorMetadata{{Name: "output", Datatype: kserve.FP32, Shape: []int64{-1, 4}}},
+	}
+	suite.server.AddModel("model", kservetest.Echo)
+	suite.server.SetMetadata("model", metadata)
+
+	//act
+	res, err := suite.newClient(resolver.ConfigMap{"modelID": "model", "modelVersion": "2"}).ModelMetadata(context.Background())
+	_, unknownErr := suite.newClient(resolver.ConfigMap{"modelID": "unknown"}).ModelMetadata(context.Background())
+
+	//assert
+	suite.NoError(err)
+	suite.Equal(&metadata, res)
+	suite.ErrorIs(unknownErr, kserve.ErrModelNotFound)
+}
+
+func (suite *ClientTestSuite) TestNewClient_ShouldBuildEndpointFromModelIDAndVersion() {
+	//arrange
+	suite.server.AddModel("model", kservetest.Echo)
+	host := strings.TrimPrefix(suite.server.URL, "http://")
+
+	// scheme이 없는 host도 http로 요청해야 한다.
+	c := suite.newClient(resolver.ConfigMap{"host": host, "modelID": "model", "modelVersion": "3"})
+
+	//act
+	res, err := c.Infer(context.Background(), &kserve.InferenceRequest{})
+
+	//assert
+	suite.NoError(err)
+	suite.Equal("3", res.ModelVersion)
+}
+
+func (suite *ClientTestSuite) TestNewClient_ShouldNotShareState_WhenCreatedTwice() {
+	//arrange
+	suite.server.AddModel("a", kservetest.Echo)
+	suite.server.AddModel("b", func(req *kserve.InferenceRequest) (*kserve.InferenceResponse, error) {
+		return nil, &kserve.InferenceError{StatusCode: http.StatusInternalServerError, Message: "fail"}
+	})
+
+	a := suite.newClient(resolver.ConfigMap{"modelID": "a"})
+	_ = suite.newClient(resolver.ConfigMap{"modelID": "b"})
+
+	//act
+	_, err := a.RequestInference(context.Background(), []int{1}, []float32{1})
+
+	//assert
+	suite.NoError(err)
+}
+
+func TestClient(t *testing.T) {
+	suite.Run(t, new(ClientTestSuite))
+}
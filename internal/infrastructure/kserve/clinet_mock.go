@@ -39,6 +39,21 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// Infer mocks base method.
+func (m *MockClient) Infer(arg0 context.Context, arg1 *InferenceRequest) (*InferenceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Infer", arg0, arg1)
+	ret0, _ := ret[0].(*InferenceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Infer indicates an expected call of Infer.
+func (mr *MockClientMockRecorder) Infer(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Infer", reflect.TypeOf((*MockClient)(nil).Infer), arg0, arg1)
+}
+
 // RequestInference mocks base method.
 func (m *MockClient) RequestInference(arg0 context.Context, arg1 []int, arg2 []float32) ([]float32, error) {
 	m.ctrl.T.Helper()
@@ -53,15 +68,3 @@ func (mr *MockClientMockRecorder) RequestInference(arg0, arg1, arg2 any) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestInference", reflect.TypeOf((*MockClient)(nil).RequestInference), arg0, arg1, arg2)
 }
-
-// SetModelName mocks base method.
-func (m *MockClient) SetModelName(arg0 string) {
-	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetModelName", arg0)
-}
-
-// SetModelName indicates an expected call of SetModelName.
-func (mr *MockClientMockRecorder) SetModelName(arg0 any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetModelName", reflect.TypeOf((*MockClient)(nil).SetModelName), arg0)
-}
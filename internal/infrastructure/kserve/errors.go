@@ -0,0 +1,99 @@
+package kserve
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrInvalidConfig   = errors.New("kserve: invalid config")
+	ErrInvalidTensor   = errors.New("kserve: invalid tensor")
+	ErrInvalidResponse = errors.New("kserve: invalid response")
+
+	ErrInvalidParameter = errors.New("kserve: invalid parameter")
+
+	ErrBadRequest       = errors.New("kserve: bad request")
+	ErrModelNotFound    = errors.New("kserve: model not found")
+	ErrModelNotReady    = errors.New("kserve: model not ready")
+	ErrServerError      = errors.New("kserve: server error")
+	ErrUnexpectedStatus = errors.New("kserve: unexpected status")
+)
+
+// InferenceError is returned when the inference service responds with an error status.
+// It wraps one of ErrBadRequest, ErrModelNotFound, ErrModelNotReady, ErrServerError and ErrUnexpectedStatus,
+// which can be checked with errors.Is.
+//
+// StatusCode is an HTTP status. A status of gRPC is converted into the equivalent HTTP status.
+type InferenceError struct {
+	StatusCode int
+	// Message is the error message of the response body.
+	Message string
+}
+
+func (e *InferenceError) Error() string {
+	return fmt.Sprintf("%s: status %d: %s", e.Unwrap(), e.StatusCode, e.Message)
+}
+
+func (e *InferenceError) Unwrap() error {
+	switch {
+	case e.StatusCode == http.StatusBadRequest:
+		return ErrBadRequest
+	case e.StatusCode == http.StatusNotFound:
+		return ErrModelNotFound
+	case e.StatusCode == http.StatusServiceUnavailable:
+		return ErrModelNotReady
+	case e.StatusCode >= 500:
+		return ErrServerError
+	default:
+		return ErrUnexpectedStatus
+	}
+}
+
+// statusCodes is the equivalence between gRPC status codes and HTTP statuses.
+var statusCodes = []struct {
+	code       codes.Code
+	statusCode int
+}{
+	{codes.InvalidArgument, http.StatusBadRequest},
+	{codes.Unauthenticated, http.StatusUnauthorized},
+	{codes.PermissionDenied, http.StatusForbidden},
+	{codes.NotFound, http.StatusNotFound},
+	{codes.AlreadyExists, http.StatusConflict},
+	{codes.ResourceExhausted, http.StatusTooManyRequests},
+	{codes.Unimplemented, http.StatusNotImplemented},
+	{codes.Unavailable, http.StatusServiceUnavailable},
+	{codes.DeadlineExceeded, http.StatusGatewayTimeout},
+	{codes.Internal, http.StatusInternalServerError},
+}
+
+// GRPCStatus returns the gRPC status equivalent to the error,
+// so that a gRPC server responds with it when its handler returns the error.
+func (e *InferenceError) GRPCStatus() *status.Status {
+	for _, s := range statusCodes {
+		if s.statusCode == e.StatusCode {
+			return status.New(s.code, e.Message)
+		}
+	}
+	return status.New(codes.Unknown, e.Message)
+}
+
+// fromGRPCStatus converts an error of a gRPC call into *InferenceError.
+// Errors caused by the context of the call are returned as they are.
+func fromGRPCStatus(err error) error {
+	s, ok := status.FromError(err)
+	if !ok || s.Code() == codes.Canceled || s.Code() == codes.DeadlineExceeded {
+		return err
+	}
+
+	statusCode := http.StatusInternalServerError
+	for _, e := range statusCodes {
+		if e.code == s.Code() {
+			statusCode = e.statusCode
+		}
+	}
+	return &InferenceError{StatusCode: statusCode, Message: s.Message()}
+}
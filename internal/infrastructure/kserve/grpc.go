@@ -0,0 +1,169 @@
+package kserve
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Goboolean/common/pkg/resolver"
+	"github.com/Goboolean/core-system.worker/internal/infrastructure/kserve/inference"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// DefaultMaxMessageSize is the maximum size of a message that GRPCClient sends or receives.
+const DefaultMaxMessageSize = 64 << 20
+
+// GRPCClient is the implementation of Client using GRPCInferenceService of the Open Inference Protocol v2.
+// Tensors of a request are sent as raw contents, which avoids encoding every element of float32 data.
+type GRPCClient struct {
+	modelID      string
+	modelVersion string
+
+	conn    *grpc.ClientConn
+	service inference.GRPCInferenceServiceClient
+}
+
+// NewGRPCClient creates a new instance of GRPCClient.
+// The connection is established lazily, so NewGRPCClient does not fail even if the service is not reachable.
+//
+// Config list
+// "host": The address of the gRPC endpoint of the inference service. Example: "kserve:8081"
+// "modelID": The name of the model to request inferences.
+// "modelVersion": (optional) The version of the model.
+func NewGRPCClient(c *resolver.ConfigMap) (*GRPCClient, error) {
+
+	id, err := c.GetStringKey("modelID")
+	if err != nil {
+		return nil, fmt.Errorf("create kserve grpc client: %w", err)
+	}
+
+	host, err := c.GetStringKey("host")
+	if err != nil {
+		return nil, fmt.Errorf("create kserve grpc client: %w", err)
+	}
+
+	version, _, err := c.GetStringKeyOptional("modelVersion")
+	if err != nil {
+		return nil, fmt.Errorf("create kserve grpc client: %w", err)
+	}
+
+	if host == "" || id == "" {
+		return nil, fmt.Errorf("create kserve grpc client: %w", ErrInvalidConfig)
+	}
+
+	conn, err := grpc.Dial(host,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(DefaultMaxMessageSize),
+			grpc.MaxCallSendMsgSize(DefaultMaxMessageSize),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create kserve grpc client: %w: %w", ErrInvalidConfig, err)
+	}
+
+	return &GRPCClient{
+		modelID:      id,
+		modelVersion: version,
+		conn:         conn,
+		service:      inference.NewGRPCInferenceServiceClient(conn),
+	}, nil
+}
+
+// Infer sends an inference request of the Open Inference Protocol v2 and returns the response.
+//
+// If the service responds with an error status, Infer returns *InferenceError.
+func (c *GRPCClient) Infer(ctx context.Context, inferenceReq *InferenceRequest) (*InferenceResponse, error) {
+	req, err := encodeModelInferRequest(c.modelID, c.modelVersion, inferenceReq)
+	if err != nil {
+		return nil, fmt.Errorf("inference: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+	defer cancel()
+
+	res, err := c.service.ModelInfer(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("inference: %w", fromGRPCStatus(err))
+	}
+
+	out, err := decodeModelInferResponse(res)
+	if err != nil {
+		return nil, fmt.Errorf("inference: %w: %w", ErrInvalidResponse, err)
+	}
+
+	return out, nil
+}
+
+// RequestInference sends a single FP32 input tensor and returns the first output tensor as float32.
+func (c *GRPCClient) RequestInference(ctx context.Context, shape []int, input []float32) ([]float32, error) {
+	return RequestInference(ctx, c, shape, input)
+}
+
+// ModelReady reports whether the model is ready to serve inferences.
+func (c *GRPCClient) ModelReady(ctx context.Context) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+	defer cancel()
+
+	res, err := c.service.ModelReady(ctx, &inference.ModelReadyRequest{
+		Name:    c.modelID,
+		Version: c.modelVersion,
+	})
+	if err != nil {
+		return false, fmt.Errorf("model ready: %w", fromGRPCStatus(err))
+	}
+
+	return res.Ready, nil
+}
+
+// ModelMetadata returns the metadata of the model.
+func (c *GRPCClient) ModelMetadata(ctx context.Context) (*ModelMetadata, error) {
+	ctx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+	defer cancel()
+
+	res, err := c.service.ModelMetadata(ctx, &inference.ModelMetadataRequest{
+		Name:    c.modelID,
+		Version: c.modelVersion,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("model metadata: %w", fromGRPCStatus(err))
+	}
+
+	return &ModelMetadata{
+		Name:     res.Name,
+		Versions: res.Versions,
+		Platform: res.Platform,
+		Inputs:   fromTensorMetadata(res.Inputs),
+		Outputs:  fromTensorMetadata(res.Outputs),
+	}, nil
+}
+
+// Close closes the connection to the inference service.
+func (c *GRPCClient) Close() error {
+	return c.conn.Close()
+}
+
+func fromTensorMetadata(in []*inference.ModelMetadataResponse_TensorMetadata) []TensorMetadata {
+	res := make([]TensorMetadata, len(in))
+	for i, e := range in {
+		res[i] = TensorMetadata{
+			Name:     e.Name,
+			Datatype: Datatype(e.Datatype),
+			Shape:    e.Shape,
+		}
+	}
+	return res
+}
+
+// ToTensorMetadata converts metadata into the metadata of GRPCInferenceService, for stand-in inference services.
+func ToTensorMetadata(in []TensorMetadata) []*inference.ModelMetadataResponse_TensorMetadata {
+	res := make([]*inference.ModelMetadataResponse_TensorMetadata, len(in))
+	for i, e := range in {
+		res[i] = &inference.ModelMetadataResponse_TensorMetadata{
+			Name:     e.Name,
+			Datatype: string(e.Datatype),
+			Shape:    e.Shape,
+		}
+	}
+	return res
+}
@@ -0,0 +1,515 @@
+package kserve
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/Goboolean/core-system.worker/internal/infrastructure/kserve/inference"
+)
+
+// encodeModelInferRequest converts req into the gRPC request. The data of every input is sent as raw contents.
+func encodeModelInferRequest(modelName, modelVersion string, req *InferenceRequest) (*inference.ModelInferRequest, error) {
+	params, err := encodeParameters(req.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs, raw, err := encodeTensors(req.Inputs, true)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := make([]*inference.ModelInferRequest_InferRequestedOutputTensor, len(req.Outputs))
+	for i, e := range req.Outputs {
+		p, err := encodeParameters(e.Parameters)
+		if err != nil {
+			return nil, err
+		}
+		outputs[i] = &inference.ModelInferRequest_InferRequestedOutputTensor{Name: e.Name, Parameters: p}
+	}
+
+	return &inference.ModelInferRequest{
+		ModelName:        modelName,
+		ModelVersion:     modelVersion,
+		Id:               req.ID,
+		Parameters:       params,
+		Inputs:           inputs,
+		Outputs:          outputs,
+		RawInputContents: raw,
+	}, nil
+}
+
+// DecodeModelInferRequest converts the gRPC request into InferenceRequest.
+// It is the counterpart of the conversion made by GRPCClient, for stand-in inference services.
+func DecodeModelInferRequest(req *inference.ModelInferRequest) (*InferenceRequest, error) {
+	inputs, err := decodeTensors(req.Inputs, req.RawInputContents)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := make([]RequestedOutput, len(req.Outputs))
+	for i, e := range req.Outputs {
+		outputs[i] = RequestedOutput{Name: e.Name, Parameters: decodeParameters(e.Parameters)}
+	}
+	if len(outputs) == 0 {
+		outputs = nil
+	}
+
+	return &InferenceRequest{
+		ID:         req.Id,
+		Parameters: decodeParameters(req.Parameters),
+		Inputs:     inputs,
+		Outputs:    outputs,
+	}, nil
+}
+
+// EncodeModelInferResponse converts res into the gRPC response.
+// If raw is true, the data of every output is sent as raw contents, otherwise as typed contents.
+// It is the counterpart of the conversion made by GRPCClient, for stand-in inference services.
+func EncodeModelInferResponse(res *InferenceResponse, raw bool) (*inference.ModelInferResponse, error) {
+	params, err := encodeParameters(res.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs, rawOutputs, err := encodeTensors(res.Outputs, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	outputTensors := make([]*inference.ModelInferResponse_InferOutputTensor, len(outputs))
+	for i, e := range outputs {
+		outputTensors[i] = &inference.ModelInferResponse_InferOutputTensor{
+			Name:       e.Name,
+			Datatype:   e.Datatype,
+			Shape:      e.Shape,
+			Parameters: e.Parameters,
+			Contents:   e.Contents,
+		}
+	}
+
+	return &inference.ModelInferResponse{
+		ModelName:         res.ModelName,
+		ModelVersion:      res.ModelVersion,
+		Id:                res.ID,
+		Parameters:        params,
+		Outputs:           outputTensors,
+		RawOutputContents: rawOutputs,
+	}, nil
+}
+
+// decodeModelInferResponse converts the gRPC response into InferenceResponse.
+func decodeModelInferResponse(res *inference.ModelInferResponse) (*InferenceResponse, error) {
+	outputs, err := decodeTensors(res.Outputs, res.RawOutputContents)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InferenceResponse{
+		ModelName:    res.ModelName,
+		ModelVersion: res.ModelVersion,
+		ID:           res.Id,
+		Parameters:   decodeParameters(res.Parameters),
+		Outputs:      outputs,
+	}, nil
+}
+
+// encodeTensors converts tensors into the input tensors of the gRPC request.
+// The output tensors of the gRPC response have the same fields, so they are converted from the input tensors.
+func encodeTensors(tensors []Tensor, raw bool) ([]*inference.ModelInferRequest_InferInputTensor, [][]byte, error) {
+	res := make([]*inference.ModelInferRequest_InferInputTensor, len(tensors))
+	var rawContents [][]byte
+	if raw {
+		rawContents = make([][]byte, len(tensors))
+	}
+
+	for i := range tensors {
+		t := &tensors[i]
+		if err := t.Validate(); err != nil {
+			return nil, nil, err
+		}
+
+		params, err := encodeParameters(t.Parameters)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		res[i] = &inference.ModelInferRequest_InferInputTensor{
+			Name:       t.Name,
+			Datatype:   string(t.Datatype),
+			Shape:      t.Shape,
+			Parameters: params,
+		}
+
+		if raw {
+			rawContents[i] = encodeRaw(t)
+			continue
+		}
+
+		res[i].Contents, err = encodeContents(t)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return res, rawContents, nil
+}
+
+// tensorMessage is an input tensor of the gRPC request or an output tensor of the gRPC response.
+type tensorMessage interface {
+	GetName() string
+	GetDatatype() string
+	GetShape() []int64
+	GetParameters() map[string]*inference.InferParameter
+	GetContents() *inference.InferTensorContents
+}
+
+func decodeTensors[T tensorMessage](tensors []T, rawContents [][]byte) ([]Tensor, error) {
+	if len(rawContents) > 0 && len(rawContents) != len(tensors) {
+		return nil, fmt.Errorf("%w: %d raw contents for %d tensors", ErrInvalidTensor, len(rawContents), len(tensors))
+	}
+
+	res := make([]Tensor, len(tensors))
+	for i, e := range tensors {
+		t := Tensor{
+			Name:       e.GetName(),
+			Shape:      e.GetShape(),
+			Datatype:   Datatype(e.GetDatatype()),
+			Parameters: decodeParameters(e.GetParameters()),
+		}
+
+		var err error
+		if len(rawContents) > 0 {
+			t.Data, err = decodeRaw(t.Datatype, rawContents[i])
+		} else {
+			t.Data, err = decodeContents(t.Datatype, e.GetContents())
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: tensor %q: %w", ErrInvalidTensor, e.GetName(), err)
+		}
+
+		if err := t.Validate(); err != nil {
+			return nil, err
+		}
+		res[i] = t
+	}
+
+	return res, nil
+}
+
+func encodeParameters(params map[string]any) (map[string]*inference.InferParameter, error) {
+	if len(params) == 0 {
+		return nil, nil
+	}
+
+	res := make(map[string]*inference.InferParameter, len(params))
+	for k, v := range params {
+		p := &inference.InferParameter{}
+		switch v := v.(type) {
+		case bool:
+			p.ParameterChoice = &inference.InferParameter_BoolParam{BoolParam: v}
+		case string:
+			p.ParameterChoice = &inference.InferParameter_StringParam{StringParam: v}
+		case int64:
+			p.ParameterChoice = &inference.InferParameter_Int64Param{Int64Param: v}
+		case int:
+			p.ParameterChoice = &inference.InferParameter_Int64Param{Int64Param: int64(v)}
+		case int32:
+			p.ParameterChoice = &inference.InferParameter_Int64Param{Int64Param: int64(v)}
+		case uint64:
+			p.ParameterChoice = &inference.InferParameter_Uint64Param{Uint64Param: v}
+		case uint:
+			p.ParameterChoice = &inference.InferParameter_Uint64Param{Uint64Param: uint64(v)}
+		case uint32:
+			p.ParameterChoice = &inference.InferParameter_Uint64Param{Uint64Param: uint64(v)}
+		case float64:
+			p.ParameterChoice = &inference.InferParameter_DoubleParam{DoubleParam: v}
+		case float32:
+			p.ParameterChoice = &inference.InferParameter_DoubleParam{DoubleParam: float64(v)}
+		default:
+			return nil, fmt.Errorf("%w: parameter %q of %T", ErrInvalidParameter, k, v)
+		}
+		res[k] = p
+	}
+	return res, nil
+}
+
+func decodeParameters(params map[string]*inference.InferParameter) map[string]any {
+	if len(params) == 0 {
+		return nil
+	}
+
+	res := make(map[string]any, len(params))
+	for k, v := range params {
+		switch p := v.GetParameterChoice().(type) {
+		case *inference.InferParameter_BoolParam:
+			res[k] = p.BoolParam
+		case *inference.InferParameter_Int64Param:
+			res[k] = p.Int64Param
+		case *inference.InferParameter_StringParam:
+			res[k] = p.StringParam
+		case *inference.InferParameter_DoubleParam:
+			res[k] = p.DoubleParam
+		case *inference.InferParameter_Uint64Param:
+			res[k] = p.Uint64Param
+		default:
+			res[k] = nil
+		}
+	}
+	return res
+}
+
+// encodeRaw encodes the data of a validated tensor in little-endian order.
+// Each element of BYTES is prefixed with its length as a 4-byte integer.
+func encodeRaw(t *Tensor) []byte {
+	switch v := t.Data.(type) {
+	case []bool:
+		res := make([]byte, len(v))
+		for i, e := range v {
+			if e {
+				res[i] = 1
+			}
+		}
+		return res
+	case []uint8:
+		return append([]byte(nil), v...)
+	case []int8:
+		return appendLittleEndian(nil, v, func(b []byte, e int8) []byte { return append(b, byte(e)) })
+	case []uint16:
+		return appendLittleEndian(nil, v, binary.LittleEndian.AppendUint16)
+	case []int16:
+		return appendLittleEndian(nil, v, func(b []byte, e int16) []byte { return binary.LittleEndian.AppendUint16(b, uint16(e)) })
+	case []uint32:
+		return appendLittleEndian(nil, v, binary.LittleEndian.AppendUint32)
+	case []int32:
+		return appendLittleEndian(nil, v, func(b []byte, e int32) []byte { return binary.LittleEndian.AppendUint32(b, uint32(e)) })
+	case []uint64:
+		return appendLittleEndian(nil, v, binary.LittleEndian.AppendUint64)
+	case []int64:
+		return appendLittleEndian(nil, v, func(b []byte, e int64) []byte { return binary.LittleEndian.AppendUint64(b, uint64(e)) })
+	case []float32:
+		if t.Datatype == FP16 {
+			return appendLittleEndian(nil, v, func(b []byte, e float32) []byte { return binary.LittleEndian.AppendUint16(b, float32ToHalf(e)) })
+		}
+		return appendLittleEndian(nil, v, func(b []byte, e float32) []byte { return binary.LittleEndian.AppendUint32(b, math.Float32bits(e)) })
+	case []float64:
+		return appendLittleEndian(nil, v, func(b []byte, e float64) []byte { return binary.LittleEndian.AppendUint64(b, math.Float64bits(e)) })
+	case []string:
+		return appendLittleEndian(nil, v, func(b []byte, e string) []byte {
+			return append(binary.LittleEndian.AppendUint32(b, uint32(len(e))), e...)
+		})
+	default:
+		return nil
+	}
+}
+
+func appendLittleEndian[T any](b []byte, v []T, put func([]byte, T) []byte) []byte {
+	for _, e := range v {
+		b = put(b, e)
+	}
+	return b
+}
+
+func decodeRaw(datatype Datatype, b []byte) (any, error) {
+	switch datatype {
+	case BOOL:
+		return decodeFixed(b, 1, func(b []byte) bool { return b[0] != 0 })
+	case UINT8:
+		return append([]uint8{}, b...), nil
+	case INT8:
+		return decodeFixed(b, 1, func(b []byte) int8 { return int8(b[0]) })
+	case UINT16:
+		return decodeFixed(b, 2, binary.LittleEndian.Uint16)
+	case INT16:
+		return decodeFixed(b, 2, func(b []byte) int16 { return int16(binary.LittleEndian.Uint16(b)) })
+	case UINT32:
+		return decodeFixed(b, 4, binary.LittleEndian.Uint32)
+	case INT32:
+		return decodeFixed(b, 4, func(b []byte) int32 { return int32(binary.LittleEndian.Uint32(b)) })
+	case UINT64:
+		return decodeFixed(b, 8, binary.LittleEndian.Uint64)
+	case INT64:
+		return decodeFixed(b, 8, func(b []byte) int64 { return int64(binary.LittleEndian.Uint64(b)) })
+	case FP16:
+		return decodeFixed(b, 2, func(b []byte) float32 { return halfToFloat32(binary.LittleEndian.Uint16(b)) })
+	case FP32:
+		return decodeFixed(b, 4, func(b []byte) float32 { return math.Float32frombits(binary.LittleEndian.Uint32(b)) })
+	case FP64:
+		return decodeFixed(b, 8, func(b []byte) float64 { return math.Float64frombits(binary.LittleEndian.Uint64(b)) })
+	case BYTES:
+		res := make([]string, 0)
+		for len(b) > 0 {
+			if len(b) < 4 {
+				return nil, fmt.Errorf("truncated length of an element")
+			}
+			n := binary.LittleEndian.Uint32(b)
+			b = b[4:]
+			if uint64(len(b)) < uint64(n) {
+				return nil, fmt.Errorf("truncated element of %d bytes", n)
+			}
+			res = append(res, string(b[:n]))
+			b = b[n:]
+		}
+		return res, nil
+	default:
+		return nil, fmt.Errorf("unknown datatype %q", datatype)
+	}
+}
+
+func decodeFixed[T any](b []byte, size int, get func([]byte) T) ([]T, error) {
+	if len(b)%size != 0 {
+		return nil, fmt.Errorf("%d bytes are not a multiple of %d", len(b), size)
+	}
+
+	res := make([]T, len(b)/size)
+	for i := range res {
+		res[i] = get(b[i*size:])
+	}
+	return res, nil
+}
+
+// encodeContents converts the data of a validated tensor into typed contents.
+func encodeContents(t *Tensor) (*inference.InferTensorContents, error) {
+	switch v := t.Data.(type) {
+	case []bool:
+		return &inference.InferTensorContents{BoolContents: v}, nil
+	case []int8:
+		return &inference.InferTensorContents{IntContents: convert[int8, int32](v)}, nil
+	case []int16:
+		return &inference.InferTensorContents{IntContents: convert[int16, int32](v)}, nil
+	case []int32:
+		return &inference.InferTensorContents{IntContents: v}, nil
+	case []int64:
+		return &inference.InferTensorContents{Int64Contents: v}, nil
+	case []uint8:
+		return &inference.InferTensorContents{UintContents: convert[uint8, uint32](v)}, nil
+	case []uint16:
+		return &inference.InferTensorContents{UintContents: convert[uint16, uint32](v)}, nil
+	case []uint32:
+		return &inference.InferTensorContents{UintContents: v}, nil
+	case []uint64:
+		return &inference.InferTensorContents{Uint64Contents: v}, nil
+	case []float32:
+		if t.Datatype == FP16 {
+			return nil, fmt.Errorf("%w: tensor %q of FP16 must be sent as raw contents", ErrInvalidTensor, t.Name)
+		}
+		return &inference.InferTensorContents{Fp32Contents: v}, nil
+	case []float64:
+		return &inference.InferTensorContents{Fp64Contents: v}, nil
+	case []string:
+		res := make([][]byte, len(v))
+		for i, e := range v {
+			res[i] = []byte(e)
+		}
+		return &inference.InferTensorContents{BytesContents: res}, nil
+	default:
+		return nil, fmt.Errorf("%w: tensor %q of %s has data of %T", ErrInvalidTensor, t.Name, t.Datatype, t.Data)
+	}
+}
+
+func decodeContents(datatype Datatype, c *inference.InferTensorContents) (any, error) {
+	if c == nil {
+		c = &inference.InferTensorContents{}
+	}
+
+	switch datatype {
+	case BOOL:
+		return append([]bool{}, c.BoolContents...), nil
+	case INT8:
+		return convert[int32, int8](c.IntContents), nil
+	case INT16:
+		return convert[int32, int16](c.IntContents), nil
+	case INT32:
+		return append([]int32{}, c.IntContents...), nil
+	case INT64:
+		return append([]int64{}, c.Int64Contents...), nil
+	case UINT8:
+		return convert[uint32, uint8](c.UintContents), nil
+	case UINT16:
+		return convert[uint32, uint16](c.UintContents), nil
+	case UINT32:
+		return append([]uint32{}, c.UintContents...), nil
+	case UINT64:
+		return append([]uint64{}, c.Uint64Contents...), nil
+	case FP32:
+		return append([]float32{}, c.Fp32Contents...), nil
+	case FP64:
+		return append([]float64{}, c.Fp64Contents...), nil
+	case BYTES:
+		res := make([]string, len(c.BytesContents))
+		for i, e := range c.BytesContents {
+			res[i] = string(e)
+		}
+		return res, nil
+	case FP16:
+		return nil, fmt.Errorf("FP16 has no typed contents")
+	default:
+		return nil, fmt.Errorf("unknown datatype %q", datatype)
+	}
+}
+
+// float32ToHalf converts f into IEEE 754 half precision, rounding to the nearest even.
+func float32ToHalf(f float32) uint16 {
+	b := math.Float32bits(f)
+	sign := uint16(b>>16) & 0x8000
+	exp := int32(b>>23&0xff) - 127 + 15
+	mant := b & 0x7fffff
+
+	switch {
+	case b&0x7fffffff == 0:
+		return sign
+	case b&0x7f800000 == 0x7f800000 && mant != 0:
+		return sign | 0x7e00
+	case exp >= 0x1f:
+		return sign | 0x7c00
+	case exp <= 0:
+		if exp < -10 {
+			return sign
+		}
+		// subnormal
+		mant |= 0x800000
+		shift := uint32(14 - exp)
+		half := mant >> shift
+		rem := mant & (1<<shift - 1)
+		mid := uint32(1) << (shift - 1)
+		if rem > mid || (rem == mid && half&1 == 1) {
+			half++
+		}
+		return sign | uint16(half)
+	}
+
+	// A carry of the rounding may overflow into the exponent, which results in the next power of two or infinity.
+	half := uint32(exp)<<10 | mant>>13
+	rem := mant & 0x1fff
+	if rem > 0x1000 || (rem == 0x1000 && half&1 == 1) {
+		half++
+	}
+	return sign | uint16(half)
+}
+
+// halfToFloat32 converts IEEE 754 half precision into float32.
+func halfToFloat32(h uint16) float32 {
+	sign := uint32(h&0x8000) << 16
+	exp := uint32(h>>10) & 0x1f
+	mant := uint32(h & 0x3ff)
+
+	switch exp {
+	case 0:
+		if mant == 0 {
+			return math.Float32frombits(sign)
+		}
+		// subnormal
+		e := int32(1)
+		for mant&0x400 == 0 {
+			mant <<= 1
+			e--
+		}
+		mant &= 0x3ff
+		return math.Float32frombits(sign | uint32(e-15+127)<<23 | mant<<13)
+	case 0x1f:
+		return math.Float32frombits(sign | 0x7f800000 | mant<<13)
+	}
+
+	return math.Float32frombits(sign | (exp-15+127)<<23 | mant<<13)
+}
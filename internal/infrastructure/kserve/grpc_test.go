@@ -0,0 +1,246 @@
+package kserve_test
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"math"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/Goboolean/common/pkg/resolver"
+	"github.com/Goboolean/core-system.worker/internal/infrastructure/kserve"
+	"github.com/Goboolean/core-system.worker/internal/infrastructure/kserve/inference"
+	"github.com/Goboolean/core-system.worker/internal/infrastructure/kserve/kservetest"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+type GRPCClientTestSuite struct {
+	suite.Suite
+	server *kservetest.GRPCServer
+}
+
+func (suite *GRPCClientTestSuite) SetupTest() {
+	suite.server = kservetest.NewGRPCServer()
+}
+
+func (suite *GRPCClientTestSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+func (suite *GRPCClientTestSuite) newClient(config resolver.ConfigMap) *kserve.GRPCClient {
+	if _, ok := config["host"]; !ok {
+		config["host"] = suite.server.Addr
+	}
+	c, err := kserve.NewGRPCClient(&config)
+	suite.Require().NoError(err)
+	suite.T().Cleanup(func() { c.Close() })
+	return c
+}
+
+func everyDatatype() []kserve.Tensor {
+	return []kserve.Tensor{
+		{Name: "bool", Shape: []int64{2}, Datatype: kserve.BOOL, Data: []bool{true, false}},
+		{Name: "uint8", Shape: []int64{2}, Datatype: kserve.UINT8, Data: []uint8{0, 255}},
+		{Name: "uint16", Shape: []int64{1}, Datatype: kserve.UINT16, Data: []uint16{65535}},
+		{Name: "uint32", Shape: []int64{1}, Datatype: kserve.UINT32, Data: []uint32{4294967295}},
+		{Name: "uint64", Shape: []int64{1}, Datatype: kserve.UINT64, Data: []uint64{18446744073709551615}},
+		{Name: "int8", Shape: []int64{2}, Datatype: kserve.INT8, Data: []int8{-128, 127}},
+		{Name: "int16", Shape: []int64{1}, Datatype: kserve.INT16, Data: []int16{-32768}},
+		{Name: "int32", Shape: []int64{1}, Datatype: kserve.INT32, Data: []int32{-2147483648}},
+		{Name: "int64", Shape: []int64{1}, Datatype: kserve.INT64, Data: []int64{-9223372036854775808}},
+		{Name: "fp32", Shape: []int64{2, 2}, Datatype: kserve.FP32, Data: []float32{1, 2.5, -3, 4}},
+		{Name: "fp64", Shape: []int64{1}, Datatype: kserve.FP64, Data: []float64{0.1}},
+		{Name: "bytes", Shape: []int64{3}, Datatype: kserve.BYTES, Data: []string{"a", "", "bc"}},
+	}
+}
+
+func (suite *GRPCClientTestSuite) TestInfer_ShouldSendAndReceiveEveryDatatype_WhenOutputsAreRaw() {
+	//arrange
+	suite.server.AddModel("model", kservetest.Echo)
+	c := suite.newClient(resolver.ConfigMap{"modelID": "model", "modelVersion": "2"})
+
+	// FP16은 raw contents로만 보낼 수 있다.
+	inputs := append(everyDatatype(), kserve.Tensor{
+		Name: "fp16", Shape: []int64{5}, Datatype: kserve.FP16, Data: []float32{0.5, -2, 65504, 0.000061035156, 0},
+	})
+
+	//act
+	res, err := c.Infer(context.Background(), &kserve.InferenceRequest{
+		ID:         "42",
+		Parameters: map[string]any{"temperature": 0.5, "topK": int64(3), "greedy": true, "mode": "fast"},
+		Inputs:     inputs,
+	})
+
+	//assert
+	suite.Require().NoError(err)
+	suite.Equal("model", res.ModelName)
+	suite.Equal("2", res.ModelVersion)
+	suite.Equal("42", res.ID)
+	suite.Equal(inputs, res.Outputs)
+	suite.Equal(map[string]any{"temperature": 0.5, "topK": int64(3), "greedy": true, "mode": "fast"}, suite.server.Requests()[0].Parameters)
+}
+
+func (suite *GRPCClientTestSuite) TestInfer_ShouldDecodeTypedContents_WhenOutputsAreNotRaw() {
+	//arrange
+	suite.server.AddModel("model", kservetest.Echo)
+	suite.server.SetTypedOutput(true)
+	c := suite.newClient(resolver.ConfigMap{"modelID": "model"})
+	inputs := everyDatatype()
+
+	//act
+	res, err := c.Infer(context.Background(), &kserve.InferenceRequest{Inputs: inputs})
+
+	//assert
+	suite.Require().NoError(err)
+	suite.Equal(inputs, res.Outputs)
+}
+
+func (suite *GRPCClientTestSuite) TestInfer_ShouldReturnTypedError_WhenServiceRespondsWithError() {
+	//arrange
+	suite.server.AddModel("ready", func(req *kserve.InferenceRequest) (*kserve.InferenceResponse, error) {
+		return nil, &kserve.InferenceError{StatusCode: http.StatusBadRequest, Message: "bad input"}
+	})
+	suite.server.AddModel("failing", func(req *kserve.InferenceRequest) (*kserve.InferenceResponse, error) {
+		return nil, errors.New("fail")
+	})
+	suite.server.AddModel("notReady", kservetest.Echo)
+	suite.server.SetReady("notReady", false)
+
+	for _, tc := range []struct {
+		model string
+		err   error
+	}{
+		{model: "ready", err: kserve.ErrBadRequest},
+		{model: "failing", err: kserve.ErrServerError},
+		{model: "notReady", err: kserve.ErrModelNotReady},
+		{model: "unknown", err: kserve.ErrModelNotFound},
+	} {
+		c := suite.newClient(resolver.ConfigMap{"modelID": tc.model})
+
+		//act
+		_, err := c.RequestInference(context.Background(), []int{1}, []float32{1})
+
+		//assert
+		suite.ErrorIs(err, tc.err, tc.model)
+		var inferenceErr *kserve.InferenceError
+		suite.True(errors.As(err, &inferenceErr), tc.model)
+	}
+}
+
+func (suite *GRPCClientTestSuite) TestModelReady_ShouldReportReadinessOfModel() {
+	//arrange
+	suite.server.AddModel("ready", kservetest.Echo)
+	suite.server.AddModel("notReady", kservetest.Echo)
+	suite.server.SetReady("notReady", false)
+
+	//act
+	ready, readyErr := suite.newClient(resolver.ConfigMap{"modelID": "ready"}).ModelReady(context.Background())
+	notReady, notReadyErr := suite.newClient(resolver.ConfigMap{"modelID": "notReady"}).ModelReady(context.Background())
+	_, unknownErr := suite.newClient(resolver.ConfigMap{"modelID": "unknown"}).ModelReady(context.Background())
+
+	//assert
+	suite.NoError(readyErr)
+	suite.True(ready)
+	suite.NoError(notReadyErr)
+	suite.False(notReady)
+	suite.ErrorIs(unknownErr, kserve.ErrModelNotFound)
+}
+
+func (suite *GRPCClientTestSuite) TestModelMetadata_ShouldReturnMetadataOfModel() {
+	//arrange
+	metadata := kserve.ModelMetadata{
+		Name:     "model",
+		Versions: []string{"1", "2"},
+		Platform: "onnxruntime",
+		Inputs:   []kserve.TensorMetadata{{Name: "input", Datatype: kserve.FP32, Shape: []int64{-1, 4}}},
+		Outputs:  []kserve.TensorMetadata{{Name: "output", Datatype: kserve.FP32, Shape: []int64{-1, 4}}},
+	}
+	suite.server.AddModel("model", kservetest.Echo)
+	suite.server.SetMetadata("model", metadata)
+	c := suite.newClient(resolver.ConfigMap{"modelID": "model"})
+
+	//act
+	res, err := c.ModelMetadata(context.Background())
+
+	//assert
+	suite.NoError(err)
+	suite.Equal(&metadata, res)
+}
+
+// rawCapture is a GRPCInferenceService recording the raw request of ModelInfer.
+type rawCapture struct {
+	inference.UnimplementedGRPCInferenceServiceServer
+	req *inference.ModelInferRequest
+}
+
+func (r *rawCapture) ModelReady(context.Context, *inference.ModelReadyRequest) (*inference.ModelReadyResponse, error) {
+	return &inference.ModelReadyResponse{}, nil
+}
+
+func (r *rawCapture) ModelMetadata(context.Context, *inference.ModelMetadataRequest) (*inference.ModelMetadataResponse, error) {
+	return &inference.ModelMetadataResponse{}, nil
+}
+
+func (r *rawCapture) ModelInfer(_ context.Context, in *inference.ModelInferRequest) (*inference.ModelInferResponse, error) {
+	r.req = in
+	return &inference.ModelInferResponse{
+		Outputs:           []*inference.ModelInferResponse_InferOutputTensor{{Name: "output", Datatype: "FP32", Shape: []int64{1}}},
+		RawOutputContents: [][]byte{binary.LittleEndian.AppendUint32(nil, math.Float32bits(7))},
+	}, nil
+}
+
+func (suite *GRPCClientTestSuite) TestRequestInference_ShouldSendFloat32AsRawContents() {
+	//arrange
+	capture := &rawCapture{}
+	s := grpc.NewServer()
+	inference.RegisterGRPCInferenceServiceServer(s, capture)
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+	go s.Serve(l)
+	defer s.Stop()
+
+	c := suite.newClient(resolver.ConfigMap{"host": l.Addr().String(), "modelID": "model"})
+
+	//act
+	out, err := c.RequestInference(context.Background(), []int{1, 2}, []float32{1, -1})
+
+	//assert
+	suite.Require().NoError(err)
+	suite.Equal([]float32{7}, out)
+	suite.Equal("model", capture.req.ModelName)
+	suite.Require().Len(capture.req.Inputs, 1)
+	suite.True(proto.Equal(&inference.ModelInferRequest_InferInputTensor{Name: kserve.DefaultInputName, Datatype: "FP32", Shape: []int64{1, 2}}, capture.req.Inputs[0]), capture.req.Inputs[0])
+	suite.Equal([][]byte{{0x00, 0x00, 0x80, 0x3f, 0x00, 0x00, 0x80, 0xbf}}, capture.req.RawInputContents)
+}
+
+func (suite *GRPCClientTestSuite) TestNew_ShouldSelectClientByProtocol() {
+	//arrange
+	for _, tc := range []struct {
+		protocol string
+		check    func(kserve.Client, error)
+	}{
+		{protocol: "", check: func(c kserve.Client, err error) { suite.IsType(&kserve.ClientImpl{}, c) }},
+		{protocol: kserve.ProtocolREST, check: func(c kserve.Client, err error) { suite.IsType(&kserve.ClientImpl{}, c) }},
+		{protocol: kserve.ProtocolGRPC, check: func(c kserve.Client, err error) {
+			suite.Require().IsType(&kserve.GRPCClient{}, c)
+			c.(*kserve.GRPCClient).Close()
+		}},
+		{protocol: "thrift", check: func(c kserve.Client, err error) { suite.ErrorIs(err, kserve.ErrInvalidConfig) }},
+	} {
+		config := resolver.ConfigMap{"host": suite.server.Addr, "modelID": "model", "protocol": tc.protocol}
+
+		//act
+		c, err := kserve.New(&config)
+
+		//assert
+		tc.check(c, err)
+	}
+}
+
+func TestGRPCClient(t *testing.T) {
+	suite.Run(t, new(GRPCClientTestSuite))
+}
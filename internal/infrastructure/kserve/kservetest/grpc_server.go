@@ -0,0 +1,106 @@
+package kservetest
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+
+	"github.com/Goboolean/core-system.worker/internal/infrastructure/kserve"
+	"github.com/Goboolean/core-system.worker/internal/infrastructure/kserve/inference"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCServer is an in-process stand-in of a KServe inference service speaking GRPCInferenceService.
+// It listens on a local port, so it is reachable by kserve.GRPCClient at Addr.
+type GRPCServer struct {
+	*registry
+
+	// Addr is the address the server listens on, in the form of "127.0.0.1:port".
+	Addr string
+
+	server *grpc.Server
+	// typedOutput makes outputs sent as typed contents instead of raw contents.
+	typedOutput atomic.Bool
+}
+
+// NewGRPCServer starts a new GRPCServer without any model.
+// The caller should call Close when finished, to shut it down.
+func NewGRPCServer() *GRPCServer {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic("kservetest: failed to listen on a port: " + err.Error())
+	}
+
+	s := &GRPCServer{
+		registry: newRegistry(),
+		Addr:     l.Addr().String(),
+		server:   grpc.NewServer(),
+	}
+	inference.RegisterGRPCInferenceServiceServer(s.server, &grpcHandler{s: s})
+
+	go s.server.Serve(l)
+	return s
+}
+
+// SetTypedOutput sets whether outputs are sent as typed contents instead of raw contents.
+func (s *GRPCServer) SetTypedOutput(typed bool) {
+	s.typedOutput.Store(typed)
+}
+
+// Close shuts down the server.
+func (s *GRPCServer) Close() {
+	s.server.Stop()
+}
+
+// grpcHandler implements inference.GRPCInferenceServiceServer without exporting its methods from GRPCServer.
+type grpcHandler struct {
+	inference.UnimplementedGRPCInferenceServiceServer
+	s *GRPCServer
+}
+
+func (h *grpcHandler) ModelReady(_ context.Context, in *inference.ModelReadyRequest) (*inference.ModelReadyResponse, error) {
+	ready, err := h.s.ready(in.Name)
+	if err != nil {
+		return nil, err
+	}
+	return &inference.ModelReadyResponse{Ready: ready}, nil
+}
+
+func (h *grpcHandler) ModelMetadata(_ context.Context, in *inference.ModelMetadataRequest) (*inference.ModelMetadataResponse, error) {
+	m, err := h.s.modelMetadata(in.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &inference.ModelMetadataResponse{
+		Name:     m.Name,
+		Versions: m.Versions,
+		Platform: m.Platform,
+		Inputs:   kserve.ToTensorMetadata(m.Inputs),
+		Outputs:  kserve.ToTensorMetadata(m.Outputs),
+	}, nil
+}
+
+func (h *grpcHandler) ModelInfer(_ context.Context, in *inference.ModelInferRequest) (*inference.ModelInferResponse, error) {
+	req, err := kserve.DecodeModelInferRequest(in)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	res, err := h.s.infer(in.ModelName, req)
+	if err != nil {
+		return nil, err
+	}
+
+	res.ModelName = in.ModelName
+	res.ModelVersion = in.ModelVersion
+	res.ID = req.ID
+
+	out, err := kserve.EncodeModelInferResponse(res, !h.s.typedOutput.Load())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return out, nil
+}
@@ -0,0 +1,108 @@
+package kservetest
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/Goboolean/core-system.worker/internal/infrastructure/kserve"
+)
+
+// InferFunc computes the response of an inference request.
+// Returning *kserve.InferenceError makes the server respond with its status code and message.
+type InferFunc func(req *kserve.InferenceRequest) (*kserve.InferenceResponse, error)
+
+// Echo is an InferFunc that returns every input tensor as an output tensor of the same name.
+func Echo(req *kserve.InferenceRequest) (*kserve.InferenceResponse, error) {
+	return &kserve.InferenceResponse{Outputs: req.Inputs}, nil
+}
+
+// registry is the set of models served by a stand-in server, shared by every protocol.
+type registry struct {
+	mu       sync.Mutex
+	models   map[string]InferFunc
+	notReady map[string]bool
+	metadata map[string]kserve.ModelMetadata
+	requests []*kserve.InferenceRequest
+}
+
+func newRegistry() *registry {
+	return &registry{
+		models:   make(map[string]InferFunc),
+		notReady: make(map[string]bool),
+		metadata: make(map[string]kserve.ModelMetadata),
+	}
+}
+
+// AddModel serves the model of the given name with f.
+func (r *registry) AddModel(name string, f InferFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.models[name] = f
+}
+
+// SetReady sets whether the model is ready to serve inferences.
+// A model that is not ready responds with 503.
+func (r *registry) SetReady(name string, ready bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.notReady[name] = !ready
+}
+
+// SetMetadata sets the metadata of the model. The metadata defaults to the one having only the name.
+func (r *registry) SetMetadata(name string, m kserve.ModelMetadata) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.metadata[name] = m
+}
+
+// Requests returns the inference requests received so far.
+func (r *registry) Requests() []*kserve.InferenceRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*kserve.InferenceRequest(nil), r.requests...)
+}
+
+// ready reports whether the model is ready. It returns *kserve.InferenceError if the model is not found.
+func (r *registry) ready(name string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.models[name]; !ok {
+		return false, &kserve.InferenceError{StatusCode: http.StatusNotFound, Message: "model " + name + " is not found"}
+	}
+	return !r.notReady[name], nil
+}
+
+func (r *registry) modelMetadata(name string) (kserve.ModelMetadata, error) {
+	if _, err := r.ready(name); err != nil {
+		return kserve.ModelMetadata{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m, ok := r.metadata[name]
+	if !ok {
+		m = kserve.ModelMetadata{Name: name}
+	}
+	return m, nil
+}
+
+// infer records req and computes the response of the model.
+func (r *registry) infer(name string, req *kserve.InferenceRequest) (*kserve.InferenceResponse, error) {
+	ready, err := r.ready(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ready {
+		return nil, &kserve.InferenceError{StatusCode: http.StatusServiceUnavailable, Message: "model " + name + " is not ready"}
+	}
+
+	r.mu.Lock()
+	f := r.models[name]
+	r.requests = append(r.requests, req)
+	r.mu.Unlock()
+
+	return f(req)
+}
@@ -0,0 +1,119 @@
+// Package kservetest provides an in-process stand-in of a KServe inference service for tests.
+package kservetest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/Goboolean/core-system.worker/internal/infrastructure/kserve"
+)
+
+// Server is an httptest-based stand-in of a KServe inference service speaking the Open Inference Protocol v2.
+type Server struct {
+	*httptest.Server
+	*registry
+}
+
+// NewServer starts a new Server without any model.
+// The caller should call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{registry: newRegistry()}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	// /v2/models/{name}[/versions/{version}][/ready|/infer]
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(segments) < 3 || segments[0] != "v2" || segments[1] != "models" {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	name := segments[2]
+	rest := segments[3:]
+	version := ""
+	if len(rest) >= 2 && rest[0] == "versions" {
+		version = rest[1]
+		rest = rest[2:]
+	}
+
+	switch {
+	case len(rest) == 0 && r.Method == http.MethodGet:
+		s.serveMetadata(w, name)
+	case len(rest) == 1 && rest[0] == "ready" && r.Method == http.MethodGet:
+		s.serveReady(w, name)
+	case len(rest) == 1 && rest[0] == "infer" && r.Method == http.MethodPost:
+		s.serveInfer(w, r, name, version)
+	case len(rest) <= 1:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (s *Server) serveMetadata(w http.ResponseWriter, name string) {
+	m, err := s.modelMetadata(name)
+	if err != nil {
+		writeInferenceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(m)
+}
+
+// serveReady responds with 200 if the model is ready and 503 otherwise.
+func (s *Server) serveReady(w http.ResponseWriter, name string) {
+	ready, err := s.ready(name)
+	if err != nil {
+		writeInferenceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !ready {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(map[string]any{"name": name, "ready": ready})
+}
+
+func (s *Server) serveInfer(w http.ResponseWriter, r *http.Request, name, version string) {
+	var req kserve.InferenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, err := s.infer(name, &req)
+	if err != nil {
+		writeInferenceError(w, err)
+		return
+	}
+
+	res.ModelName = name
+	res.ModelVersion = version
+	res.ID = req.ID
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+// writeInferenceError responds with the status of *kserve.InferenceError or 500 for the other errors.
+func writeInferenceError(w http.ResponseWriter, err error) {
+	var inferenceErr *kserve.InferenceError
+	if errors.As(err, &inferenceErr) {
+		writeError(w, inferenceErr.StatusCode, inferenceErr.Message)
+	} else {
+		writeError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
@@ -1,28 +1,346 @@
 package kserve
 
-// InferenceReq is a struct for sending requests to kserve.
-type InferenceReq struct {
-	Name       string      `json:"string"`
-	Shape      []int       `json:"shape"`
-	DataType   string      `json:"datatype"`
-	Parameters string      `json:"parameters,omitempty"`
-	Data       interface{} `json:"data"`
-}
-
-// InferenceRes is a struct for storing responses received from kserve.
-type InferenceRes struct {
-	ModelName    string           `json:"model_name"`
-	ModelVersion string           `json:"model_version,omitempty"`
-	ID           string           `json:"ID"`
-	Parameters   string           `json:"parameters,omitempty"`
-	Outputs      []ResponseOutput `json:"outputs"`
-}
-
-// ResponseOutput is a struct for storing the output of responses received from kserve.
-type ResponseOutput struct {
-	Name       string      `json:"name"`
-	Shape      []int       `json:"shape"`
-	DataType   string      `json:"datatype"`
-	Parameters string      `json:"parameters,omitempty"`
-	Data       interface{} `json:"data"`
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// Datatype is the type of the elements of a tensor defined by the Open Inference Protocol.
+type Datatype string
+
+const (
+	BOOL   Datatype = "BOOL"
+	UINT8  Datatype = "UINT8"
+	UINT16 Datatype = "UINT16"
+	UINT32 Datatype = "UINT32"
+	UINT64 Datatype = "UINT64"
+	INT8   Datatype = "INT8"
+	INT16  Datatype = "INT16"
+	INT32  Datatype = "INT32"
+	INT64  Datatype = "INT64"
+	FP16   Datatype = "FP16"
+	FP32   Datatype = "FP32"
+	FP64   Datatype = "FP64"
+	BYTES  Datatype = "BYTES"
+)
+
+// Tensor is a named tensor sent to or received from an inference service.
+//
+// Data holds the elements in row-major order as a slice of the Go type matching Datatype:
+// []bool, []uint8, []uint16, []uint32, []uint64, []int8, []int16, []int32, []int64,
+// []float32 for FP16 and FP32, []float64, or []string for BYTES.
+type Tensor struct {
+	Name       string         `json:"name"`
+	Shape      []int64        `json:"shape"`
+	Datatype   Datatype       `json:"datatype"`
+	Parameters map[string]any `json:"parameters,omitempty"`
+	Data       any            `json:"data"`
+}
+
+// NumElements returns the number of elements that the shape of the tensor describes.
+func (t *Tensor) NumElements() int64 {
+	n := int64(1)
+	for _, e := range t.Shape {
+		n *= e
+	}
+	return n
+}
+
+// Validate checks that Data matches Datatype and Shape.
+func (t *Tensor) Validate() error {
+	n, ok := lenOf(t.Datatype, t.Data)
+	if !ok {
+		return fmt.Errorf("%w: tensor %q of %s has data of %T", ErrInvalidTensor, t.Name, t.Datatype, t.Data)
+	}
+
+	if int64(n) != t.NumElements() {
+		return fmt.Errorf("%w: tensor %q of shape %v has %d elements", ErrInvalidTensor, t.Name, t.Shape, n)
+	}
+	return nil
+}
+
+// AsFloat32 returns the elements of a numeric tensor converted to float32.
+func (t *Tensor) AsFloat32() ([]float32, error) {
+	switch v := t.Data.(type) {
+	case []float32:
+		return v, nil
+	case []float64:
+		return convert[float64, float32](v), nil
+	case []int8:
+		return convert[int8, float32](v), nil
+	case []int16:
+		return convert[int16, float32](v), nil
+	case []int32:
+		return convert[int32, float32](v), nil
+	case []int64:
+		return convert[int64, float32](v), nil
+	case []uint8:
+		return convert[uint8, float32](v), nil
+	case []uint16:
+		return convert[uint16, float32](v), nil
+	case []uint32:
+		return convert[uint32, float32](v), nil
+	case []uint64:
+		return convert[uint64, float32](v), nil
+	case []bool:
+		res := make([]float32, len(v))
+		for i, e := range v {
+			if e {
+				res[i] = 1
+			}
+		}
+		return res, nil
+	default:
+		return nil, fmt.Errorf("%w: tensor %q of %s is not numeric", ErrInvalidTensor, t.Name, t.Datatype)
+	}
+}
+
+type number interface {
+	~int8 | ~int16 | ~int32 | ~int64 | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64
+}
+
+func convert[S, D number](in []S) []D {
+	res := make([]D, len(in))
+	for i, e := range in {
+		res[i] = D(e)
+	}
+	return res
+}
+
+func lenOf(datatype Datatype, data any) (int, bool) {
+	switch v := data.(type) {
+	case []bool:
+		return len(v), datatype == BOOL
+	case []uint8:
+		return len(v), datatype == UINT8
+	case []uint16:
+		return len(v), datatype == UINT16
+	case []uint32:
+		return len(v), datatype == UINT32
+	case []uint64:
+		return len(v), datatype == UINT64
+	case []int8:
+		return len(v), datatype == INT8
+	case []int16:
+		return len(v), datatype == INT16
+	case []int32:
+		return len(v), datatype == INT32
+	case []int64:
+		return len(v), datatype == INT64
+	case []float32:
+		return len(v), datatype == FP16 || datatype == FP32
+	case []float64:
+		return len(v), datatype == FP64
+	case []string:
+		return len(v), datatype == BYTES
+	default:
+		return 0, false
+	}
+}
+
+// MarshalJSON encodes a tensor. UINT8 data is encoded as numbers instead of base64.
+func (t Tensor) MarshalJSON() ([]byte, error) {
+	type tensor Tensor
+	if v, ok := t.Data.([]uint8); ok {
+		t.Data = convert[uint8, uint16](v)
+	}
+	return json.Marshal(tensor(t))
+}
+
+// UnmarshalJSON decodes a tensor, converting its data into the slice type matching its datatype.
+// Data may be flattened or nested in the shape of the tensor.
+func (t *Tensor) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		Name       string          `json:"name"`
+		Shape      []int64         `json:"shape"`
+		Datatype   Datatype        `json:"datatype"`
+		Parameters map[string]any  `json:"parameters,omitempty"`
+		Data       json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	t.Name = raw.Name
+	t.Shape = raw.Shape
+	t.Datatype = raw.Datatype
+	t.Parameters = raw.Parameters
+	t.Data = nil
+
+	if len(raw.Data) == 0 || string(raw.Data) == "null" {
+		return nil
+	}
+
+	d := json.NewDecoder(bytes.NewReader(raw.Data))
+	d.UseNumber()
+	var nested any
+	if err := d.Decode(&nested); err != nil {
+		return err
+	}
+
+	data, err := decodeData(raw.Datatype, flatten(nested, nil))
+	if err != nil {
+		return fmt.Errorf("%w: tensor %q: %w", ErrInvalidTensor, raw.Name, err)
+	}
+
+	t.Data = data
+	return nil
+}
+
+func flatten(v any, res []any) []any {
+	if arr, ok := v.([]any); ok {
+		for _, e := range arr {
+			res = flatten(e, res)
+		}
+		return res
+	}
+	return append(res, v)
+}
+
+func decodeData(datatype Datatype, elems []any) (any, error) {
+	switch datatype {
+	case BOOL:
+		return decodeElems(elems, func(v any) (bool, error) {
+			b, ok := v.(bool)
+			if !ok {
+				return false, fmt.Errorf("%v is not a bool", v)
+			}
+			return b, nil
+		})
+	case BYTES:
+		return decodeElems(elems, func(v any) (string, error) {
+			s, ok := v.(string)
+			if !ok {
+				return "", fmt.Errorf("%v is not a string", v)
+			}
+			return s, nil
+		})
+	case UINT8:
+		return decodeInts[uint8](elems, 0, math.MaxUint8)
+	case UINT16:
+		return decodeInts[uint16](elems, 0, math.MaxUint16)
+	case UINT32:
+		return decodeInts[uint32](elems, 0, math.MaxUint32)
+	case UINT64:
+		return decodeElems(elems, func(v any) (uint64, error) {
+			n, ok := v.(json.Number)
+			if !ok {
+				return 0, fmt.Errorf("%v is not a number", v)
+			}
+			var res uint64
+			_, err := fmt.Sscan(n.String(), &res)
+			return res, err
+		})
+	case INT8:
+		return decodeInts[int8](elems, math.MinInt8, math.MaxInt8)
+	case INT16:
+		return decodeInts[int16](elems, math.MinInt16, math.MaxInt16)
+	case INT32:
+		return decodeInts[int32](elems, math.MinInt32, math.MaxInt32)
+	case INT64:
+		return decodeInts[int64](elems, math.MinInt64, math.MaxInt64)
+	case FP16, FP32:
+		return decodeFloats[float32](elems)
+	case FP64:
+		return decodeFloats[float64](elems)
+	default:
+		return nil, fmt.Errorf("unknown datatype %q", datatype)
+	}
+}
+
+func decodeElems[T any](elems []any, f func(any) (T, error)) ([]T, error) {
+	res := make([]T, len(elems))
+	for i, e := range elems {
+		v, err := f(e)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = v
+	}
+	return res, nil
+}
+
+func decodeInts[T ~int8 | ~int16 | ~int32 | ~int64 | ~uint8 | ~uint16 | ~uint32](elems []any, lo, hi int64) ([]T, error) {
+	return decodeElems(elems, func(v any) (T, error) {
+		n, ok := v.(json.Number)
+		if !ok {
+			return 0, fmt.Errorf("%v is not a number", v)
+		}
+		i, err := n.Int64()
+		if err != nil {
+			return 0, err
+		}
+		if i < lo || i > hi {
+			return 0, fmt.Errorf("%d overflows", i)
+		}
+		return T(i), nil
+	})
+}
+
+func decodeFloats[T ~float32 | ~float64](elems []any) ([]T, error) {
+	return decodeElems(elems, func(v any) (T, error) {
+		n, ok := v.(json.Number)
+		if !ok {
+			return 0, fmt.Errorf("%v is not a number", v)
+		}
+		f, err := n.Float64()
+		return T(f), err
+	})
+}
+
+// RequestedOutput selects an output tensor that the inference service should return.
+type RequestedOutput struct {
+	Name       string         `json:"name"`
+	Parameters map[string]any `json:"parameters,omitempty"`
+}
+
+// InferenceRequest is the body of an inference request of the Open Inference Protocol.
+type InferenceRequest struct {
+	ID         string            `json:"id,omitempty"`
+	Parameters map[string]any    `json:"parameters,omitempty"`
+	Inputs     []Tensor          `json:"inputs"`
+	Outputs    []RequestedOutput `json:"outputs,omitempty"`
+}
+
+// InferenceResponse is the body of an inference response of the Open Inference Protocol.
+type InferenceResponse struct {
+	ModelName    string         `json:"model_name"`
+	ModelVersion string         `json:"model_version,omitempty"`
+	ID           string         `json:"id,omitempty"`
+	Parameters   map[string]any `json:"parameters,omitempty"`
+	Outputs      []Tensor       `json:"outputs"`
+}
+
+// Output returns the output tensor of the given name.
+func (r *InferenceResponse) Output(name string) (*Tensor, bool) {
+	for i := range r.Outputs {
+		if r.Outputs[i].Name == name {
+			return &r.Outputs[i], true
+		}
+	}
+	return nil, false
+}
+
+// errorResponse is the body of a failed request of the Open Inference Protocol.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// TensorMetadata is the metadata of an input or output tensor of a model.
+type TensorMetadata struct {
+	Name     string   `json:"name"`
+	Datatype Datatype `json:"datatype"`
+	// Shape is the shape of the tensor. Variable-size dimensions are -1.
+	Shape []int64 `json:"shape"`
+}
+
+// ModelMetadata is the metadata of a model served by an inference service.
+type ModelMetadata struct {
+	Name     string           `json:"name"`
+	Versions []string         `json:"versions,omitempty"`
+	Platform string           `json:"platform"`
+	Inputs   []TensorMetadata `json:"inputs"`
+	Outputs  []TensorMetadata `json:"outputs"`
 }
@@ -0,0 +1,101 @@
+package adapter
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/Goboolean/core-system.worker/internal/util/chanutil"
+)
+
+// Candlestick builds a candlestick spanning the values of each model.ValueList,
+// which are the forecasts of the closes of the following steps.
+//
+// Open is the first value and Close is the last one. High and Low are the maximum and the minimum of the values.
+// The candlestick opens at the Time of the packet and spans one time frame per value.
+// Volume is not forecast, so it is 0.
+type Candlestick struct {
+	timeFrame time.Duration
+
+	in  job.DataChan `type:"ValueList"`
+	out job.DataChan `type:"*StockAggregate"` //Job은 자신의 Output 채널에 대해 소유권을 가진다.
+}
+
+// NewCandlestick creates new instance of Candlestick
+//
+// Parameter List:
+// job.TimeFrame: The time frame of a step. Example: "1m". ClosedTime equals OpenTime if it is not given.
+func NewCandlestick(params *job.UserParams) (*Candlestick, error) {
+	instance := &Candlestick{
+		out: make(job.DataChan),
+	}
+
+	if !params.IsKeyNilOrEmpty(job.TimeFrame) {
+		val, err := time.ParseDuration((*params)[job.TimeFrame])
+		if err != nil {
+			return nil, fmt.Errorf("create candlestick adapt job: %w", err)
+		}
+
+		instance.timeFrame = val
+	}
+
+	return instance, nil
+}
+
+// Execute starts to build candlesticks from the input.
+//
+// If the Job fails to perform its task, Execute returns an error.
+// If the Job completes successfully, it returns nil.
+// DO NOT CALL Execute() TWICE. IT MUST BE PANIC
+func (c *Candlestick) Execute() error {
+	defer close(c.out)
+	defer func() {
+		go chanutil.DummyChannelConsumer(c.in)
+	}()
+
+	for p := range c.in {
+		if model.IsWatermark(p) {
+			c.out <- p
+			continue
+		}
+
+		values, ok := p.Data.(model.ValueList)
+		if !ok {
+			return fmt.Errorf("candlestick adapt job: type mismatch. expected model.ValueList, got %s %w", reflect.TypeOf(p.Data), job.ErrTypeMismatch)
+		}
+
+		if len(values) == 0 {
+			return fmt.Errorf("candlestick adapt job: empty value list at %s", p.Time)
+		}
+
+		res := &model.StockAggregate{
+			OpenTime:   p.Time.Unix(),
+			ClosedTime: p.Time.Add(time.Duration(len(values)) * c.timeFrame).Unix(),
+			Open:       values[0],
+			Close:      values[len(values)-1],
+			High:       values[0],
+			Low:        values[0],
+		}
+		for _, e := range values[1:] {
+			res.High = max(res.High, e)
+			res.Low = min(res.Low, e)
+		}
+
+		c.out <- model.Packet{
+			Time: p.Time,
+			Data: res,
+		}
+	}
+
+	return nil
+}
+
+func (c *Candlestick) SetInput(in job.DataChan) {
+	c.in = in
+}
+
+func (c *Candlestick) Output() job.DataChan {
+	return c.out
+}
@@ -0,0 +1,65 @@
+package adapter_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/job/adapter"
+	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type CandlestickTestSuite struct {
+	suite.Suite
+}
+
+func (suite *CandlestickTestSuite) TestCandlestick_ShouldSpanValues_WhenInputIsValueList() {
+	//arrange
+	a, err := adapter.NewCandlestick(&job.UserParams{job.TimeFrame: "1m"})
+	suite.Require().NoError(err)
+
+	//act
+	res, err := adapt(a, model.ValueList{10, 12, 9, 11}, model.Watermark{})
+
+	//assert
+	suite.NoError(err)
+	suite.Equal([]model.Packet{
+		{
+			Time: time.Unix(0, 0),
+			Data: &model.StockAggregate{
+				OpenTime:   0,
+				ClosedTime: 240,
+				Open:       10,
+				Close:      11,
+				High:       12,
+				Low:        9,
+			},
+		},
+		{Time: time.Unix(1, 0), Data: model.Watermark{}},
+	}, res)
+}
+
+func (suite *CandlestickTestSuite) TestCandlestick_ShouldReturnError_WhenValueListIsEmpty() {
+	//arrange
+	a, err := adapter.NewCandlestick(&job.UserParams{})
+	suite.Require().NoError(err)
+
+	//act
+	_, err = adapt(a, model.ValueList{})
+
+	//assert
+	suite.Error(err)
+}
+
+func (suite *CandlestickTestSuite) TestNewCandlestick_ShouldReturnError_WhenTimeFrameIsInvalid() {
+	//act
+	_, err := adapter.NewCandlestick(&job.UserParams{job.TimeFrame: "daily"})
+
+	//assert
+	suite.Error(err)
+}
+
+func TestCandlestick(t *testing.T) {
+	suite.Run(t, new(CandlestickTestSuite))
+}
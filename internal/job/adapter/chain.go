@@ -0,0 +1,56 @@
+package adapter
+
+import (
+	"strings"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"golang.org/x/sync/errgroup"
+)
+
+// Chain composes the adapters converting the data step by step into one Adapter.
+// The output of each adapter is the input of the next one.
+type Chain struct {
+	// types is the data types the chain converts through, from the input type to the output type.
+	types    []string
+	adapters []Adapter
+}
+
+// newChain creates new instance of Chain
+// types must have one more element than adapters.
+func newChain(types []string, adapters []Adapter) *Chain {
+	for i := 1; i < len(adapters); i++ {
+		adapters[i].SetInput(adapters[i-1].Output())
+	}
+
+	return &Chain{
+		types:    types,
+		adapters: adapters,
+	}
+}
+
+// Execute runs all the adapters of the chain.
+//
+// If the Job fails to perform its task, Execute returns an error.
+// If the Job completes successfully, it returns nil.
+// DO NOT CALL Execute() TWICE. IT MUST BE PANIC
+func (c *Chain) Execute() error {
+	// 각 adapter가 실패하면 입력을 비우고 출력을 닫으므로 나머지 adapter도 종료된다.
+	g := errgroup.Group{}
+	for _, e := range c.adapters {
+		g.Go(e.Execute)
+	}
+	return g.Wait()
+}
+
+// String returns the data types the chain converts through. Example: "probeDist -> valueList -> candlestick"
+func (c *Chain) String() string {
+	return strings.Join(c.types, " -> ")
+}
+
+func (c *Chain) SetInput(in job.DataChan) {
+	c.adapters[0].SetInput(in)
+}
+
+func (c *Chain) Output() job.DataChan {
+	return c.adapters[len(c.adapters)-1].Output()
+}
@@ -0,0 +1,61 @@
+package adapter_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/job/adapter"
+	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type ChainTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ChainTestSuite) TestCreate_ShouldComposeShortestPath_WhenTypesAreNotConvertedDirectly() {
+	//act
+	a, err := adapter.Create(adapter.Spec{InputType: "probeDist", OutputType: "candlestick"}, &job.UserParams{job.TimeFrame: "1m"})
+
+	//assert
+	suite.Require().NoError(err)
+	suite.IsType(&adapter.Chain{}, a)
+	suite.Equal("probeDist -> valueList -> candlestick", fmt.Sprint(a))
+}
+
+func (suite *ChainTestSuite) TestChain_ShouldConvertDataThroughEveryAdapter() {
+	//arrange
+	a, err := adapter.Create(adapter.Spec{InputType: "probeDist", OutputType: "candlestick"}, &job.UserParams{job.TimeFrame: "1m"})
+	suite.Require().NoError(err)
+
+	//act
+	res, err := adapt(a, &model.ProbeDist{Probs: []float32{0.2, 0.3, 0.5}}, model.Watermark{})
+
+	//assert
+	suite.NoError(err)
+	suite.Require().Len(res, 2)
+	suite.Equal(time.Unix(0, 0), res[0].Time)
+	candle := res[0].Data.(*model.StockAggregate)
+	suite.Equal(int64(60), candle.ClosedTime-candle.OpenTime)
+	suite.InDelta(1.3, candle.Open, 1e-6)
+	suite.InDelta(1.3, candle.Close, 1e-6)
+	suite.Equal(model.Packet{Time: time.Unix(1, 0), Data: model.Watermark{}}, res[1])
+}
+
+func (suite *ChainTestSuite) TestChain_ShouldReturnError_WhenAdapterInTheMiddleFails() {
+	//arrange
+	a, err := adapter.Create(adapter.Spec{InputType: "probeDist", OutputType: "candlestick"}, &job.UserParams{job.TimeFrame: "1m"})
+	suite.Require().NoError(err)
+
+	//act
+	_, err = adapt(a, &model.ProbeDist{Labels: []string{"down", "up"}, Probs: []float32{0.5, 0.5}})
+
+	//assert
+	suite.Error(err)
+}
+
+func TestChain(t *testing.T) {
+	suite.Run(t, new(ChainTestSuite))
+}
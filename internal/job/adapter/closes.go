@@ -0,0 +1,104 @@
+package adapter
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/Goboolean/core-system.worker/internal/util/chanutil"
+)
+
+// User param keys
+const (
+	// WindowParam is the number of the latest closes Closes sends in a model.ValueList.
+	WindowParam = "adapter.window"
+)
+
+// Closes sends the closes of the latest candlesticks in the order of time as model.ValueList.
+//
+// Nothing is sent until the window is filled.
+type Closes struct {
+	window int
+	closes []float32
+
+	in  job.DataChan `type:"*StockAggregate"`
+	out job.DataChan `type:"ValueList"` //Job은 자신의 Output 채널에 대해 소유권을 가진다.
+}
+
+// NewCloses creates new instance of Closes
+//
+// Parameter List:
+// WindowParam: The number of the latest closes in a value list. Defaults to 1.
+func NewCloses(params *job.UserParams) (*Closes, error) {
+	instance := &Closes{
+		window: 1,
+		out:    make(job.DataChan),
+	}
+
+	if !params.IsKeyNilOrEmpty(WindowParam) {
+		val, err := strconv.Atoi((*params)[WindowParam])
+		if err != nil {
+			return nil, fmt.Errorf("create closes adapt job: %w", err)
+		}
+
+		if val <= 0 {
+			return nil, fmt.Errorf("create closes adapt job: %s must be positive", WindowParam)
+		}
+
+		instance.window = val
+	}
+
+	instance.closes = make([]float32, 0, instance.window)
+	return instance, nil
+}
+
+// Execute starts to collect the closes of the input.
+//
+// If the Job fails to perform its task, Execute returns an error.
+// If the Job completes successfully, it returns nil.
+// DO NOT CALL Execute() TWICE. IT MUST BE PANIC
+func (c *Closes) Execute() error {
+	defer close(c.out)
+	defer func() {
+		go chanutil.DummyChannelConsumer(c.in)
+	}()
+
+	for p := range c.in {
+		if model.IsWatermark(p) {
+			c.out <- p
+			continue
+		}
+
+		data, ok := model.AsStockAggregate(p.Data)
+		if !ok {
+			return fmt.Errorf("closes adapt job: type mismatch. expected *model.StockAggregate, got %s %w", reflect.TypeOf(p.Data), job.ErrTypeMismatch)
+		}
+
+		if len(c.closes) == c.window {
+			c.closes = c.closes[1:]
+		}
+		c.closes = append(c.closes, data.Close)
+
+		if len(c.closes) < c.window {
+			continue
+		}
+
+		c.out <- model.Packet{
+			Time: p.Time,
+			// 다음 job이 값을 보관할 수 있으므로 복사한다.
+			Data: append(model.ValueList(nil), c.closes...),
+		}
+	}
+
+	return nil
+}
+
+func (c *Closes) SetInput(in job.DataChan) {
+	c.in = in
+}
+
+func (c *Closes) Output() job.DataChan {
+	return c.out
+}
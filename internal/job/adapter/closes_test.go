@@ -0,0 +1,78 @@
+package adapter_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/job/adapter"
+	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type ClosesTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ClosesTestSuite) TestCloses_ShouldSendLatestCloses_WhenWindowIsFilled() {
+	//arrange
+	a, err := adapter.NewCloses(&job.UserParams{adapter.WindowParam: "3"})
+	suite.Require().NoError(err)
+
+	//act
+	res, err := adapt(a,
+		&model.StockAggregate{Close: 1},
+		&model.StockAggregate{Close: 2},
+		&model.StockAggregate{Close: 3},
+		&model.StockAggregate{Close: 4},
+	)
+
+	//assert
+	suite.NoError(err)
+	suite.Equal([]model.Packet{
+		{Time: time.Unix(2, 0), Data: model.ValueList{1, 2, 3}},
+		{Time: time.Unix(3, 0), Data: model.ValueList{2, 3, 4}},
+	}, res)
+}
+
+func (suite *ClosesTestSuite) TestCloses_ShouldSendClose_WhenWindowIsNotGiven() {
+	//arrange
+	a, err := adapter.NewCloses(&job.UserParams{})
+	suite.Require().NoError(err)
+
+	//act
+	res, err := adapt(a, &model.StockAggregate{Close: 1.5})
+
+	//assert
+	suite.NoError(err)
+	suite.Equal([]model.Packet{{Time: time.Unix(0, 0), Data: model.ValueList{1.5}}}, res)
+}
+
+func (suite *ClosesTestSuite) TestCloses_ShouldReturnError_WhenInputIsNotCandlestick() {
+	//arrange
+	a, err := adapter.NewCloses(&job.UserParams{})
+	suite.Require().NoError(err)
+
+	//act
+	_, err = adapt(a, model.ValueList{1})
+
+	//assert
+	suite.ErrorIs(err, job.ErrTypeMismatch)
+}
+
+func (suite *ClosesTestSuite) TestNewCloses_ShouldReturnError_WhenWindowIsInvalid() {
+	for _, params := range []job.UserParams{
+		{adapter.WindowParam: "many"},
+		{adapter.WindowParam: "0"},
+	} {
+		//act
+		_, err := adapter.NewCloses(&params)
+
+		//assert
+		suite.Error(err, params)
+	}
+}
+
+func TestCloses(t *testing.T) {
+	suite.Run(t, new(ClosesTestSuite))
+}
@@ -1,5 +0,0 @@
-package adapter
-
-type Dummy struct {
-	Adapter
-}
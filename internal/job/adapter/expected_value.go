@@ -0,0 +1,87 @@
+package adapter
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/Goboolean/core-system.worker/internal/util/chanutil"
+)
+
+// ExpectedValue sends the expected value of each probability distribution as a model.ValueList of one value.
+//
+// The value of each class is its label parsed as a number, such as the return of a bin.
+// If the distribution has no labels, the value of each class is its index.
+type ExpectedValue struct {
+	in  job.DataChan `type:"*ProbeDist"`
+	out job.DataChan `type:"ValueList"` //Job은 자신의 Output 채널에 대해 소유권을 가진다.
+}
+
+// NewExpectedValue creates new instance of ExpectedValue
+func NewExpectedValue(_ *job.UserParams) (*ExpectedValue, error) {
+	return &ExpectedValue{
+		out: make(job.DataChan),
+	}, nil
+}
+
+// Execute starts to compute the expected values of the input.
+//
+// If the Job fails to perform its task, Execute returns an error.
+// If the Job completes successfully, it returns nil.
+// DO NOT CALL Execute() TWICE. IT MUST BE PANIC
+func (e *ExpectedValue) Execute() error {
+	defer close(e.out)
+	defer func() {
+		go chanutil.DummyChannelConsumer(e.in)
+	}()
+
+	for p := range e.in {
+		if model.IsWatermark(p) {
+			e.out <- p
+			continue
+		}
+
+		dist, ok := p.Data.(*model.ProbeDist)
+		if !ok {
+			return fmt.Errorf("expected value adapt job: type mismatch. expected *model.ProbeDist, got %s %w", reflect.TypeOf(p.Data), job.ErrTypeMismatch)
+		}
+
+		v, err := expectedValue(dist)
+		if err != nil {
+			return fmt.Errorf("expected value adapt job: %w", err)
+		}
+
+		e.out <- model.Packet{
+			Time: p.Time,
+			Data: model.ValueList{v},
+		}
+	}
+
+	return nil
+}
+
+func expectedValue(dist *model.ProbeDist) (float32, error) {
+	var res float64
+	for i, e := range dist.Probs {
+		v := float64(i)
+		if dist.Labels != nil {
+			var err error
+			v, err = strconv.ParseFloat(dist.Label(i), 64)
+			if err != nil {
+				return 0, fmt.Errorf("label of class %d is not a number: %w", i, err)
+			}
+		}
+		res += float64(e) * v
+	}
+	return float32(res), nil
+}
+
+func (e *ExpectedValue) SetInput(in job.DataChan) {
+	e.in = in
+}
+
+func (e *ExpectedValue) Output() job.DataChan {
+	return e.out
+}
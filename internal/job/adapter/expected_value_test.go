@@ -0,0 +1,64 @@
+package adapter_test
+
+import (
+	"testing"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/job/adapter"
+	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type ExpectedValueTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ExpectedValueTestSuite) expect(dist *model.ProbeDist) (model.ValueList, error) {
+	a, err := adapter.NewExpectedValue(&job.UserParams{})
+	suite.Require().NoError(err)
+
+	res, err := adapt(a, dist)
+	if err != nil {
+		return nil, err
+	}
+	suite.Require().Len(res, 1)
+	return res[0].Data.(model.ValueList), nil
+}
+
+func (suite *ExpectedValueTestSuite) TestExpectedValue_ShouldWeighLabels_WhenLabelsAreNumbers() {
+	//act
+	res, err := suite.expect(&model.ProbeDist{
+		Labels: []string{"-0.02", "0", "0.01"},
+		Probs:  []float32{0.25, 0.25, 0.5},
+	})
+
+	//assert
+	suite.NoError(err)
+	suite.Require().Len(res, 1)
+	suite.InDelta(0, res[0], 1e-6)
+}
+
+func (suite *ExpectedValueTestSuite) TestExpectedValue_ShouldWeighIndexes_WhenThereIsNoLabel() {
+	//act
+	res, err := suite.expect(&model.ProbeDist{Probs: []float32{0.2, 0.3, 0.5}})
+
+	//assert
+	suite.NoError(err)
+	suite.Require().Len(res, 1)
+	suite.InDelta(1.3, res[0], 1e-6)
+}
+
+func (suite *ExpectedValueTestSuite) TestExpectedValue_ShouldReturnError_WhenLabelIsNotNumber() {
+	//act
+	_, err := suite.expect(&model.ProbeDist{
+		Labels: []string{"down", "up"},
+		Probs:  []float32{0.5, 0.5},
+	})
+
+	//assert
+	suite.Error(err)
+}
+
+func TestExpectedValue(t *testing.T) {
+	suite.Run(t, new(ExpectedValueTestSuite))
+}
@@ -2,8 +2,11 @@ package adapter
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/Goboolean/core-system.worker/internal/job"
+	log "github.com/sirupsen/logrus"
 )
 
 // jobProvider is a type alias for a function
@@ -12,17 +15,86 @@ type jobProvider func(p *job.UserParams) (Adapter, error)
 
 // Create generates an appropriate fetcher based on the given spec.
 // Create passes userParam to the job during this process
+//
+// If no adapter converts spec.InputType into spec.OutputType directly,
+// Create finds the shortest conversion path through the registered adapters
+// and composes the adapters on the path into a Chain.
 func Create(spec Spec, p *job.UserParams) (Adapter, error) {
 
 	var provider, ok = providerRepo[spec]
-	if !ok {
+	if ok {
+		f, err := provider(p)
+		if err != nil {
+			return nil, fmt.Errorf("create adapt job: %w", err)
+		}
+
+		return f, nil
+	}
+
+	path := resolve(spec.InputType, spec.OutputType)
+	if len(path) == 0 {
 		return nil, fmt.Errorf("create adapt job: %w", job.ErrNotFoundJob)
 	}
 
-	f, err := provider(p)
-	if err != nil {
-		return nil, fmt.Errorf("create adapt job: %w", err)
+	types := []string{spec.InputType}
+	adapters := make([]Adapter, len(path))
+	for i, e := range path {
+		f, err := providerRepo[e](p)
+		if err != nil {
+			return nil, fmt.Errorf("create adapt job: %s -> %s: %w", e.InputType, e.OutputType, err)
+		}
+
+		adapters[i] = f
+		types = append(types, e.OutputType)
+	}
+
+	chain := newChain(types, adapters)
+	log.WithField("chain", chain.String()).Info("adapter chain is resolved")
+	return chain, nil
+}
+
+// resolve finds the shortest path of the registered specs converting from into to by breadth-first search.
+// It returns nil if there is no path, and an empty path if from equals to.
+// Among the paths of the same length, the one with the types first in lexical order is chosen.
+func resolve(from, to string) []Spec {
+	edges := make(map[string][]Spec)
+	for e := range providerRepo {
+		// 같은 타입으로의 변환은 경로를 줄이지 않는다.
+		if e.InputType != e.OutputType {
+			edges[e.InputType] = append(edges[e.InputType], e)
+		}
+	}
+	for _, e := range edges {
+		slices.SortFunc(e, func(a, b Spec) int {
+			return strings.Compare(a.OutputType, b.OutputType)
+		})
+	}
+
+	// prev는 각 타입에 처음 도달한 spec을 기록한다.
+	prev := map[string]Spec{from: {}}
+	queue := []string{from}
+	for len(queue) > 0 {
+		t := queue[0]
+		queue = queue[1:]
+
+		if t == to {
+			path := make([]Spec, 0)
+			for t != from {
+				path = append(path, prev[t])
+				t = prev[t].InputType
+			}
+			slices.Reverse(path)
+			return path
+		}
+
+		for _, e := range edges[t] {
+			if _, ok := prev[e.OutputType]; ok {
+				continue
+			}
+			prev[e.OutputType] = e
+			queue = append(queue, e.OutputType)
+		}
 	}
 
-	return f, nil
+	return nil
 }
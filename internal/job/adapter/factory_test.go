@@ -0,0 +1,90 @@
+package adapter_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/job/adapter"
+	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/sync/errgroup"
+)
+
+// adapt sends data to a one second apart from each other and returns the output.
+func adapt(a adapter.Adapter, data ...any) ([]model.Packet, error) {
+	in := make(job.DataChan)
+	a.SetInput(in)
+
+	res := make([]model.Packet, 0)
+	g := errgroup.Group{}
+	g.Go(func() error {
+		defer close(in)
+		for i, e := range data {
+			in <- model.Packet{Time: time.Unix(int64(i), 0), Data: e}
+		}
+		return nil
+	})
+	g.Go(func() error {
+		for p := range a.Output() {
+			res = append(res, p)
+		}
+		return nil
+	})
+
+	err := a.Execute()
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return res, err
+}
+
+type FactoryTestSuite struct {
+	suite.Suite
+}
+
+func (suite *FactoryTestSuite) TestCreate_ShouldCreateAdapter_WhenTypesAreSupported() {
+	for _, spec := range []adapter.Spec{
+		{InputType: "candlestick", OutputType: "candlestick"},
+		{InputType: "valueList", OutputType: "valueList"},
+		{InputType: "probeDist", OutputType: "probeDist"},
+		{InputType: "candlestick", OutputType: "valueList"},
+		{InputType: "probeDist", OutputType: "valueList"},
+		{InputType: "valueList", OutputType: "candlestick"},
+		{InputType: "quote", OutputType: "quoteFeatures"},
+	} {
+		//act
+		_, err := adapter.Create(spec, &job.UserParams{})
+
+		//assert
+		suite.NoError(err, spec)
+	}
+}
+
+func (suite *FactoryTestSuite) TestCreate_ShouldReturnError_WhenTypesAreNotSupported() {
+	//act
+	_, err := adapter.Create(adapter.Spec{InputType: "candlestick", OutputType: "probeDist"}, &job.UserParams{})
+
+	//assert
+	suite.ErrorIs(err, job.ErrNotFoundJob)
+}
+
+func (suite *FactoryTestSuite) TestIdentity_ShouldPassInputAsItIs() {
+	//arrange
+	a, err := adapter.NewIdentity(&job.UserParams{})
+	suite.Require().NoError(err)
+
+	//act
+	res, err := adapt(a, model.ValueList{1, 2}, model.Watermark{})
+
+	//assert
+	suite.NoError(err)
+	suite.Equal([]model.Packet{
+		{Time: time.Unix(0, 0), Data: model.ValueList{1, 2}},
+		{Time: time.Unix(1, 0), Data: model.Watermark{}},
+	}, res)
+}
+
+func TestFactory(t *testing.T) {
+	suite.Run(t, new(FactoryTestSuite))
+}
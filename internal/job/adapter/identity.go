@@ -0,0 +1,46 @@
+package adapter
+
+import (
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/util/chanutil"
+)
+
+// Identity passes the input to the output as it is.
+// It is used when the output type of the model is already the input type of the strategy.
+type Identity struct {
+	in  job.DataChan
+	out job.DataChan //Job은 자신의 Output 채널에 대해 소유권을 가진다.
+}
+
+// NewIdentity creates new instance of Identity
+func NewIdentity(_ *job.UserParams) (*Identity, error) {
+	return &Identity{
+		out: make(job.DataChan),
+	}, nil
+}
+
+// Execute starts to pass the input.
+//
+// If the Job fails to perform its task, Execute returns an error.
+// If the Job completes successfully, it returns nil.
+// DO NOT CALL Execute() TWICE. IT MUST BE PANIC
+func (i *Identity) Execute() error {
+	defer close(i.out)
+	defer func() {
+		go chanutil.DummyChannelConsumer(i.in)
+	}()
+
+	for p := range i.in {
+		i.out <- p
+	}
+
+	return nil
+}
+
+func (i *Identity) SetInput(in job.DataChan) {
+	i.in = in
+}
+
+func (i *Identity) Output() job.DataChan {
+	return i.out
+}
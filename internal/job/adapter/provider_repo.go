@@ -1,5 +1,27 @@
-//go:build !develop
-
 package adapter
 
-var providerRepo = map[Spec]jobProvider{}
+import "github.com/Goboolean/core-system.worker/internal/job"
+
+var providerRepo = map[Spec]jobProvider{
+	{InputType: "candlestick", OutputType: "candlestick"}: func(p *job.UserParams) (Adapter, error) {
+		return NewIdentity(p)
+	},
+	{InputType: "valueList", OutputType: "valueList"}: func(p *job.UserParams) (Adapter, error) {
+		return NewIdentity(p)
+	},
+	{InputType: "probeDist", OutputType: "probeDist"}: func(p *job.UserParams) (Adapter, error) {
+		return NewIdentity(p)
+	},
+	{InputType: "candlestick", OutputType: "valueList"}: func(p *job.UserParams) (Adapter, error) {
+		return NewCloses(p)
+	},
+	{InputType: "probeDist", OutputType: "valueList"}: func(p *job.UserParams) (Adapter, error) {
+		return NewExpectedValue(p)
+	},
+	{InputType: "valueList", OutputType: "candlestick"}: func(p *job.UserParams) (Adapter, error) {
+		return NewCandlestick(p)
+	},
+	{InputType: "quote", OutputType: "quoteFeatures"}: func(p *job.UserParams) (Adapter, error) {
+		return NewQuoteFeatures(p)
+	},
+}
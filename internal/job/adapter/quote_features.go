@@ -0,0 +1,67 @@
+package adapter
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/Goboolean/core-system.worker/internal/util/chanutil"
+)
+
+// QuoteFeatures derives the mid price, the spread and the imbalance from quotes
+// and sends them to the output channel as *model.QuoteFeatures.
+//
+// The features are consumed by analyzers taking "quoteFeatures".
+type QuoteFeatures struct {
+	in  job.DataChan `type:"*Quote"`
+	out job.DataChan `type:"*QuoteFeatures"` //Job은 자신의 Output 채널에 대해 소유권을 가진다.
+}
+
+// NewQuoteFeatures creates new instance of QuoteFeatures
+func NewQuoteFeatures(params *job.UserParams) (*QuoteFeatures, error) {
+	instance := &QuoteFeatures{
+		out: make(job.DataChan),
+	}
+
+	return instance, nil
+}
+
+// Execute starts to derive features from the input.
+//
+// If the Job fails to perform its task, Execute returns an error.
+// If the Job completes successfully, it returns nil.
+// DO NOT CALL Execute() TWICE. IT MUST BE PANIC
+func (q *QuoteFeatures) Execute() error {
+	defer close(q.out)
+	defer func() {
+		go chanutil.DummyChannelConsumer(q.in)
+	}()
+
+	for p := range q.in {
+		if model.IsWatermark(p) {
+			q.out <- p
+			continue
+		}
+
+		quote, ok := p.Data.(*model.Quote)
+		if !ok {
+			return fmt.Errorf("quote features adapt job: type mismatch. expected *model.Quote, got %s %w", reflect.TypeOf(p.Data), job.ErrTypeMismatch)
+		}
+
+		q.out <- model.Packet{
+			Time: p.Time,
+			Data: quote.Features(),
+		}
+	}
+
+	return nil
+}
+
+func (q *QuoteFeatures) SetInput(in job.DataChan) {
+	q.in = in
+}
+
+func (q *QuoteFeatures) Output() job.DataChan {
+	return q.out
+}
@@ -0,0 +1,73 @@
+package adapter_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/job/adapter"
+	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/sync/errgroup"
+)
+
+type QuoteFeaturesTestSuite struct {
+	suite.Suite
+}
+
+func (suite *QuoteFeaturesTestSuite) adapt(params *job.UserParams, data ...any) ([]*model.QuoteFeatures, error) {
+	a, err := adapter.NewQuoteFeatures(params)
+	suite.Require().NoError(err)
+
+	in := make(job.DataChan)
+	a.SetInput(in)
+
+	res := make([]*model.QuoteFeatures, 0)
+	g := errgroup.Group{}
+	g.Go(func() error {
+		defer close(in)
+		for i, e := range data {
+			in <- model.Packet{Time: time.Unix(int64(i), 0), Data: e}
+		}
+		return nil
+	})
+	g.Go(func() error {
+		for p := range a.Output() {
+			res = append(res, p.Data.(*model.QuoteFeatures))
+		}
+		return nil
+	})
+
+	err = a.Execute()
+	suite.Require().NoError(g.Wait())
+	return res, err
+}
+
+func (suite *QuoteFeaturesTestSuite) TestQuoteFeatures_ShouldDeriveFeatures_WhenInputIsQuote() {
+	//act
+	res, err := suite.adapt(&job.UserParams{}, &model.Quote{
+		BidPrice: 99,
+		BidSize:  30,
+		AskPrice: 101,
+		AskSize:  10,
+	})
+
+	//assert
+	suite.NoError(err)
+	suite.Require().Len(res, 1)
+	suite.Equal(float32(100), res[0].Mid)
+	suite.Equal(float32(2), res[0].Spread)
+	suite.Equal(float32(0.5), res[0].Imbalance)
+}
+
+func (suite *QuoteFeaturesTestSuite) TestQuoteFeatures_ShouldReturnError_WhenInputTypeIsNotSupported() {
+	//act
+	_, err := suite.adapt(&job.UserParams{}, &model.StockAggregate{})
+
+	//assert
+	suite.ErrorIs(err, job.ErrTypeMismatch)
+}
+
+func TestQuoteFeatures(t *testing.T) {
+	suite.Run(t, new(QuoteFeaturesTestSuite))
+}
@@ -14,4 +14,19 @@ var providerRepo = map[Spec]jobProvider{
 	{ID: "stub", InputType: "stock"}: func(p *job.UserParams) (Analyzer, error) {
 		return NewStub(p)
 	},
+	{ID: "stub", InputType: "candlestick"}: func(p *job.UserParams) (Analyzer, error) {
+		return NewStub(p)
+	},
+	{ID: "stub", InputType: "valueList"}: func(p *job.UserParams) (Analyzer, error) {
+		return NewStub(p)
+	},
+	{ID: "stub", InputType: "probeDist"}: func(p *job.UserParams) (Analyzer, error) {
+		return NewStub(p)
+	},
+	{ID: "stub", InputType: "tuple"}: func(p *job.UserParams) (Analyzer, error) {
+		return NewStub(p)
+	},
+	{ID: "stub", InputType: "quoteFeatures"}: func(p *job.UserParams) (Analyzer, error) {
+		return NewStub(p)
+	},
 }
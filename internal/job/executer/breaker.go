@@ -0,0 +1,162 @@
+package executer
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/model"
+)
+
+var ErrCircuitOpen = errors.New("executer: circuit breaker is open")
+
+// Param keys of the circuit breaker and the fallback policy.
+const (
+	BreakerFailureThresholdParam = "breaker.failureThreshold"
+	BreakerOpenTimeoutParam      = "breaker.openTimeout"
+	FallbackParam                = "fallback"
+)
+
+// Fallback policies applied when an inference fails.
+const (
+	// FallbackFail fails the job.
+	FallbackFail = "fail"
+	// FallbackSkip sends no output of the input and emits model.InferenceFallback.
+	FallbackSkip = "skip"
+	// FallbackLastKnown sends the latest output again and emits model.InferenceFallback.
+	// It skips the input if there is no output yet.
+	FallbackLastKnown = "lastKnown"
+)
+
+// States of circuitBreaker.
+const (
+	BreakerClosed   = "closed"
+	BreakerOpen     = "open"
+	BreakerHalfOpen = "halfOpen"
+)
+
+const DefaultBreakerOpenTimeout = 30 * time.Second
+
+// circuitBreaker stops sending requests to a failing service.
+//
+// It opens after threshold consecutive failures and rejects every request until openTimeout passes.
+// Then it half-opens and lets a single probe through; it closes if the probe succeeds and opens again otherwise.
+type circuitBreaker struct {
+	threshold   int
+	openTimeout time.Duration
+	now         func() time.Time
+
+	mu       sync.Mutex
+	state    string
+	failures int
+	openedAt time.Time
+	probing  bool
+	// changes is the state changes not yet taken by takeChanges.
+	changes []model.CircuitBreakerStateChange
+}
+
+// newCircuitBreaker reads the breaker from params. It returns nil if no failure threshold is given.
+//
+// Param list
+// BreakerFailureThresholdParam: the number of consecutive failures that opens the breaker
+// BreakerOpenTimeoutParam: how long the breaker stays open before probing, such as "30s". Defaults to DefaultBreakerOpenTimeout
+func newCircuitBreaker(params *job.UserParams) (*circuitBreaker, error) {
+	if params.IsKeyNilOrEmpty(BreakerFailureThresholdParam) {
+		return nil, nil
+	}
+
+	threshold, err := strconv.Atoi((*params)[BreakerFailureThresholdParam])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", BreakerFailureThresholdParam, err)
+	}
+	if threshold <= 0 {
+		return nil, fmt.Errorf("%s must be positive, got %d", BreakerFailureThresholdParam, threshold)
+	}
+
+	openTimeout := DefaultBreakerOpenTimeout
+	if !params.IsKeyNilOrEmpty(BreakerOpenTimeoutParam) {
+		openTimeout, err = time.ParseDuration((*params)[BreakerOpenTimeoutParam])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", BreakerOpenTimeoutParam, err)
+		}
+	}
+
+	return &circuitBreaker{
+		threshold:   threshold,
+		openTimeout: openTimeout,
+		now:         time.Now,
+		state:       BreakerClosed,
+	}, nil
+}
+
+// allow reports whether a request can be sent.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Sub(b.openedAt) < b.openTimeout {
+			return false
+		}
+		b.transit(BreakerHalfOpen)
+		b.probing = true
+		return true
+	case BreakerHalfOpen:
+		// 탐색 요청의 결과가 나올 때까지 다른 요청은 보내지 않는다.
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// success records a successful request.
+func (b *circuitBreaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.probing = false
+	if b.state != BreakerClosed {
+		b.transit(BreakerClosed)
+	}
+}
+
+// failure records a failed request.
+func (b *circuitBreaker) failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.probing = false
+	if b.state == BreakerHalfOpen || (b.state == BreakerClosed && b.failures >= b.threshold) {
+		b.openedAt = b.now()
+		b.transit(BreakerOpen)
+	}
+}
+
+// takeChanges returns the state changes since the last call.
+func (b *circuitBreaker) takeChanges() []model.CircuitBreakerStateChange {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	res := b.changes
+	b.changes = nil
+	return res
+}
+
+func (b *circuitBreaker) transit(to string) {
+	b.changes = append(b.changes, model.CircuitBreakerStateChange{
+		From:     b.state,
+		To:       to,
+		Failures: b.failures,
+	})
+	b.state = to
+}
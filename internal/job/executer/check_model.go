@@ -0,0 +1,128 @@
+package executer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Goboolean/core-system.worker/internal/infrastructure/kserve"
+	"github.com/Goboolean/core-system.worker/internal/job"
+)
+
+var ErrModelMismatch = errors.New("executer: model does not match the pipeline")
+
+// CheckModel checks that the model served through client is ready
+// and that the tensors declared in its metadata match what the executer sends and decodes.
+//
+// The input tensor must be the only input, named kserve.DefaultInputName, of FP32 and of inputShape.
+// Variable-size dimensions of the model match any size.
+// The first output tensor must be convertible into float32 and hold as many values as the output type decodes.
+// The number of the values is checked only if every dimension of the output is fixed.
+//
+// The check is skipped if client cannot inspect the model,
+// and the tensors are not compared if the model declares none.
+// A mismatch returns an error wrapping ErrModelMismatch.
+func CheckModel(ctx context.Context, client kserve.Client, inputShape []int, outputType string, params *job.UserParams) error {
+	inspector, ok := client.(kserve.ModelInspector)
+	if !ok {
+		return nil
+	}
+
+	modelID := (*params)[job.ModelID]
+
+	ready, err := inspector.ModelReady(ctx)
+	if errors.Is(err, errors.ErrUnsupported) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("check model %q: %w", modelID, err)
+	}
+	if !ready {
+		return fmt.Errorf("check model %q: %w", modelID, kserve.ErrModelNotReady)
+	}
+
+	metadata, err := inspector.ModelMetadata(ctx)
+	if err != nil {
+		return fmt.Errorf("check model %q: %w", modelID, err)
+	}
+
+	if err := checkInput(metadata.Inputs, inputShape); err != nil {
+		return fmt.Errorf("check model %q: %w", modelID, err)
+	}
+
+	n, exact := outputSize(outputType, params)
+	if err := checkOutput(metadata.Outputs, outputType, n, exact); err != nil {
+		return fmt.Errorf("check model %q: %w", modelID, err)
+	}
+
+	return nil
+}
+
+func checkInput(inputs []kserve.TensorMetadata, shape []int) error {
+	if len(inputs) == 0 {
+		return nil
+	}
+
+	names := make([]string, len(inputs))
+	for i, e := range inputs {
+		names[i] = e.Name
+	}
+
+	if len(inputs) != 1 || inputs[0].Name != kserve.DefaultInputName {
+		return fmt.Errorf("%w: the model declares inputs %q, but the executer sends only %q", ErrModelMismatch, names, kserve.DefaultInputName)
+	}
+
+	in := inputs[0]
+	if in.Datatype != kserve.FP32 {
+		return fmt.Errorf("%w: input %q is %s, but the executer sends %s", ErrModelMismatch, in.Name, in.Datatype, kserve.FP32)
+	}
+
+	if !matchShape(in.Shape, shape) {
+		return fmt.Errorf("%w: input %q has shape %v, but the feature window builds %v", ErrModelMismatch, in.Name, in.Shape, shape)
+	}
+
+	return nil
+}
+
+func checkOutput(outputs []kserve.TensorMetadata, outputType string, n int, exact bool) error {
+	if len(outputs) == 0 {
+		return nil
+	}
+
+	// RequestInference는 첫 번째 출력만 사용한다.
+	out := outputs[0]
+	if out.Datatype == kserve.BYTES {
+		return fmt.Errorf("%w: output %q is %s, which is not a number", ErrModelMismatch, out.Name, out.Datatype)
+	}
+
+	size := 1
+	for _, e := range out.Shape {
+		if e < 0 {
+			return nil
+		}
+		size *= int(e)
+	}
+
+	switch {
+	case exact && size != n:
+		return fmt.Errorf("%w: output %q has %d values, but %s expects %d", ErrModelMismatch, out.Name, size, outputType, n)
+	case !exact && size < n:
+		return fmt.Errorf("%w: output %q has %d values, but %s expects at least %d", ErrModelMismatch, out.Name, size, outputType, n)
+	}
+
+	return nil
+}
+
+// matchShape reports whether a tensor of shape fits the declared shape.
+func matchShape(declared []int64, shape []int) bool {
+	if len(declared) != len(shape) {
+		return false
+	}
+
+	for i, e := range declared {
+		if e >= 0 && e != int64(shape[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,127 @@
+package executer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Goboolean/common/pkg/resolver"
+	"github.com/Goboolean/core-system.worker/internal/infrastructure/kserve"
+	"github.com/Goboolean/core-system.worker/internal/infrastructure/kserve/kservetest"
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/job/executer"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type CheckModelTestSuite struct {
+	suite.Suite
+	server *kservetest.Server
+	client *kserve.ClientImpl
+}
+
+func (suite *CheckModelTestSuite) SetupTest() {
+	suite.server = kservetest.NewServer()
+	suite.server.AddModel("model", kservetest.Echo)
+
+	c, err := kserve.NewClient(&resolver.ConfigMap{"host": suite.server.URL, "modelID": "model"})
+	suite.Require().NoError(err)
+	suite.client = c
+}
+
+func (suite *CheckModelTestSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+func (suite *CheckModelTestSuite) setTensors(input, output kserve.TensorMetadata) {
+	suite.server.SetMetadata("model", kserve.ModelMetadata{
+		Name:    "model",
+		Inputs:  []kserve.TensorMetadata{input},
+		Outputs: []kserve.TensorMetadata{output},
+	})
+}
+
+func (suite *CheckModelTestSuite) check(outputType string, params job.UserParams) error {
+	params[job.ModelID] = "model"
+	return executer.CheckModel(context.Background(), suite.client, []int{32, 4}, outputType, &params)
+}
+
+func (suite *CheckModelTestSuite) TestCheckModel_ShouldPass_WhenTensorsMatch() {
+	//arrange
+	suite.setTensors(
+		kserve.TensorMetadata{Name: "input", Datatype: kserve.FP32, Shape: []int64{-1, 4}},
+		kserve.TensorMetadata{Name: "output", Datatype: kserve.FP64, Shape: []int64{1, 3}},
+	)
+
+	//act
+	err := suite.check(executer.OutputProbeDist, job.UserParams{job.OutputLabels: "down,flat,up"})
+
+	//assert
+	suite.NoError(err)
+}
+
+func (suite *CheckModelTestSuite) TestCheckModel_ShouldPass_WhenModelDeclaresNoTensor() {
+	//act
+	err := suite.check(executer.OutputCandlestick, job.UserParams{})
+
+	//assert
+	suite.NoError(err)
+}
+
+func (suite *CheckModelTestSuite) TestCheckModel_ShouldReturnError_WhenModelIsNotServed() {
+	//arrange
+	suite.server.SetReady("model", false)
+	unknown, err := kserve.NewClient(&resolver.ConfigMap{"host": suite.server.URL, "modelID": "unknown"})
+	suite.Require().NoError(err)
+
+	//act
+	notReadyErr := suite.check(executer.OutputCandlestick, job.UserParams{})
+	unknownErr := executer.CheckModel(context.Background(), unknown, []int{32, 4}, executer.OutputCandlestick, &job.UserParams{job.ModelID: "unknown"})
+
+	//assert
+	suite.ErrorIs(notReadyErr, kserve.ErrModelNotReady)
+	suite.ErrorIs(unknownErr, kserve.ErrModelNotFound)
+}
+
+func (suite *CheckModelTestSuite) TestCheckModel_ShouldReturnError_WhenTensorsMismatch() {
+	input := kserve.TensorMetadata{Name: "input", Datatype: kserve.FP32, Shape: []int64{32, 4}}
+	output := kserve.TensorMetadata{Name: "output", Datatype: kserve.FP32, Shape: []int64{4}}
+
+	for name, tc := range map[string]struct {
+		input      kserve.TensorMetadata
+		output     kserve.TensorMetadata
+		outputType string
+		params     job.UserParams
+	}{
+		"input name":        {kserve.TensorMetadata{Name: "x", Datatype: kserve.FP32, Shape: []int64{32, 4}}, output, executer.OutputCandlestick, job.UserParams{}},
+		"input datatype":    {kserve.TensorMetadata{Name: "input", Datatype: kserve.INT64, Shape: []int64{32, 4}}, output, executer.OutputCandlestick, job.UserParams{}},
+		"input rank":        {kserve.TensorMetadata{Name: "input", Datatype: kserve.FP32, Shape: []int64{-1, 32, 4}}, output, executer.OutputCandlestick, job.UserParams{}},
+		"input window":      {kserve.TensorMetadata{Name: "input", Datatype: kserve.FP32, Shape: []int64{16, 4}}, output, executer.OutputCandlestick, job.UserParams{}},
+		"output datatype":   {input, kserve.TensorMetadata{Name: "output", Datatype: kserve.BYTES, Shape: []int64{4}}, executer.OutputValueList, job.UserParams{}},
+		"short candlestick": {input, kserve.TensorMetadata{Name: "output", Datatype: kserve.FP32, Shape: []int64{1, 3}}, executer.OutputCandlestick, job.UserParams{}},
+		"labels mismatch":   {input, output, executer.OutputProbeDist, job.UserParams{job.OutputLabels: "down,flat,up"}},
+	} {
+		//arrange
+		suite.setTensors(tc.input, tc.output)
+
+		//act
+		err := suite.check(tc.outputType, tc.params)
+
+		//assert
+		suite.ErrorIs(err, executer.ErrModelMismatch, name)
+	}
+}
+
+func (suite *CheckModelTestSuite) TestCheckModel_ShouldSkip_WhenClientCannotInspectModel() {
+	//arrange
+	c := kserve.NewMockClient(gomock.NewController(suite.T()))
+
+	//act
+	err := executer.CheckModel(context.Background(), c, []int{32, 4}, executer.OutputCandlestick, &job.UserParams{})
+
+	//assert
+	suite.NoError(err)
+}
+
+func TestCheckModel(t *testing.T) {
+	suite.Run(t, new(CheckModelTestSuite))
+}
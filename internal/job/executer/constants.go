@@ -1,5 +1,10 @@
 package executer
 
+import "time"
+
 const (
-	DefaultMaxRetry = 5
+	DefaultMaxRetry    = 5
+	DefaultConcurrency = 1
+	// DefaultCheckModelTimeout is the time limit of checking the model while the executer is created.
+	DefaultCheckModelTimeout = 30 * time.Second
 )
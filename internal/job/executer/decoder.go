@@ -0,0 +1,166 @@
+package executer
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/model"
+)
+
+var ErrInvalidOutput = errors.New("executer: invalid model output")
+
+// probeDistTolerance is the tolerance of the sum of the probabilities of a model that outputs probabilities.
+const probeDistTolerance = 1e-3
+
+// Decoder maps the output tensor of a model into the model output type.
+// ref is the latest bar fed into the model. It is nil if the model takes quotes.
+type Decoder func(ref *model.StockAggregate, out []float32) (any, error)
+
+// NewDecoder creates the Decoder of the output type.
+//
+// OutputCandlestick: *model.StockAggregate of the next bar from out[0..3], which are High, Low, Open and Close.
+// OutputValueList: model.ValueList of out as it is.
+// OutputProbeDist: *model.ProbeDist of out.
+//
+// Param list
+// job.OutputLabels: comma separated labels of the classes of OutputProbeDist. The number of the outputs must match
+// job.OutputSoftmax: if "true", the outputs of OutputProbeDist are logits and converted into probabilities by softmax.
+// Otherwise they must be probabilities summing to 1
+func NewDecoder(outputType string, params *job.UserParams) (Decoder, error) {
+	switch outputType {
+	case OutputCandlestick:
+		return decodeCandlestick, nil
+	case OutputValueList:
+		return decodeValueList, nil
+	case OutputProbeDist:
+		labels := parseLabels(params)
+
+		softmax := false
+		if !params.IsKeyNilOrEmpty(job.OutputSoftmax) {
+			var err error
+			softmax, err = strconv.ParseBool((*params)[job.OutputSoftmax])
+			if err != nil {
+				return nil, fmt.Errorf("create decoder: %w", err)
+			}
+		}
+
+		return func(_ *model.StockAggregate, out []float32) (any, error) {
+			return decodeProbeDist(out, labels, softmax)
+		}, nil
+	default:
+		return nil, fmt.Errorf("create decoder: unsupported output type %q", outputType)
+	}
+}
+
+// outputSize returns the number of the outputs that the output type decodes.
+// If exact is false, n is the minimum number.
+func outputSize(outputType string, params *job.UserParams) (n int, exact bool) {
+	switch outputType {
+	case OutputCandlestick:
+		return 4, false
+	case OutputProbeDist:
+		if labels := parseLabels(params); labels != nil {
+			return len(labels), true
+		}
+		return 1, false
+	default:
+		return 1, false
+	}
+}
+
+// parseLabels returns the labels of job.OutputLabels. It returns nil if no label is given.
+func parseLabels(params *job.UserParams) []string {
+	if params.IsKeyNilOrEmpty(job.OutputLabels) {
+		return nil
+	}
+
+	labels := strings.Split((*params)[job.OutputLabels], ",")
+	for i, e := range labels {
+		labels[i] = strings.TrimSpace(e)
+	}
+	return labels
+}
+
+func decodeCandlestick(ref *model.StockAggregate, out []float32) (any, error) {
+	if len(out) < 4 {
+		return nil, fmt.Errorf("%w: expected 4 outputs, got %d", ErrInvalidOutput, len(out))
+	}
+
+	if ref == nil {
+		return nil, fmt.Errorf("%w: candlestick requires the input bar", ErrInvalidOutput)
+	}
+
+	//거래량 중요한 데이터가 아니므로 일단 0처리
+	return &model.StockAggregate{
+		OpenTime:   ref.ClosedTime,
+		ClosedTime: ref.ClosedTime + (ref.ClosedTime - ref.OpenTime),
+		High:       out[0],
+		Low:        out[1],
+		Open:       out[2],
+		Close:      out[3],
+		Volume:     0.0,
+	}, nil
+}
+
+func decodeValueList(_ *model.StockAggregate, out []float32) (any, error) {
+	if len(out) == 0 {
+		return nil, fmt.Errorf("%w: no output", ErrInvalidOutput)
+	}
+
+	// out은 client의 버퍼일 수 있으므로 복사한다.
+	return append(model.ValueList(nil), out...), nil
+}
+
+func decodeProbeDist(out []float32, labels []string, softmax bool) (*model.ProbeDist, error) {
+	if len(out) == 0 {
+		return nil, fmt.Errorf("%w: no output", ErrInvalidOutput)
+	}
+
+	if labels != nil && len(labels) != len(out) {
+		return nil, fmt.Errorf("%w: %d outputs for %d labels", ErrInvalidOutput, len(out), len(labels))
+	}
+
+	probs := make([]float64, len(out))
+	for i, e := range out {
+		probs[i] = float64(e)
+		if math.IsNaN(probs[i]) || math.IsInf(probs[i], 0) {
+			return nil, fmt.Errorf("%w: output %d is %v", ErrInvalidOutput, i, e)
+		}
+	}
+
+	if softmax {
+		// 가장 큰 logit을 빼서 overflow를 막는다.
+		hi := math.Inf(-1)
+		for _, e := range probs {
+			hi = max(hi, e)
+		}
+		for i, e := range probs {
+			probs[i] = math.Exp(e - hi)
+		}
+	}
+
+	sum := 0.0
+	for i, e := range probs {
+		if e < 0 {
+			return nil, fmt.Errorf("%w: probability %d is negative %v", ErrInvalidOutput, i, e)
+		}
+		sum += e
+	}
+
+	if !softmax && math.Abs(sum-1) > probeDistTolerance {
+		return nil, fmt.Errorf("%w: probabilities sum to %v", ErrInvalidOutput, sum)
+	}
+
+	res := &model.ProbeDist{
+		Labels: labels,
+		Probs:  make([]float32, len(probs)),
+	}
+	for i, e := range probs {
+		res.Probs[i] = float32(e / sum)
+	}
+	return res, nil
+}
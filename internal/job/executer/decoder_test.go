@@ -0,0 +1,91 @@
+package executer_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/job/executer"
+	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type DecoderTestSuite struct {
+	suite.Suite
+}
+
+func (suite *DecoderTestSuite) decode(outputType string, params *job.UserParams, out []float32) (any, error) {
+	decode, err := executer.NewDecoder(outputType, params)
+	suite.Require().NoError(err)
+	return decode(&model.StockAggregate{OpenTime: 60, ClosedTime: 120}, out)
+}
+
+func (suite *DecoderTestSuite) TestDecoder_ShouldDecodeNextBar_WhenOutputTypeIsCandlestick() {
+	//act
+	res, err := suite.decode(executer.OutputCandlestick, &job.UserParams{}, []float32{4, 1, 2, 3})
+
+	//assert
+	suite.NoError(err)
+	suite.Equal(&model.StockAggregate{OpenTime: 120, ClosedTime: 180, High: 4, Low: 1, Open: 2, Close: 3}, res)
+}
+
+func (suite *DecoderTestSuite) TestDecoder_ShouldCopyOutputs_WhenOutputTypeIsValueList() {
+	//arrange
+	out := []float32{1, 2, 3}
+
+	//act
+	res, err := suite.decode(executer.OutputValueList, &job.UserParams{}, out)
+	out[0] = 100
+
+	//assert
+	suite.NoError(err)
+	suite.Equal(model.ValueList{1, 2, 3}, res)
+}
+
+func (suite *DecoderTestSuite) TestDecoder_ShouldNormalizeProbabilities_WhenOutputTypeIsProbeDist() {
+	//act
+	res, err := suite.decode(executer.OutputProbeDist, &job.UserParams{}, []float32{0.2, 0.5, 0.3001})
+
+	//assert
+	suite.NoError(err)
+	dist := res.(*model.ProbeDist)
+	suite.Nil(dist.Labels)
+	suite.InDeltaSlice([]float32{0.2, 0.5, 0.3}, dist.Probs, 1e-3)
+	suite.Equal(1, dist.Argmax())
+	suite.Equal("1", dist.Label(1))
+}
+
+func (suite *DecoderTestSuite) TestDecoder_ShouldReturnError_WhenOutputIsInvalid() {
+	for name, e := range map[string]struct {
+		outputType string
+		params     job.UserParams
+		out        []float32
+	}{
+		"short candlestick":     {executer.OutputCandlestick, job.UserParams{}, []float32{1, 2, 3}},
+		"empty value list":      {executer.OutputValueList, job.UserParams{}, []float32{}},
+		"sum is not 1":          {executer.OutputProbeDist, job.UserParams{}, []float32{0.5, 0.2}},
+		"negative probability":  {executer.OutputProbeDist, job.UserParams{}, []float32{1.5, -0.5}},
+		"labels mismatch":       {executer.OutputProbeDist, job.UserParams{job.OutputLabels: "down,flat,up"}, []float32{0.5, 0.5}},
+		"infinite logit":        {executer.OutputProbeDist, job.UserParams{job.OutputSoftmax: "true"}, []float32{float32(math.Inf(1)), 0}},
+		"empty probe dist":      {executer.OutputProbeDist, job.UserParams{}, []float32{}},
+		"labels of empty probe": {executer.OutputProbeDist, job.UserParams{job.OutputLabels: "up"}, []float32{}},
+	} {
+		//act
+		_, err := suite.decode(e.outputType, &e.params, e.out)
+
+		//assert
+		suite.ErrorIs(err, executer.ErrInvalidOutput, name)
+	}
+}
+
+func (suite *DecoderTestSuite) TestNewDecoder_ShouldReturnError_WhenOutputTypeIsUnsupported() {
+	//act
+	_, err := executer.NewDecoder("orderBook", &job.UserParams{})
+
+	//assert
+	suite.Error(err)
+}
+
+func TestDecoder(t *testing.T) {
+	suite.Run(t, new(DecoderTestSuite))
+}
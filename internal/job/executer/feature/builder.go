@@ -0,0 +1,213 @@
+package feature
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Goboolean/core-system.worker/internal/model"
+)
+
+// Builder turns the stream of bars or quotes into input tensors of a model.
+// Whether it takes bars or quotes depends on the fields of the spec, see TakesQuotes.
+//
+// A bar contributes to tensors only after every field of it is available,
+// so indicators and returns delay the first tensor until they are warmed up.
+// After the window is filled, a tensor is built every Stride bars.
+type Builder struct {
+	spec Spec
+	// Either fields or quoteFields is empty.
+	fields      []field
+	quoteFields []quoteField
+	norms       []string
+
+	// rows is the ring buffer of the fields of the latest bars.
+	rows     [][]float64
+	count    int
+	lastFull int
+}
+
+// NewBuilder creates a new Builder of the spec.
+//
+// Available fields are FieldOpen, FieldHigh, FieldLow, FieldClose, FieldVolume,
+// FieldReturn, FieldLogReturn, FieldRange and the indicators of close with their period,
+// such as "sma:20", "ema:12" and "rsi:14".
+// The fields of a quote, FieldMid, FieldSpread and FieldImbalance, cannot be mixed with them.
+func NewBuilder(spec Spec) (*Builder, error) {
+	if len(spec.Fields) == 0 {
+		return nil, fmt.Errorf("%w: no field", ErrInvalidSpec)
+	}
+
+	if spec.Window <= 0 || spec.Stride <= 0 {
+		return nil, fmt.Errorf("%w: window %d and stride %d must be positive", ErrInvalidSpec, spec.Window, spec.Stride)
+	}
+
+	if spec.Layout != LayoutRow && spec.Layout != LayoutColumn {
+		return nil, fmt.Errorf("%w: unknown layout %q", ErrInvalidSpec, spec.Layout)
+	}
+
+	b := &Builder{
+		spec:  spec,
+		norms: make([]string, len(spec.Fields)),
+		rows:  make([][]float64, spec.Window),
+	}
+
+	for _, name := range spec.Fields {
+		if f, ok := quoteFields[name]; ok {
+			b.quoteFields = append(b.quoteFields, f)
+			continue
+		}
+
+		f, err := newField(name)
+		if err != nil {
+			return nil, err
+		}
+		b.fields = append(b.fields, f)
+	}
+
+	if len(b.fields) > 0 && len(b.quoteFields) > 0 {
+		return nil, fmt.Errorf("%w: fields of bars and quotes cannot be mixed", ErrInvalidSpec)
+	}
+
+	for name, norm := range spec.Normalization {
+		switch norm {
+		case NormalizationNone, NormalizationZScore, NormalizationMinMax:
+		default:
+			return nil, fmt.Errorf("%w: unknown normalization %q", ErrInvalidSpec, norm)
+		}
+
+		found := false
+		for i, e := range spec.Fields {
+			if e == name {
+				b.norms[i] = norm
+				found = true
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("%w: normalization of unknown field %q", ErrInvalidSpec, name)
+		}
+	}
+
+	for i := range b.rows {
+		b.rows[i] = make([]float64, len(spec.Fields))
+	}
+
+	return b, nil
+}
+
+// Shape returns the shape of the tensors.
+func (b *Builder) Shape() []int {
+	if b.spec.Layout == LayoutColumn {
+		return []int{len(b.spec.Fields), b.spec.Window}
+	}
+	return []int{b.spec.Window, len(b.spec.Fields)}
+}
+
+// TakesQuotes reports whether the fields are the fields of a quote,
+// in which case AddQuote is used instead of Add.
+func (b *Builder) TakesQuotes() bool {
+	return len(b.quoteFields) > 0
+}
+
+// Add feeds the latest bar and returns a tensor in row-major order if one is built.
+// It MUST NOT be called if the Builder takes quotes.
+func (b *Builder) Add(bar *model.StockAggregate) ([]float32, bool) {
+	row := b.rows[b.count%b.spec.Window]
+	ready := true
+	for i, f := range b.fields {
+		v, ok := f.next(bar)
+		row[i] = v
+		ready = ready && ok
+	}
+
+	if !ready {
+		return nil, false
+	}
+	return b.push()
+}
+
+// AddQuote feeds the features of the latest quote and returns a tensor in row-major order if one is built.
+// It MUST NOT be called unless the Builder takes quotes.
+func (b *Builder) AddQuote(q *model.QuoteFeatures) ([]float32, bool) {
+	row := b.rows[b.count%b.spec.Window]
+	for i, f := range b.quoteFields {
+		row[i] = f(q)
+	}
+	return b.push()
+}
+
+// push adds the row filled last to the window and builds a tensor if the window is full and the stride is reached.
+func (b *Builder) push() ([]float32, bool) {
+	b.count++
+
+	if b.count < b.spec.Window {
+		return nil, false
+	}
+
+	if b.lastFull != 0 && b.count-b.lastFull < b.spec.Stride {
+		return nil, false
+	}
+	b.lastFull = b.count
+
+	return b.build(), true
+}
+
+// build makes a tensor of the rows in the window from the oldest.
+func (b *Builder) build() []float32 {
+	window, numOfFields := b.spec.Window, len(b.spec.Fields)
+	res := make([]float32, window*numOfFields)
+	column := make([]float64, window)
+
+	for f := 0; f < numOfFields; f++ {
+		for t := 0; t < window; t++ {
+			column[t] = b.rows[(b.count+t)%window][f]
+		}
+		normalize(column, b.norms[f])
+
+		for t, v := range column {
+			if b.spec.Layout == LayoutColumn {
+				res[f*window+t] = float32(v)
+			} else {
+				res[t*numOfFields+f] = float32(v)
+			}
+		}
+	}
+
+	return res
+}
+
+func normalize(v []float64, norm string) {
+	switch norm {
+	case NormalizationZScore:
+		mean, sq := 0.0, 0.0
+		for _, e := range v {
+			mean += e
+		}
+		mean /= float64(len(v))
+		for _, e := range v {
+			sq += (e - mean) * (e - mean)
+		}
+		std := math.Sqrt(sq / float64(len(v)))
+
+		for i, e := range v {
+			if std == 0 {
+				v[i] = 0
+			} else {
+				v[i] = (e - mean) / std
+			}
+		}
+	case NormalizationMinMax:
+		lo, hi := math.Inf(1), math.Inf(-1)
+		for _, e := range v {
+			lo, hi = min(lo, e), max(hi, e)
+		}
+
+		for i, e := range v {
+			if hi == lo {
+				v[i] = 0
+			} else {
+				v[i] = (e - lo) / (hi - lo)
+			}
+		}
+	}
+}
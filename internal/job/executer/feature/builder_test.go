@@ -0,0 +1,184 @@
+package feature_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/job/executer/feature"
+	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type BuilderTestSuite struct {
+	suite.Suite
+}
+
+func bar(close float32) *model.StockAggregate {
+	return &model.StockAggregate{Open: close - 1, High: close + 1, Low: close - 2, Close: close, Volume: close * 10}
+}
+
+func (suite *BuilderTestSuite) build(spec feature.Spec, closes ...float32) [][]float32 {
+	b, err := feature.NewBuilder(spec)
+	suite.Require().NoError(err)
+
+	res := make([][]float32, 0)
+	for _, e := range closes {
+		if tensor, ok := b.Add(bar(e)); ok {
+			res = append(res, tensor)
+		}
+	}
+	return res
+}
+
+func (suite *BuilderTestSuite) TestParseSpec_ShouldBuildHighLowOpenCloseInRows_WhenNoSpecIsGiven() {
+	//arrange
+	spec, err := feature.ParseSpec(&job.UserParams{}, 2)
+	suite.Require().NoError(err)
+	b, err := feature.NewBuilder(spec)
+	suite.Require().NoError(err)
+
+	//act
+	_, first := b.Add(bar(10))
+	tensor, second := b.Add(bar(20))
+
+	//assert
+	suite.False(first)
+	suite.True(second)
+	suite.Equal([]int{2, 4}, b.Shape())
+	suite.Equal([]float32{11, 8, 9, 10, 21, 18, 19, 20}, tensor)
+}
+
+func (suite *BuilderTestSuite) TestParseSpec_ShouldReadEveryParam() {
+	//arrange
+	params := job.UserParams{
+		feature.FieldsParam:                         "close, volume",
+		feature.WindowParam:                         "3",
+		feature.StrideParam:                         "2",
+		feature.LayoutParam:                         feature.LayoutColumn,
+		feature.NormalizationParamPrefix + "volume": feature.NormalizationMinMax,
+	}
+
+	//act
+	spec, err := feature.ParseSpec(&params, 100)
+
+	//assert
+	suite.NoError(err)
+	suite.Equal(feature.Spec{
+		Fields:        []string{"close", "volume"},
+		Window:        3,
+		Stride:        2,
+		Layout:        feature.LayoutColumn,
+		Normalization: map[string]string{"volume": feature.NormalizationMinMax},
+	}, spec)
+}
+
+func (suite *BuilderTestSuite) TestAdd_ShouldBuildFieldMajorTensor_WhenLayoutIsColumn() {
+	//arrange
+	spec := feature.Spec{Fields: []string{"close", "volume"}, Window: 3, Stride: 1, Layout: feature.LayoutColumn}
+
+	//act
+	res := suite.build(spec, 1, 2, 3, 4)
+
+	//assert
+	suite.Equal([][]float32{
+		{1, 2, 3, 10, 20, 30},
+		{2, 3, 4, 20, 30, 40},
+	}, res)
+}
+
+func (suite *BuilderTestSuite) TestAdd_ShouldSkipBars_WhenStrideIsGreaterThanOne() {
+	//arrange
+	spec := feature.Spec{Fields: []string{"close"}, Window: 2, Stride: 3, Layout: feature.LayoutRow}
+
+	//act
+	res := suite.build(spec, 1, 2, 3, 4, 5, 6, 7, 8)
+
+	//assert
+	suite.Equal([][]float32{{1, 2}, {4, 5}, {7, 8}}, res)
+}
+
+func (suite *BuilderTestSuite) TestAdd_ShouldWaitForDerivedFields_WhenTheyAreWarmingUp() {
+	//arrange
+	spec := feature.Spec{Fields: []string{"return", "sma:3", "ema:2", "rsi:2", "range"}, Window: 1, Stride: 1, Layout: feature.LayoutRow}
+
+	//act
+	res := suite.build(spec, 1, 3, 1, 5)
+
+	//assert
+	// return은 2번째, sma:3은 3번째, ema:2는 2번째, rsi:2는 3번째 bar부터 계산된다.
+	suite.Require().Len(res, 2)
+	// ema = 2 + 2/3 * (1 - 2), rsi의 평균 상승폭과 하락폭은 모두 1이다.
+	suite.InDeltaSlice([]float32{-2.0 / 3, 5.0 / 3, 4.0 / 3, 50, 3}, res[0], 1e-5)
+	// ema = 4/3 + 2/3 * (5 - 4/3), 평균 상승폭 = (1 + 4) / 2, 평균 하락폭 = (1 + 0) / 2
+	suite.InDeltaSlice([]float32{4, 3, 34.0 / 9, 100 - 100.0/6, 3}, res[1], 1e-4)
+}
+
+func (suite *BuilderTestSuite) TestAdd_ShouldNormalizeEachFieldOverWindow() {
+	//arrange
+	spec := feature.Spec{
+		Fields:        []string{"close", "volume", "logReturn"},
+		Window:        3,
+		Stride:        1,
+		Layout:        feature.LayoutColumn,
+		Normalization: map[string]string{"close": feature.NormalizationZScore, "volume": feature.NormalizationMinMax},
+	}
+
+	//act
+	res := suite.build(spec, 1, 2, 3, 4)
+
+	//assert
+	// logReturn이 계산되지 않는 첫 bar는 window에 포함되지 않는다.
+	suite.Require().Len(res, 1)
+	z := float32(math.Sqrt(1.5))
+	suite.InDeltaSlice([]float32{
+		-z, 0, z,
+		0, 0.5, 1,
+		float32(math.Log(2)), float32(math.Log(3.0 / 2)), float32(math.Log(4.0 / 3)),
+	}, res[0], 1e-6)
+}
+
+func (suite *BuilderTestSuite) TestBuilder_ShouldBuildFieldsOfQuotes_WhenFieldsAreQuoteFields() {
+	//arrange
+	b, err := feature.NewBuilder(feature.Spec{
+		Fields: []string{feature.FieldMid, feature.FieldSpread, feature.FieldImbalance},
+		Window: 2,
+		Stride: 1,
+		Layout: feature.LayoutRow,
+	})
+	suite.Require().NoError(err)
+
+	//act
+	_, first := b.AddQuote(&model.QuoteFeatures{Mid: 100, Spread: 2, Imbalance: 0.5})
+	tensor, second := b.AddQuote(&model.QuoteFeatures{Mid: 101, Spread: 1, Imbalance: -0.5})
+
+	//assert
+	suite.True(b.TakesQuotes())
+	suite.False(first)
+	suite.True(second)
+	suite.Equal([]int{2, 3}, b.Shape())
+	suite.Equal([]float32{100, 2, 0.5, 101, 1, -0.5}, tensor)
+}
+
+func (suite *BuilderTestSuite) TestNewBuilder_ShouldReturnError_WhenSpecIsInvalid() {
+	for name, spec := range map[string]feature.Spec{
+		"no field":        {Window: 1, Stride: 1, Layout: feature.LayoutRow},
+		"unknown field":   {Fields: []string{"vwap"}, Window: 1, Stride: 1, Layout: feature.LayoutRow},
+		"invalid period":  {Fields: []string{"sma:0"}, Window: 1, Stride: 1, Layout: feature.LayoutRow},
+		"zero window":     {Fields: []string{"close"}, Window: 0, Stride: 1, Layout: feature.LayoutRow},
+		"unknown layout":  {Fields: []string{"close"}, Window: 1, Stride: 1, Layout: "diagonal"},
+		"unknown norm":    {Fields: []string{"close"}, Window: 1, Stride: 1, Layout: feature.LayoutRow, Normalization: map[string]string{"close": "log"}},
+		"norm of unknown": {Fields: []string{"close"}, Window: 1, Stride: 1, Layout: feature.LayoutRow, Normalization: map[string]string{"open": "zscore"}},
+		"mixed fields":    {Fields: []string{"close", "spread"}, Window: 1, Stride: 1, Layout: feature.LayoutRow},
+	} {
+		//act
+		_, err := feature.NewBuilder(spec)
+
+		//assert
+		suite.ErrorIs(err, feature.ErrInvalidSpec, name)
+	}
+}
+
+func TestBuilder(t *testing.T) {
+	suite.Run(t, new(BuilderTestSuite))
+}
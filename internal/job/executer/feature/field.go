@@ -0,0 +1,145 @@
+package feature
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/Goboolean/core-system.worker/internal/algorithm"
+	"github.com/Goboolean/core-system.worker/internal/model"
+)
+
+// Fields of a bar.
+const (
+	FieldOpen   = "open"
+	FieldHigh   = "high"
+	FieldLow    = "low"
+	FieldClose  = "close"
+	FieldVolume = "volume"
+	// FieldReturn is the simple return of close from the previous bar.
+	FieldReturn = "return"
+	// FieldLogReturn is the log return of close from the previous bar.
+	FieldLogReturn = "logReturn"
+	// FieldRange is high minus low.
+	FieldRange = "range"
+)
+
+// Fields of a quote, which are the features derived by model.Quote.Features.
+const (
+	FieldMid       = "mid"
+	FieldSpread    = "spread"
+	FieldImbalance = "imbalance"
+)
+
+// Indicators of close, which are given with their period such as "sma:20".
+const (
+	// IndicatorSMA is the simple moving average.
+	IndicatorSMA = "sma"
+	// IndicatorEMA is the exponential moving average seeded by the simple moving average.
+	IndicatorEMA = "ema"
+	// IndicatorRSI is the relative strength index with Wilder's smoothing.
+	IndicatorRSI = "rsi"
+)
+
+// field computes a feature from bars fed one by one.
+type field interface {
+	// next feeds the latest bar and returns the feature.
+	// It reports false until enough bars are fed.
+	next(bar *model.StockAggregate) (float64, bool)
+}
+
+type fieldFunc func(bar *model.StockAggregate) (float64, bool)
+
+func (f fieldFunc) next(bar *model.StockAggregate) (float64, bool) {
+	return f(bar)
+}
+
+// quoteField computes a feature from the features of a quote.
+type quoteField func(q *model.QuoteFeatures) float64
+
+// quoteFields are the fields of a quote keyed by their names.
+var quoteFields = map[string]quoteField{
+	FieldMid:       func(q *model.QuoteFeatures) float64 { return float64(q.Mid) },
+	FieldSpread:    func(q *model.QuoteFeatures) float64 { return float64(q.Spread) },
+	FieldImbalance: func(q *model.QuoteFeatures) float64 { return float64(q.Imbalance) },
+}
+
+func newField(name string) (field, error) {
+	switch name {
+	case FieldOpen:
+		return fieldFunc(func(bar *model.StockAggregate) (float64, bool) { return float64(bar.Open), true }), nil
+	case FieldHigh:
+		return fieldFunc(func(bar *model.StockAggregate) (float64, bool) { return float64(bar.High), true }), nil
+	case FieldLow:
+		return fieldFunc(func(bar *model.StockAggregate) (float64, bool) { return float64(bar.Low), true }), nil
+	case FieldClose:
+		return fieldFunc(func(bar *model.StockAggregate) (float64, bool) { return float64(bar.Close), true }), nil
+	case FieldVolume:
+		return fieldFunc(func(bar *model.StockAggregate) (float64, bool) { return float64(bar.Volume), true }), nil
+	case FieldRange:
+		return fieldFunc(func(bar *model.StockAggregate) (float64, bool) { return float64(bar.High - bar.Low), true }), nil
+	case FieldReturn:
+		return &returns{}, nil
+	case FieldLogReturn:
+		return &returns{log: true}, nil
+	}
+
+	kind, p, ok := strings.Cut(name, ":")
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidSpec, name)
+	}
+
+	period, err := strconv.Atoi(p)
+	if err != nil || period <= 0 {
+		return nil, fmt.Errorf("%w: invalid period of %q", ErrInvalidSpec, name)
+	}
+
+	// period는 위에서 검증했으므로 생성자는 실패하지 않는다.
+	switch kind {
+	case IndicatorSMA:
+		sma, _ := algorithm.NewSMA(period)
+		return indicator{sma}, nil
+	case IndicatorEMA:
+		ema, _ := algorithm.NewEMA(period)
+		return indicator{ema}, nil
+	case IndicatorRSI:
+		rsi, _ := algorithm.NewRSI(period)
+		return indicator{rsi}, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown indicator %q", ErrInvalidSpec, name)
+	}
+}
+
+type returns struct {
+	log       bool
+	prevClose float64
+	started   bool
+}
+
+func (r *returns) next(bar *model.StockAggregate) (float64, bool) {
+	close := float64(bar.Close)
+	defer func() {
+		r.prevClose = close
+		r.started = true
+	}()
+
+	if !r.started {
+		return 0, false
+	}
+
+	if r.log {
+		return math.Log(close / r.prevClose), true
+	}
+	return close/r.prevClose - 1, true
+}
+
+// indicator adapts an algorithm.Indicator to field.
+type indicator struct {
+	algorithm.Indicator
+}
+
+func (i indicator) next(bar *model.StockAggregate) (float64, bool) {
+	i.Update(bar)
+	return i.Value(), i.Ready()
+}
@@ -0,0 +1,111 @@
+// Package feature builds the input tensors of a model from the stream of bars or quotes.
+package feature
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+)
+
+var ErrInvalidSpec = errors.New("feature: invalid spec")
+
+// Param keys of Spec. Normalization of each field is given as NormalizationParamPrefix + field.
+const (
+	FieldsParam              = "feature.fields"
+	WindowParam              = "feature.window"
+	StrideParam              = "feature.stride"
+	LayoutParam              = "feature.layout"
+	NormalizationParamPrefix = "feature.normalization."
+)
+
+// Layouts of a tensor.
+const (
+	// LayoutRow makes a tensor of shape {window, fields}, where each row is the fields of a bar.
+	LayoutRow = "row"
+	// LayoutColumn makes a tensor of shape {fields, window}, where each row is a field over the window.
+	LayoutColumn = "column"
+)
+
+// Normalizations applied to a field over each window.
+const (
+	NormalizationNone = "none"
+	// NormalizationZScore subtracts the mean and divides by the standard deviation.
+	NormalizationZScore = "zscore"
+	// NormalizationMinMax maps the minimum to 0 and the maximum to 1.
+	NormalizationMinMax = "minmax"
+)
+
+// DefaultFields is the fields fed into a model when no field is specified.
+var DefaultFields = []string{FieldHigh, FieldLow, FieldOpen, FieldClose}
+
+// Spec specifies how the input tensors are built.
+type Spec struct {
+	// Fields is the fields of each bar or quote in the order of the tensor. See NewBuilder for the available fields.
+	Fields []string
+	// Window is the number of bars in a tensor.
+	Window int
+	// Stride is the number of bars between the last bars of successive tensors.
+	Stride int
+	// Layout is LayoutRow or LayoutColumn.
+	Layout string
+	// Normalization maps a field to its normalization. Fields without it are not normalized.
+	Normalization map[string]string
+}
+
+// ParseSpec reads Spec from params.
+//
+// Parameter List:
+// FieldsParam: Comma separated fields. Defaults to DefaultFields.
+// WindowParam: The number of bars in a tensor. Defaults to defaultWindow.
+// StrideParam: The number of bars between successive tensors. Defaults to 1.
+// LayoutParam: LayoutRow or LayoutColumn. Defaults to LayoutRow.
+// NormalizationParamPrefix + field: The normalization of the field.
+func ParseSpec(params *job.UserParams, defaultWindow int) (Spec, error) {
+	spec := Spec{
+		Fields:        DefaultFields,
+		Window:        defaultWindow,
+		Stride:        1,
+		Layout:        LayoutRow,
+		Normalization: make(map[string]string),
+	}
+
+	if !params.IsKeyNilOrEmpty(FieldsParam) {
+		spec.Fields = strings.Split((*params)[FieldsParam], ",")
+		for i, e := range spec.Fields {
+			spec.Fields[i] = strings.TrimSpace(e)
+		}
+	}
+
+	for _, e := range []struct {
+		key    string
+		target *int
+	}{
+		{WindowParam, &spec.Window},
+		{StrideParam, &spec.Stride},
+	} {
+		if params.IsKeyNilOrEmpty(e.key) {
+			continue
+		}
+
+		val, err := strconv.Atoi((*params)[e.key])
+		if err != nil {
+			return spec, fmt.Errorf("%w: %s: %w", ErrInvalidSpec, e.key, err)
+		}
+		*e.target = val
+	}
+
+	if !params.IsKeyNilOrEmpty(LayoutParam) {
+		spec.Layout = (*params)[LayoutParam]
+	}
+
+	for k, v := range *params {
+		if field, ok := strings.CutPrefix(k, NormalizationParamPrefix); ok {
+			spec.Normalization[field] = v
+		}
+	}
+
+	return spec, nil
+}
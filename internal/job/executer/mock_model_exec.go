@@ -3,27 +3,40 @@ package executer
 import (
 	"context"
 	"fmt"
+	"io"
 	"reflect"
 	"strconv"
 	"time"
 
 	"github.com/Goboolean/core-system.worker/internal/infrastructure/kserve"
 	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/job/executer/feature"
 	"github.com/Goboolean/core-system.worker/internal/model"
 	"github.com/Goboolean/core-system.worker/internal/util"
 	"github.com/Goboolean/core-system.worker/internal/util/chanutil"
 	"github.com/cenkalti/backoff"
+	"golang.org/x/sync/errgroup"
 )
 
 // Mock is a struct that describes the typical logic of requesting KServe to execute a model.
 // Depending on the actual model specifications, the content of this struct may vary.
 type Mock struct {
-	//TODO: map으로 변경?
-	//user param의 type은 float32
-	modelParam1 float32
+	// parameters is sent as the parameters of every inference request.
+	parameters map[string]any
 
-	batchSize int32
-	maxRetry  int32
+	batchSize   int32
+	maxRetry    int32
+	concurrency int
+
+	// features builds the input tensors. Its window defaults to batchSize.
+	features *feature.Builder
+	// decode maps the output tensors into the output type.
+	decode Decoder
+	// breaker is nil if no circuit breaker is configured.
+	breaker  *circuitBreaker
+	fallback string
+	// last is the latest output, which FallbackLastKnown sends again.
+	last any
 
 	kServeClient kserve.Client
 
@@ -31,118 +44,392 @@ type Mock struct {
 	out     job.DataChan `type:""` //Job은 자신의 Output 채널에 대해 소유권을 가진다.
 	errChan chan error
 
+	annotations job.DataChan `type:"model.CircuitBreakerStateChange|model.InferenceFallback"`
+
 	stop *util.StopNotifier
 }
 
-// NewMock creates new NewMock instance
+// initializeKServe creates Mock decoding the outputs into the output type.
+// The model is checked by CheckModel, so that a pipeline with a wrong model fails before it runs.
+func initializeKServe(outputType string) jobProvider {
+	return func(p *job.UserParams) (ModelExecutor, error) {
+		d, err := NewDecoder(outputType, p)
+		if err != nil {
+			return nil, err
+		}
+
+		m, err := initializeMock(d, p)
+		if err != nil {
+			return nil, err
+		}
+
+		// candlestick은 입력 bar의 시간을 기준으로 만들어지므로 quote를 입력받는 model은 사용할 수 없다.
+		if outputType == OutputCandlestick && m.features.TakesQuotes() {
+			if c, ok := m.kServeClient.(io.Closer); ok {
+				c.Close()
+			}
+			return nil, fmt.Errorf("create mock model exec job: %s output requires the fields of a bar", OutputCandlestick)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), DefaultCheckModelTimeout)
+		defer cancel()
+
+		if err := CheckModel(ctx, m.kServeClient, m.features.Shape(), outputType, p); err != nil {
+			if c, ok := m.kServeClient.(io.Closer); ok {
+				c.Close()
+			}
+			return nil, fmt.Errorf("create mock model exec job: %w", err)
+		}
+		return m, nil
+	}
+}
+
+// NewMock creates new NewMock instance sending the outputs decoded by decode
 //
 // Param list
 // job.BatchSize: the number of trade data that you feed into your model at each iteration of the inference
-// "param1": an example param of model params
-func NewMock(kServeClient kserve.Client, params *job.UserParams) (*Mock, error) {
+// job.Concurrency: the number of inference requests in flight at once. Defaults to DefaultConcurrency
+// feature.*: the spec of the input tensors. See feature.ParseSpec
+// If the fields are the fields of a quote, the inputs must be *model.Quote or *model.QuoteFeatures instead of bars
+// BreakerFailureThresholdParam, BreakerOpenTimeoutParam: the circuit breaker around inference. No breaker if the threshold is not given
+// FallbackParam: the policy applied when an inference fails. FallbackFail|FallbackSkip|FallbackLastKnown. Defaults to FallbackFail
+// ModelParamPrefix+{key}: a parameter of the inference requests encoded by EncodeModelParam
+func NewMock(kServeClient kserve.Client, decode Decoder, params *job.UserParams) (*Mock, error) {
 	//여기에 기본값 초기화 아웃풋 채널은 job이 소유권을 가져야 한다.
 	instance := &Mock{
 		kServeClient: kServeClient,
+		decode:       decode,
 		maxRetry:     DefaultMaxRetry,
+		concurrency:  DefaultConcurrency,
+		fallback:     FallbackFail,
 		out:          make(job.DataChan),
 		errChan:      make(chan error),
+		annotations:  make(job.DataChan),
 		stop:         util.NewStopNotifier(),
 	}
 
 	//여기에서 user param 초기화
-	if param1, ok := (*params)["param1"]; ok {
-		val, err := strconv.ParseFloat(param1, 32)
+	parameters, err := parseModelParams(params)
+	if err != nil {
+		return nil, fmt.Errorf("create mock model exec job: %w", err)
+	}
+	instance.parameters = parameters
+
+	if param1, ok := (*params)[job.BatchSize]; ok {
+		val, err := strconv.ParseInt(param1, 10, 32)
 
 		if err != nil {
 			return nil, fmt.Errorf("create mock model exec job: %w", err)
 		}
 
-		instance.modelParam1 = float32(val)
+		instance.batchSize = int32(val)
 	}
 
-	if param1, ok := (*params)[job.BatchSize]; ok {
-		val, err := strconv.ParseInt(param1, 10, 32)
-
+	if !params.IsKeyNilOrEmpty(job.Concurrency) {
+		val, err := strconv.Atoi((*params)[job.Concurrency])
 		if err != nil {
 			return nil, fmt.Errorf("create mock model exec job: %w", err)
 		}
 
-		instance.batchSize = int32(val)
+		if val <= 0 {
+			return nil, fmt.Errorf("create mock model exec job: concurrency must be positive, got %d", val)
+		}
+
+		instance.concurrency = val
+	}
+
+	if !params.IsKeyNilOrEmpty(FallbackParam) {
+		switch fallback := (*params)[FallbackParam]; fallback {
+		case FallbackFail, FallbackSkip, FallbackLastKnown:
+			instance.fallback = fallback
+		default:
+			return nil, fmt.Errorf("create mock model exec job: unknown fallback policy %q", fallback)
+		}
+	}
+
+	breaker, err := newCircuitBreaker(params)
+	if err != nil {
+		return nil, fmt.Errorf("create mock model exec job: %w", err)
+	}
+	instance.breaker = breaker
+
+	spec, err := feature.ParseSpec(params, max(int(instance.batchSize), 1))
+	if err != nil {
+		return nil, fmt.Errorf("create mock model exec job: %w", err)
+	}
+
+	instance.features, err = feature.NewBuilder(spec)
+	if err != nil {
+		return nil, fmt.Errorf("create mock model exec job: %w", err)
 	}
 
 	return instance, nil
 }
 
+// inference is an inference request in flight.
+// done is closed when out or err is set.
+// A watermark input is queued as an inference without a request so that it keeps its order.
+type inference struct {
+	input model.Packet
+	// data is the latest bar fed into the model. It is nil if the model takes quotes.
+	data *model.StockAggregate
+
+	out  []float32
+	err  error
+	done chan struct{}
+}
+
 // Execute starts to execute model through KServe and pass the model output to out channel
 //
+// At most m.concurrency requests are in flight at once,
+// and the outputs are sent in the order of the inputs regardless of the order the responses arrive in.
+// If an inference fails, the fallback policy decides whether to fail, skip the input or send the latest output again.
+// The state changes of the circuit breaker and the applied fallbacks are sent to Annotations.
+//
 // If the Job fails to perform its task, Execute returns an error.
 // If the Job completes successfully, it returns nil.
 // DO NOT CALL Execute() TWICE. IT MUST BE PANIC
 func (m *Mock) Execute() error {
 
 	defer close(m.out)
+	defer close(m.annotations)
 	defer func() {
 		go chanutil.DummyChannelConsumer(m.in)
 	}()
-	var accumulator = make([]float32, 0)
+	// gRPC client는 연결을 소유하므로 job이 끝나면 닫아야 한다.
+	if c, ok := m.kServeClient.(io.Closer); ok {
+		defer c.Close()
+	}
+
+	stopCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
-	for input := range m.in {
-		//TODO: 고루틴이 무한정 생성되는 문제 해결
-		ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(1*60*time.Second))
-		go func() {
-			<-m.stop.Done()
+	// 요청마다 고루틴을 만들지 않고 job 하나에 하나의 고루틴으로 중단을 전달한다.
+	go func() {
+		select {
+		case <-m.stop.Done():
 			cancel()
-		}()
+		case <-stopCtx.Done():
+		}
+	}()
+
+	g, ctx := errgroup.WithContext(stopCtx)
+
+	// pending is the reorder buffer holding requests in the order of the inputs.
+	// slots limits the number of requests in flight. Every entry of pending takes a slot, including watermarks,
+	// so pending has room for an entry that has taken a slot.
+	pending := make(chan *inference, m.concurrency)
+	slots := make(chan struct{}, m.concurrency)
+
+	g.Go(func() error {
+		defer close(pending)
+		return m.request(ctx, g, pending, slots)
+	})
+
+	g.Go(func() error {
+		for req := range pending {
+			if model.IsWatermark(req.input) {
+				<-slots
+				select {
+				case <-ctx.Done():
+					return nil
+				case m.out <- req.input:
+				}
+				continue
+			}
 
-		data, ok := input.Data.(*model.StockAggregate)
+			<-req.done
+			<-slots
+
+			if m.breaker != nil {
+				for _, e := range m.breaker.takeChanges() {
+					if !m.annotate(ctx, req.input.Time, e) {
+						return nil
+					}
+				}
+			}
+
+			data, err := m.output(ctx, req)
+			if err != nil {
+				return err
+			}
+
+			if data == nil {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case m.out <- model.Packet{Time: req.input.Time, Data: data}:
+			}
+		}
+		return nil
+	})
+
+	err := g.Wait()
+
+	select {
+	case <-m.stop.Done():
+		// 중단 요청으로 인해 취소된 요청의 에러는 무시한다.
+		return nil
+	default:
+		return err
+	}
+}
+
+// output decodes the response of req, or applies the fallback policy if the inference failed.
+// It returns nil if no output is sent for req.
+func (m *Mock) output(ctx context.Context, req *inference) (any, error) {
+	if req.err == nil {
+		//반환 받은 텐서 타입에서 알맞은 타입으로 가공한다.
+		data, err := m.decode(req.data, req.out)
+		if err != nil {
+			return nil, fmt.Errorf("model exec job: %w", err)
+		}
+		m.last = data
+		return data, nil
+	}
+
+	// 중단되어 취소된 요청은 fallback을 적용하지 않는다.
+	if ctx.Err() != nil {
+		return nil, nil
+	}
+
+	policy, data := m.fallback, m.last
+	switch {
+	case policy == FallbackFail:
+		return nil, fmt.Errorf("model exec job: inference service returns error %w", req.err)
+	case policy == FallbackSkip, data == nil:
+		policy, data = FallbackSkip, nil
+	}
+
+	m.annotate(ctx, req.input.Time, model.InferenceFallback{Policy: policy, Reason: req.err.Error()})
+	return data, nil
+}
+
+// annotate sends the annotation. It reports false if ctx is done.
+func (m *Mock) annotate(ctx context.Context, t time.Time, annotation any) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case m.annotations <- model.Packet{Time: t, Data: annotation}:
+		return true
+	}
+}
+
+// request accumulates inputs into batches and starts an inference request of each batch.
+func (m *Mock) request(ctx context.Context, g *errgroup.Group, pending chan<- *inference, slots chan struct{}) error {
+	for {
+		var input model.Packet
+		var ok bool
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case input, ok = <-m.in:
+		}
 
 		if !ok {
-			return fmt.Errorf("model exec job: type mismatch. expected *model.StockAggregate, got %s %w", reflect.TypeOf(input), job.ErrTypeMismatch)
+			return nil
+		}
+
+		// watermark는 앞선 입력의 출력 뒤에 전달되도록 요청과 같은 순서로 넣는다.
+		if model.IsWatermark(input) {
+			if !m.enqueue(ctx, pending, slots, &inference{input: input}) {
+				return nil
+			}
+			continue
 		}
 
 		//데이터를 1차원 텐서 타입으로 변환한다.
 		//데이터가 충분히 쌓일 때까지 다음 동작을 실행할 수 없도록 막는다.
-		var numOfInput = 4
-		accumulator = append(accumulator, data.High, data.Low, data.Open, data.Close)
-		if len(accumulator)/numOfInput < int(m.batchSize) {
+		var data *model.StockAggregate
+		var batch []float32
+		if m.features.TakesQuotes() {
+			var quote *model.QuoteFeatures
+			quote, ok = model.AsQuoteFeatures(input.Data)
+			if !ok {
+				return fmt.Errorf("model exec job: type mismatch. expected *model.Quote or *model.QuoteFeatures, got %s %w", reflect.TypeOf(input.Data), job.ErrTypeMismatch)
+			}
+			batch, ok = m.features.AddQuote(quote)
+		} else {
+			data, ok = model.AsStockAggregate(input.Data)
+			if !ok {
+				return fmt.Errorf("model exec job: type mismatch. expected *model.StockAggregate, got %s %w", reflect.TypeOf(input.Data), job.ErrTypeMismatch)
+			}
+			batch, ok = m.features.Add(data)
+		}
+
+		if !ok {
 			continue
 		}
 
-		//이를 http client를 이용해 kserve로 보낸다.
-		var out []float32
+		req := &inference{
+			input: input,
+			data:  data,
+			done:  make(chan struct{}),
+		}
+		if !m.enqueue(ctx, pending, slots, req) {
+			return nil
+		}
 
-		b := backoff.WithMaxRetries(backoff.WithContext(backoff.NewExponentialBackOff(), ctx), uint64(m.maxRetry))
+		g.Go(func() error {
+			defer close(req.done)
+			req.out, req.err = m.infer(ctx, m.features.Shape(), batch)
+			return nil
+		})
+	}
+}
 
-		if err := backoff.Retry(func() error {
-			var err error
-			// Shape = [model.StockAggregate에서 사용되는 데이터의 개수 = 7, batch size]
-			out, err = m.kServeClient.RequestInference(ctx, []int{numOfInput, int(m.batchSize)}, accumulator)
-			return err
+// enqueue takes a slot and puts req into pending. It reports false if ctx is done.
+// The consumer of pending may stop before releasing the slots, so both are guarded by ctx.
+func (m *Mock) enqueue(ctx context.Context, pending chan<- *inference, slots chan<- struct{}, req *inference) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case slots <- struct{}{}:
+	}
+
+	select {
+	case <-ctx.Done():
+		return false
+	case pending <- req:
+		return true
+	}
+}
+
+// infer requests an inference to KServe, retrying up to m.maxRetry times with exponential backoff.
+// Every attempt is recorded by the circuit breaker, and no attempt is made while it rejects requests.
+func (m *Mock) infer(ctx context.Context, shape []int, batch []float32) ([]float32, error) {
+	ctx, cancel := context.WithTimeout(ctx, 1*60*time.Second)
+	defer cancel()
+
+	var out []float32
+	b := backoff.WithMaxRetries(backoff.WithContext(backoff.NewExponentialBackOff(), ctx), uint64(m.maxRetry))
 
-		}, b); err != nil {
-			return fmt.Errorf("model exec job: inference service returns error %w", err)
+	err := backoff.Retry(func() error {
+		if m.breaker != nil && !m.breaker.allow() {
+			return backoff.Permanent(ErrCircuitOpen)
 		}
 
-		accumulator = accumulator[numOfInput:]
+		var err error
+		if m.parameters == nil {
+			out, err = m.kServeClient.RequestInference(ctx, shape, batch)
+		} else {
+			out, err = kserve.RequestInferenceWithParameters(ctx, m.kServeClient, shape, batch, m.parameters)
+		}
 
-		//반환 받은 텐서 타입에서 알맞은 타입으로 가공한다.
-		//지금은 모델이 candlestick를 리턴한다고 가정한다.
-		//거래량 중요한 데이터가 아니므로 일단 0처리
-		m.out <- model.Packet{
-			Time: input.Time,
-			Data: &model.StockAggregate{
-				OpenTime:   data.ClosedTime,
-				ClosedTime: data.ClosedTime + (data.ClosedTime - data.OpenTime),
-				High:       out[0],
-				Low:        out[1],
-				Open:       out[2],
-				Close:      out[3],
-				Volume:     0.0,
-			},
-		}
-	}
-
-	return nil
+		if m.breaker != nil {
+			if err != nil {
+				m.breaker.failure()
+			} else {
+				m.breaker.success()
+			}
+		}
+		return err
+	}, b)
+	return out, err
 }
 
 func (m *Mock) SetInput(input job.DataChan) {
@@ -153,6 +440,10 @@ func (m *Mock) Output() job.DataChan {
 	return m.out
 }
 
+func (m *Mock) Annotations() job.DataChan {
+	return m.annotations
+}
+
 func (m *Mock) Cancel() {
 	m.stop.NotifyStop()
 }
@@ -1,15 +1,23 @@
 package executer_test
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"runtime"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/Goboolean/core-system.worker/internal/infrastructure/kserve"
 	"github.com/Goboolean/core-system.worker/internal/job"
 	"github.com/Goboolean/core-system.worker/internal/job/executer"
+	"github.com/Goboolean/core-system.worker/internal/job/executer/feature"
 	"github.com/Goboolean/core-system.worker/internal/model"
 	"github.com/Goboolean/core-system.worker/internal/util"
+	"github.com/Goboolean/core-system.worker/internal/util/chanutil"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/mock/gomock"
 )
@@ -22,14 +30,23 @@ func TestMain(m *testing.M) {
 	m.Run()
 }
 
+func (suite *MockTestSuite) newMock(client kserve.Client, outputType string, params *job.UserParams) *executer.Mock {
+	decode, err := executer.NewDecoder(outputType, params)
+	suite.Require().NoError(err)
+
+	m, err := executer.NewMock(client, decode, params)
+	suite.Require().NoError(err)
+	return m
+}
+
 func (suite *MockTestSuite) TestMock_Should_ProcessBatchInferences_When_BatchSizeIs2() {
 	//arrange
 	ctl := gomock.NewController(suite.T())
 	m := kserve.NewMockClient(ctl)
 
-	m.EXPECT().RequestInference(gomock.Any(), gomock.Any(), []float32{1, 1, 1, 1, 2, 2, 2, 2}).Return(
+	m.EXPECT().RequestInference(gomock.Any(), []int{2, 4}, []float32{1, 1, 1, 1, 2, 2, 2, 2}).Return(
 		[]float32{1, 2, 3, 4}, nil)
-	m.EXPECT().RequestInference(gomock.Any(), gomock.Any(), []float32{2, 2, 2, 2, 3, 3, 3, 3}).Return(
+	m.EXPECT().RequestInference(gomock.Any(), []int{2, 4}, []float32{2, 2, 2, 2, 3, 3, 3, 3}).Return(
 		[]float32{5, 6, 7, 8}, nil)
 
 	input := []*model.StockAggregate{
@@ -69,8 +86,7 @@ func (suite *MockTestSuite) TestMock_Should_ProcessBatchInferences_When_BatchSiz
 	}
 	close(inChan)
 
-	execute, err := executer.NewMock(m, &job.UserParams{job.BatchSize: "2"})
-	suite.Require().NoError(err)
+	execute := suite.newMock(m, executer.OutputCandlestick, &job.UserParams{job.BatchSize: "2"})
 
 	execute.SetInput(inChan)
 
@@ -109,7 +125,7 @@ func (suite *MockTestSuite) TestMock_Should_ProcessBatchInferences_When_BatchSiz
 		}
 	}()
 
-	err = execute.Execute()
+	err := execute.Execute()
 
 	suite.Require().False(util.IsWaitGroupTimeout(wg, 5*time.Second))
 
@@ -119,6 +135,463 @@ func (suite *MockTestSuite) TestMock_Should_ProcessBatchInferences_When_BatchSiz
 	suite.Len(errsInPipe, 0)
 }
 
+// slowClient responds to earlier requests later, recording the number of requests in flight.
+type slowClient struct {
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+}
+
+func (c *slowClient) Infer(ctx context.Context, req *kserve.InferenceRequest) (*kserve.InferenceResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (c *slowClient) RequestInference(ctx context.Context, shape []int, input []float32) ([]float32, error) {
+	n := c.inFlight.Add(1)
+	defer c.inFlight.Add(-1)
+	for {
+		max := c.maxInFlight.Load()
+		if n <= max || c.maxInFlight.CompareAndSwap(max, n) {
+			break
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(time.Duration(20-input[0]) * time.Millisecond):
+	}
+	return []float32{input[0], input[0], input[0], input[0]}, nil
+}
+
+func (suite *MockTestSuite) TestMock_ShouldSendOutputsInInputOrder_WhenRequestsAreInFlightConcurrently() {
+	//arrange
+	num := 12
+	client := &slowClient{}
+	inChan := make(job.DataChan, num)
+	for i := 0; i < num; i++ {
+		inChan <- model.Packet{
+			Time: time.Unix(int64(i), 0),
+			Data: &model.StockAggregate{
+				OpenTime:   int64(i),
+				ClosedTime: int64(i + 1),
+				High:       float32(i),
+			},
+		}
+	}
+	close(inChan)
+
+	execute := suite.newMock(client, executer.OutputCandlestick, &job.UserParams{job.BatchSize: "1", job.Concurrency: "3"})
+	execute.SetInput(inChan)
+
+	//act
+	res := make([]float32, 0)
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for v := range execute.Output() {
+			res = append(res, v.Data.(*model.StockAggregate).High)
+		}
+	}()
+
+	err := execute.Execute()
+	suite.Require().False(util.IsWaitGroupTimeout(wg, 5*time.Second))
+
+	//assert
+	suite.NoError(err)
+	suite.Equal([]float32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}, res)
+	suite.EqualValues(3, client.maxInFlight.Load())
+}
+
+func (suite *MockTestSuite) TestMock_ShouldPassWatermarksAfterPrecedingOutputs_WhenRequestsAreInFlightConcurrently() {
+	//arrange
+	num := 6
+	client := &slowClient{}
+	inChan := make(job.DataChan, 2*num)
+	for i := 0; i < num; i++ {
+		inChan <- model.Packet{
+			Time: time.Unix(int64(i), 0),
+			Data: &model.StockAggregate{High: float32(i)},
+		}
+		inChan <- model.Packet{Time: time.Unix(int64(i), 0), Data: model.Watermark{}}
+	}
+	close(inChan)
+
+	execute := suite.newMock(client, executer.OutputCandlestick, &job.UserParams{job.BatchSize: "1", job.Concurrency: "3"})
+	execute.SetInput(inChan)
+
+	//act
+	res := make([]string, 0)
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for v := range execute.Output() {
+			if model.IsWatermark(v) {
+				res = append(res, fmt.Sprintf("w%d", v.Time.Unix()))
+			} else {
+				res = append(res, fmt.Sprintf("d%d", v.Time.Unix()))
+			}
+		}
+	}()
+
+	err := execute.Execute()
+	suite.Require().False(util.IsWaitGroupTimeout(wg, 5*time.Second))
+
+	//assert
+	suite.NoError(err)
+	suite.Equal([]string{"d0", "w0", "d1", "w1", "d2", "w2", "d3", "w3", "d4", "w4", "d5", "w5"}, res)
+}
+
+func (suite *MockTestSuite) TestMock_ShouldNotLeakGoroutines_WhenManyInputsAreProcessed() {
+	//arrange
+	num := 1000
+	ctl := gomock.NewController(suite.T())
+	m := kserve.NewMockClient(ctl)
+	m.EXPECT().RequestInference(gomock.Any(), gomock.Any(), gomock.Any()).Return([]float32{1, 2, 3, 4}, nil).Times(num)
+
+	inChan := make(job.DataChan, num)
+	for i := 0; i < num; i++ {
+		inChan <- model.Packet{Time: time.Unix(int64(i), 0), Data: &model.StockAggregate{}}
+	}
+	close(inChan)
+
+	execute := suite.newMock(m, executer.OutputCandlestick, &job.UserParams{job.BatchSize: "1", job.Concurrency: "4"})
+	execute.SetInput(inChan)
+
+	before := runtime.NumGoroutine()
+	peak := make(chan int)
+	go func() {
+		res := 0
+		for range execute.Output() {
+			res = max(res, runtime.NumGoroutine())
+		}
+		peak <- res
+	}()
+
+	//act
+	err := execute.Execute()
+
+	//assert
+	suite.NoError(err)
+	suite.Less(<-peak-before, 20)
+}
+
+func (suite *MockTestSuite) TestMock_ShouldSendProbeDist_WhenOutputTypeIsProbeDist() {
+	//arrange
+	ctl := gomock.NewController(suite.T())
+	m := kserve.NewMockClient(ctl)
+	m.EXPECT().RequestInference(gomock.Any(), gomock.Any(), gomock.Any()).Return([]float32{0, float32(math.Log(3))}, nil)
+
+	inChan := make(job.DataChan, 1)
+	inChan <- model.Packet{Time: time.Unix(1, 0), Data: &model.StockAggregate{}}
+	close(inChan)
+
+	execute := suite.newMock(m, executer.OutputProbeDist, &job.UserParams{
+		job.BatchSize:     "1",
+		job.OutputLabels:  "down, up",
+		job.OutputSoftmax: "true",
+	})
+	execute.SetInput(inChan)
+
+	res := make(chan any, 1)
+	go func() {
+		for v := range execute.Output() {
+			res <- v.Data
+		}
+		close(res)
+	}()
+
+	//act
+	err := execute.Execute()
+
+	//assert
+	suite.NoError(err)
+	dist := (<-res).(*model.ProbeDist)
+	suite.Equal([]string{"down", "up"}, dist.Labels)
+	suite.InDeltaSlice([]float32{0.25, 0.75}, dist.Probs, 1e-6)
+	suite.Equal("up", dist.Label(dist.Argmax()))
+}
+
+func (suite *MockTestSuite) TestMock_ShouldFeedFeaturesOfQuotes_WhenFieldsAreQuoteFields() {
+	//arrange
+	ctl := gomock.NewController(suite.T())
+	m := kserve.NewMockClient(ctl)
+	m.EXPECT().RequestInference(gomock.Any(), []int{2, 3}, []float32{100, 2, 0.5, 101, 1, -0.5}).Return([]float32{1}, nil)
+
+	inChan := make(job.DataChan, 2)
+	// quote는 feature로 변환되어 입력된다.
+	inChan <- model.Packet{Time: time.Unix(1, 0), Data: &model.Quote{BidPrice: 99, BidSize: 30, AskPrice: 101, AskSize: 10}}
+	inChan <- model.Packet{Time: time.Unix(2, 0), Data: &model.QuoteFeatures{Mid: 101, Spread: 1, Imbalance: -0.5}}
+	close(inChan)
+
+	execute := suite.newMock(m, executer.OutputValueList, &job.UserParams{
+		job.BatchSize:       "2",
+		feature.FieldsParam: "mid, spread, imbalance",
+	})
+	execute.SetInput(inChan)
+
+	res := make(chan any, 1)
+	go func() {
+		for v := range execute.Output() {
+			res <- v.Data
+		}
+		close(res)
+	}()
+
+	//act
+	err := execute.Execute()
+
+	//assert
+	suite.NoError(err)
+	suite.Equal(model.ValueList{1}, <-res)
+}
+
+func (suite *MockTestSuite) TestMock_ShouldReturnError_WhenBarIsFedIntoModelTakingQuotes() {
+	//arrange
+	inChan := make(job.DataChan, 1)
+	inChan <- model.Packet{Time: time.Unix(1, 0), Data: &model.StockAggregate{}}
+	close(inChan)
+
+	execute := suite.newMock(kserve.NewMockClient(gomock.NewController(suite.T())), executer.OutputValueList, &job.UserParams{
+		job.BatchSize:       "1",
+		feature.FieldsParam: "mid",
+	})
+	execute.SetInput(inChan)
+	go func() {
+		for range execute.Output() {
+		}
+	}()
+
+	//act
+	err := execute.Execute()
+
+	//assert
+	suite.ErrorIs(err, job.ErrTypeMismatch)
+}
+
+func (suite *MockTestSuite) TestMock_ShouldSendModelParamsAsRequestParameters() {
+	//arrange
+	params := job.UserParams{job.BatchSize: "1"}
+	for k, v := range map[string]any{"mode": "fast", "topK": 5, "scale": 2.0, "debug": true} {
+		val, err := executer.EncodeModelParam(v)
+		suite.Require().NoError(err)
+		params[executer.ModelParamPrefix+k] = val
+	}
+
+	ctl := gomock.NewController(suite.T())
+	m := kserve.NewMockClient(ctl)
+	var req *kserve.InferenceRequest
+	m.EXPECT().Infer(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, r *kserve.InferenceRequest) (*kserve.InferenceResponse, error) {
+		req = r
+		return &kserve.InferenceResponse{Outputs: []kserve.Tensor{{Datatype: kserve.FP32, Data: []float32{1, 2, 3, 4}}}}, nil
+	})
+
+	inChan := make(job.DataChan, 1)
+	inChan <- model.Packet{Time: time.Unix(1, 0), Data: &model.StockAggregate{}}
+	close(inChan)
+
+	execute := suite.newMock(m, executer.OutputCandlestick, &params)
+	execute.SetInput(inChan)
+	go func() {
+		for range execute.Output() {
+		}
+	}()
+
+	//act
+	err := execute.Execute()
+
+	//assert
+	suite.NoError(err)
+	suite.Require().NotNil(req)
+	suite.Equal(map[string]any{"mode": "fast", "topK": int64(5), "scale": 2.0, "debug": true}, req.Parameters)
+}
+
+func (suite *MockTestSuite) TestNewMock_ShouldReturnError_WhenModelParamIsInvalid() {
+	//arrange
+	decode, err := executer.NewDecoder(executer.OutputCandlestick, &job.UserParams{})
+	suite.Require().NoError(err)
+
+	//act
+	_, err = executer.NewMock(nil, decode, &job.UserParams{executer.ModelParamPrefix + "list": "[1, 2]"})
+
+	//assert
+	suite.ErrorIs(err, executer.ErrInvalidModelParam)
+}
+
+// scriptedClient fails the calls whose index is in failures and returns the number of calls otherwise.
+type scriptedClient struct {
+	kserve.Client
+	failures map[int]bool
+
+	mu    sync.Mutex
+	calls int
+}
+
+func (c *scriptedClient) RequestInference(ctx context.Context, shape []int, input []float32) ([]float32, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.calls++
+	if c.failures[c.calls] || c.failures[-1] {
+		return nil, errors.New("unavailable")
+	}
+	v := float32(c.calls)
+	return []float32{v, v, v, v}, nil
+}
+
+// runWithAnnotations executes the job with num inputs and returns the Close of the outputs and the annotations.
+func (suite *MockTestSuite) runWithAnnotations(client kserve.Client, num int, params job.UserParams) ([]float32, []any, error) {
+	params[job.BatchSize] = "1"
+	execute := suite.newMock(client, executer.OutputCandlestick, &params)
+
+	inChan := make(job.DataChan, num)
+	for i := 0; i < num; i++ {
+		inChan <- model.Packet{Time: time.Unix(int64(i), 0), Data: &model.StockAggregate{}}
+	}
+	close(inChan)
+	execute.SetInput(inChan)
+
+	outputs := make([]float32, 0)
+	annotations := make([]any, 0)
+	wg := &sync.WaitGroup{}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for v := range execute.Output() {
+			outputs = append(outputs, v.Data.(*model.StockAggregate).Close)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for v := range execute.Annotations() {
+			annotations = append(annotations, v.Data)
+		}
+	}()
+
+	err := execute.Execute()
+	suite.Require().False(util.IsWaitGroupTimeout(wg, 10*time.Second))
+	return outputs, annotations, err
+}
+
+func (suite *MockTestSuite) TestMock_ShouldSkipInputs_WhenCircuitIsOpenAndFallbackIsSkip() {
+	//arrange
+	client := &scriptedClient{failures: map[int]bool{-1: true}}
+
+	//act
+	outputs, annotations, err := suite.runWithAnnotations(client, 3, job.UserParams{
+		executer.BreakerFailureThresholdParam: "1",
+		executer.BreakerOpenTimeoutParam:      "1h",
+		executer.FallbackParam:                executer.FallbackSkip,
+	})
+
+	//assert
+	suite.NoError(err)
+	suite.Empty(outputs)
+	// 첫 요청의 실패로 breaker가 열린 뒤에는 KServe에 요청하지 않는다.
+	suite.Equal(1, client.calls)
+	reason := executer.ErrCircuitOpen.Error()
+	suite.Equal([]any{
+		model.CircuitBreakerStateChange{From: executer.BreakerClosed, To: executer.BreakerOpen, Failures: 1},
+		model.InferenceFallback{Policy: executer.FallbackSkip, Reason: reason},
+		model.InferenceFallback{Policy: executer.FallbackSkip, Reason: reason},
+		model.InferenceFallback{Policy: executer.FallbackSkip, Reason: reason},
+	}, annotations)
+}
+
+func (suite *MockTestSuite) TestMock_ShouldSendLastOutput_WhenInferenceFailsAndFallbackIsLastKnown() {
+	//arrange
+	client := &scriptedClient{failures: map[int]bool{2: true, 3: true, 4: true}}
+
+	//act
+	outputs, annotations, err := suite.runWithAnnotations(client, 3, job.UserParams{
+		executer.BreakerFailureThresholdParam: "1",
+		executer.BreakerOpenTimeoutParam:      "1h",
+		executer.FallbackParam:                executer.FallbackLastKnown,
+	})
+
+	//assert
+	suite.NoError(err)
+	suite.Equal([]float32{1, 1, 1}, outputs)
+	suite.Equal([]any{
+		model.CircuitBreakerStateChange{From: executer.BreakerClosed, To: executer.BreakerOpen, Failures: 1},
+		model.InferenceFallback{Policy: executer.FallbackLastKnown, Reason: executer.ErrCircuitOpen.Error()},
+		model.InferenceFallback{Policy: executer.FallbackLastKnown, Reason: executer.ErrCircuitOpen.Error()},
+	}, annotations)
+}
+
+func (suite *MockTestSuite) TestMock_ShouldCloseCircuit_WhenProbeSucceedsInHalfOpenState() {
+	//arrange
+	client := &scriptedClient{failures: map[int]bool{1: true, 2: true}}
+
+	//act
+	outputs, annotations, err := suite.runWithAnnotations(client, 1, job.UserParams{
+		executer.BreakerFailureThresholdParam: "1",
+		executer.BreakerOpenTimeoutParam:      "1ms",
+	})
+
+	//assert
+	suite.NoError(err)
+	suite.Equal([]float32{3}, outputs)
+	suite.Equal([]any{
+		model.CircuitBreakerStateChange{From: executer.BreakerClosed, To: executer.BreakerOpen, Failures: 1},
+		model.CircuitBreakerStateChange{From: executer.BreakerOpen, To: executer.BreakerHalfOpen, Failures: 1},
+		// 탐색 요청이 실패하면 다시 열린다.
+		model.CircuitBreakerStateChange{From: executer.BreakerHalfOpen, To: executer.BreakerOpen, Failures: 2},
+		model.CircuitBreakerStateChange{From: executer.BreakerOpen, To: executer.BreakerHalfOpen, Failures: 2},
+		model.CircuitBreakerStateChange{From: executer.BreakerHalfOpen, To: executer.BreakerClosed, Failures: 0},
+	}, annotations)
+}
+
+func (suite *MockTestSuite) TestMock_ShouldFail_WhenInferenceFailsAndFallbackIsFail() {
+	//arrange
+	client := &scriptedClient{failures: map[int]bool{-1: true}}
+
+	//act
+	_, _, err := suite.runWithAnnotations(client, 2, job.UserParams{
+		executer.BreakerFailureThresholdParam: "1",
+		executer.BreakerOpenTimeoutParam:      "1h",
+	})
+
+	//assert
+	suite.ErrorIs(err, executer.ErrCircuitOpen)
+}
+
+func (suite *MockTestSuite) TestMock_ShouldFail_WhenInferenceFailsBeforeWatermarkAndNextInput() {
+	for i := 0; i < 20; i++ {
+		//arrange
+		client := &scriptedClient{failures: map[int]bool{-1: true}}
+		execute := suite.newMock(client, executer.OutputCandlestick, &job.UserParams{
+			job.BatchSize:                         "1",
+			executer.BreakerFailureThresholdParam: "1",
+			executer.BreakerOpenTimeoutParam:      "1h",
+		})
+
+		inChan := make(job.DataChan, 3)
+		inChan <- model.Packet{Time: time.Unix(0, 0), Data: &model.StockAggregate{}}
+		inChan <- model.Packet{Time: time.Unix(0, 0), Data: model.Watermark{}}
+		inChan <- model.Packet{Time: time.Unix(1, 0), Data: &model.StockAggregate{}}
+		close(inChan)
+		execute.SetInput(inChan)
+
+		go chanutil.DummyChannelConsumer(execute.Output())
+		go chanutil.DummyChannelConsumer(execute.Annotations())
+
+		//act
+		var err error
+		wg := &sync.WaitGroup{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err = execute.Execute()
+		}()
+
+		//assert
+		suite.Require().False(util.IsWaitGroupTimeout(wg, 5*time.Second), "Execute must return")
+		suite.ErrorIs(err, executer.ErrCircuitOpen)
+	}
+}
+
 func TestMock(t *testing.T) {
 	suite.Run(t, new(MockTestSuite))
 }
@@ -0,0 +1,179 @@
+package executer
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"reflect"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/Goboolean/core-system.worker/internal/util"
+	"github.com/Goboolean/core-system.worker/internal/util/chanutil"
+)
+
+// DefaultNativeModelDir is the directory of the coefficient files used when NATIVE_MODEL_DIR is not set.
+const DefaultNativeModelDir = "models"
+
+// Native executes a simple model such as a linear regression or an ARIMA forecaster in process,
+// so that a pipeline with the model runs without KServe.
+//
+// The output is *model.StockAggregate of the next bar if the output type is OutputCandlestick,
+// or the prediction decoded by the Decoder of the output type otherwise.
+// A univariate forecaster predicts the next bar opening at the latest close and closing at the first forecast.
+// Nothing is sent until the model is fed enough bars.
+type Native struct {
+	model      nativeModel
+	outputType string
+	decode     Decoder
+
+	in  job.DataChan `type:"*StockAggregate"`
+	out job.DataChan `type:""` //Job은 자신의 Output 채널에 대해 소유권을 가진다.
+
+	stop *util.StopNotifier
+}
+
+// initializeNative creates Native reading coefficient files from NATIVE_MODEL_DIR.
+func initializeNative(outputType string) jobProvider {
+	return func(p *job.UserParams) (ModelExecutor, error) {
+		dir := os.Getenv("NATIVE_MODEL_DIR")
+		if dir == "" {
+			dir = DefaultNativeModelDir
+		}
+		return NewNative(os.DirFS(dir), outputType, p)
+	}
+}
+
+// NewNative creates new instance of Native loading the coefficients of the model from {job.ModelID}.json of models.
+//
+// Param list
+// job.ModelID: the ID of the model, which is the name of the coefficient file without extension
+// job.OutputLabels, job.OutputSoftmax: see NewDecoder
+func NewNative(models fs.FS, outputType string, params *job.UserParams) (*Native, error) {
+	if params.IsKeyNilOrEmpty(job.ModelID) {
+		return nil, fmt.Errorf("create native model exec job: %w: model ID is empty", ErrInvalidModel)
+	}
+
+	m, err := loadNativeModel(models, (*params)[job.ModelID])
+	if err != nil {
+		return nil, fmt.Errorf("create native model exec job: %w", err)
+	}
+
+	switch {
+	case outputType == OutputValueList, outputType == OutputProbeDist:
+	case outputType == OutputCandlestick && m.univariate():
+	case outputType == OutputCandlestick:
+		if r, ok := m.(*regression); !ok || len(r.weights) != 4 {
+			return nil, fmt.Errorf("create native model exec job: %w: candlestick requires 4 outputs", ErrInvalidModel)
+		}
+	default:
+		return nil, fmt.Errorf("create native model exec job: unsupported output type %q", outputType)
+	}
+
+	decode, err := NewDecoder(outputType, params)
+	if err != nil {
+		return nil, fmt.Errorf("create native model exec job: %w", err)
+	}
+
+	//여기에 기본값 초기화 아웃풋 채널은 job이 소유권을 가져야 한다.
+	return &Native{
+		model:      m,
+		outputType: outputType,
+		decode:     decode,
+		out:        make(job.DataChan),
+		stop:       util.NewStopNotifier(),
+	}, nil
+}
+
+// Execute starts to execute the model and pass the model output to out channel
+//
+// If the Job fails to perform its task, Execute returns an error.
+// If the Job completes successfully, it returns nil.
+// DO NOT CALL Execute() TWICE. IT MUST BE PANIC
+func (n *Native) Execute() error {
+	defer close(n.out)
+	defer func() {
+		go chanutil.DummyChannelConsumer(n.in)
+	}()
+
+	for {
+		select {
+		case <-n.stop.Done():
+			return nil
+		case input, ok := <-n.in:
+			if !ok {
+				return nil
+			}
+
+			if model.IsWatermark(input) {
+				select {
+				case <-n.stop.Done():
+					return nil
+				case n.out <- input:
+				}
+				continue
+			}
+
+			data, ok := model.AsStockAggregate(input.Data)
+			if !ok {
+				return fmt.Errorf("model exec job: type mismatch. expected *model.StockAggregate, got %s %w", reflect.TypeOf(input.Data), job.ErrTypeMismatch)
+			}
+
+			prediction, ok := n.model.update(data)
+			if !ok {
+				continue
+			}
+
+			output, err := n.output(data, prediction)
+			if err != nil {
+				return fmt.Errorf("model exec job: %w", err)
+			}
+
+			select {
+			case <-n.stop.Done():
+				return nil
+			case n.out <- model.Packet{Time: input.Time, Data: output}:
+			}
+		}
+	}
+}
+
+func (n *Native) output(data *model.StockAggregate, prediction []float64) (any, error) {
+	if n.outputType != OutputCandlestick {
+		out := make([]float32, len(prediction))
+		for i, e := range prediction {
+			out[i] = float32(e)
+		}
+		return n.decode(data, out)
+	}
+
+	res := &model.StockAggregate{
+		OpenTime:   data.ClosedTime,
+		ClosedTime: data.ClosedTime + (data.ClosedTime - data.OpenTime),
+	}
+
+	if n.model.univariate() {
+		res.Open = data.Close
+		res.Close = float32(prediction[0])
+		res.High = max(res.Open, res.Close)
+		res.Low = min(res.Open, res.Close)
+	} else {
+		res.High = float32(prediction[0])
+		res.Low = float32(prediction[1])
+		res.Open = float32(prediction[2])
+		res.Close = float32(prediction[3])
+	}
+	return res, nil
+}
+
+func (n *Native) SetInput(input job.DataChan) {
+	n.in = input
+}
+
+func (n *Native) Output() job.DataChan {
+	return n.out
+}
+
+func (n *Native) Cancel() {
+	n.stop.NotifyStop()
+}
@@ -0,0 +1,324 @@
+package executer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/Goboolean/core-system.worker/internal/model"
+)
+
+var (
+	ErrUnknownModelType = errors.New("native model: unknown model type")
+	ErrInvalidModel     = errors.New("native model: invalid coefficients")
+)
+
+const (
+	ModelLinear = "linear"
+	ModelRidge  = "ridge"
+	ModelAR     = "ar"
+	ModelARIMA  = "arima"
+	ModelETS    = "ets"
+)
+
+// nativeModelFile is the fitted coefficients of a native model stored as {modelID}.json.
+//
+// linear, ridge: Window, Intercept, Weights
+// ar: Constant, AR, Horizon
+// arima: Constant, AR, D, MA, Horizon
+// ets: Alpha, Beta, Horizon
+type nativeModelFile struct {
+	Type string `json:"type"`
+
+	// Window is the number of latest bars fed into a regression.
+	Window int `json:"window"`
+	// Intercept is the intercept of each output of a regression.
+	Intercept []float64 `json:"intercept"`
+	// Weights is the weights of each output of a regression.
+	// Each row has 4*Window weights for High, Low, Open and Close of the bars from the oldest to the latest.
+	Weights [][]float64 `json:"weights"`
+
+	// Constant is the constant term of the differenced close.
+	Constant float64 `json:"constant"`
+	// AR is the autoregressive coefficients from lag 1.
+	AR []float64 `json:"ar"`
+	// D is the order of differencing.
+	D int `json:"d"`
+	// MA is the moving average coefficients from lag 1.
+	MA []float64 `json:"ma"`
+
+	// Alpha is the smoothing factor of the level.
+	Alpha float64 `json:"alpha"`
+	// Beta is the smoothing factor of the trend. Zero means simple exponential smoothing.
+	Beta float64 `json:"beta"`
+
+	// Horizon is the number of steps to forecast. Defaults to 1.
+	Horizon int `json:"horizon"`
+}
+
+// nativeModel predicts from bars fed one by one.
+type nativeModel interface {
+	// update feeds the latest bar and returns the prediction.
+	// It reports false until enough bars are fed.
+	update(bar *model.StockAggregate) ([]float64, bool)
+
+	// univariate reports whether the prediction is the forecasts of close
+	// instead of the outputs of High, Low, Open and Close.
+	univariate() bool
+}
+
+// loadNativeModel reads the coefficients of the model from {modelID}.json of fsys.
+func loadNativeModel(fsys fs.FS, modelID string) (nativeModel, error) {
+	b, err := fs.ReadFile(fsys, modelID+".json")
+	if err != nil {
+		return nil, err
+	}
+
+	var f nativeModelFile
+	if err := json.Unmarshal(b, &f); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidModel, err)
+	}
+
+	if f.Horizon == 0 {
+		f.Horizon = 1
+	}
+	if f.Horizon < 0 {
+		return nil, fmt.Errorf("%w: horizon %d", ErrInvalidModel, f.Horizon)
+	}
+
+	switch f.Type {
+	case ModelLinear, ModelRidge:
+		// Ridge regression differs from linear regression only in fitting.
+		return newRegression(f)
+	case ModelAR:
+		if f.D != 0 || len(f.MA) != 0 {
+			return nil, fmt.Errorf("%w: ar model with d or ma", ErrInvalidModel)
+		}
+		return newARIMA(f)
+	case ModelARIMA:
+		return newARIMA(f)
+	case ModelETS:
+		return newETS(f)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownModelType, f.Type)
+	}
+}
+
+// regression is a linear regression on High, Low, Open and Close of the latest bars.
+type regression struct {
+	window    int
+	intercept []float64
+	weights   [][]float64
+
+	features []float64
+}
+
+func newRegression(f nativeModelFile) (*regression, error) {
+	if f.Window <= 0 {
+		return nil, fmt.Errorf("%w: window %d", ErrInvalidModel, f.Window)
+	}
+
+	if len(f.Weights) == 0 || len(f.Weights) != len(f.Intercept) {
+		return nil, fmt.Errorf("%w: %d rows of weights for %d intercepts", ErrInvalidModel, len(f.Weights), len(f.Intercept))
+	}
+
+	for i, e := range f.Weights {
+		if len(e) != 4*f.Window {
+			return nil, fmt.Errorf("%w: row %d has %d weights for window %d", ErrInvalidModel, i, len(e), f.Window)
+		}
+	}
+
+	return &regression{
+		window:    f.Window,
+		intercept: f.Intercept,
+		weights:   f.Weights,
+		features:  make([]float64, 0, 4*f.Window),
+	}, nil
+}
+
+func (r *regression) update(bar *model.StockAggregate) ([]float64, bool) {
+	if len(r.features) == 4*r.window {
+		r.features = append(r.features[:0], r.features[4:]...)
+	}
+	r.features = append(r.features, float64(bar.High), float64(bar.Low), float64(bar.Open), float64(bar.Close))
+
+	if len(r.features) < 4*r.window {
+		return nil, false
+	}
+
+	res := make([]float64, len(r.weights))
+	for i, w := range r.weights {
+		res[i] = r.intercept[i]
+		for j, x := range r.features {
+			res[i] += w[j] * x
+		}
+	}
+	return res, true
+}
+
+func (r *regression) univariate() bool {
+	return false
+}
+
+// arima is an ARIMA(p, d, q) forecaster of close.
+//
+// The residuals are the errors of the one-step forecasts made while bars are fed,
+// so they are zero until the first forecast.
+type arima struct {
+	constant float64
+	ar       []float64
+	ma       []float64
+	d        int
+	horizon  int
+
+	// raw is the latest d+1 closes.
+	raw []float64
+	// diffs is the latest len(ar) differenced closes from the oldest.
+	diffs []float64
+	// residuals is the latest len(ma) residuals from the oldest.
+	residuals []float64
+
+	next    float64
+	hasNext bool
+}
+
+func newARIMA(f nativeModelFile) (*arima, error) {
+	if f.D < 0 {
+		return nil, fmt.Errorf("%w: d %d", ErrInvalidModel, f.D)
+	}
+
+	return &arima{
+		constant:  f.Constant,
+		ar:        f.AR,
+		ma:        f.MA,
+		d:         f.D,
+		horizon:   f.Horizon,
+		raw:       make([]float64, 0, f.D+1),
+		diffs:     make([]float64, 0, len(f.AR)),
+		residuals: make([]float64, len(f.MA)),
+	}, nil
+}
+
+func (a *arima) update(bar *model.StockAggregate) ([]float64, bool) {
+	a.raw = pushBounded(a.raw, float64(bar.Close), a.d+1)
+	if len(a.raw) < a.d+1 {
+		return nil, false
+	}
+
+	// levels[i] is the latest close differenced i times.
+	levels := make([]float64, a.d+1)
+	diff := append([]float64(nil), a.raw...)
+	for i := 0; i <= a.d; i++ {
+		levels[i] = diff[len(diff)-1]
+		for j := 0; j < len(diff)-1; j++ {
+			diff[j] = diff[j+1] - diff[j]
+		}
+		diff = diff[:len(diff)-1]
+	}
+	w := levels[a.d]
+
+	residual := 0.0
+	if a.hasNext {
+		residual = w - a.next
+	}
+	a.residuals = pushBounded(a.residuals, residual, len(a.ma))
+	a.diffs = pushBounded(a.diffs, w, len(a.ar))
+
+	if len(a.diffs) < len(a.ar) {
+		return nil, false
+	}
+
+	// Forecast the differenced closes. Future residuals are zero.
+	history := append([]float64(nil), a.diffs...)
+	forecasts := make([]float64, a.horizon)
+	for k := 0; k < a.horizon; k++ {
+		v := a.constant
+		for i, phi := range a.ar {
+			v += phi * history[len(history)-1-i]
+		}
+		for j, theta := range a.ma {
+			// The residual of lag j+1 is known only if it is not in the future.
+			if m := j - k; m >= 0 {
+				v += theta * a.residuals[len(a.residuals)-1-m]
+			}
+		}
+		forecasts[k] = v
+		history = append(history, v)
+	}
+
+	a.next = forecasts[0]
+	a.hasNext = true
+
+	// Integrate the forecasts back to closes.
+	for i := a.d - 1; i >= 0; i-- {
+		last := levels[i]
+		for k := range forecasts {
+			last += forecasts[k]
+			forecasts[k] = last
+		}
+	}
+
+	return forecasts, true
+}
+
+func (a *arima) univariate() bool {
+	return true
+}
+
+// ets is an exponential smoothing forecaster of close with an additive trend.
+type ets struct {
+	alpha   float64
+	beta    float64
+	horizon int
+
+	level   float64
+	trend   float64
+	started bool
+}
+
+func newETS(f nativeModelFile) (*ets, error) {
+	if f.Alpha <= 0 || f.Alpha > 1 || f.Beta < 0 || f.Beta > 1 {
+		return nil, fmt.Errorf("%w: alpha %v, beta %v", ErrInvalidModel, f.Alpha, f.Beta)
+	}
+
+	return &ets{
+		alpha:   f.Alpha,
+		beta:    f.Beta,
+		horizon: f.Horizon,
+	}, nil
+}
+
+func (e *ets) update(bar *model.StockAggregate) ([]float64, bool) {
+	y := float64(bar.Close)
+
+	if !e.started {
+		e.level = y
+		e.started = true
+	} else {
+		level := e.alpha*y + (1-e.alpha)*(e.level+e.trend)
+		e.trend = e.beta*(level-e.level) + (1-e.beta)*e.trend
+		e.level = level
+	}
+
+	res := make([]float64, e.horizon)
+	for k := range res {
+		res[k] = e.level + float64(k+1)*e.trend
+	}
+	return res, true
+}
+
+func (e *ets) univariate() bool {
+	return true
+}
+
+// pushBounded appends v to s and drops the oldest elements so that s has at most n elements.
+func pushBounded(s []float64, v float64, n int) []float64 {
+	if n == 0 {
+		return s[:0]
+	}
+	if len(s) == n {
+		s = append(s[:0], s[1:]...)
+	}
+	return append(s, v)
+}
@@ -0,0 +1,152 @@
+package executer_test
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/job/executer"
+	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/sync/errgroup"
+)
+
+type NativeTestSuite struct {
+	suite.Suite
+}
+
+func (suite *NativeTestSuite) run(coefficients string, outputType string, closes ...float32) []any {
+	models := fstest.MapFS{"model.json": &fstest.MapFile{Data: []byte(coefficients)}}
+	native, err := executer.NewNative(models, outputType, &job.UserParams{job.ModelID: "model"})
+	suite.Require().NoError(err)
+
+	in := make(job.DataChan, len(closes))
+	start := time.Unix(1716775440, 0)
+	for i, e := range closes {
+		in <- model.Packet{
+			Time: start.Add(time.Duration(i) * time.Minute),
+			Data: &model.StockAggregate{
+				OpenTime:   start.Unix() + int64(i)*60,
+				ClosedTime: start.Unix() + int64(i+1)*60,
+				Open:       e - 1,
+				High:       e + 1,
+				Low:        e - 2,
+				Close:      e,
+			},
+		}
+	}
+	close(in)
+	native.SetInput(in)
+
+	g := errgroup.Group{}
+	g.Go(native.Execute)
+
+	res := make([]any, 0)
+	for p := range native.Output() {
+		res = append(res, p.Data)
+	}
+	suite.Require().NoError(g.Wait())
+	return res
+}
+
+func (suite *NativeTestSuite) TestNative_ShouldPredictNextBar_WhenLinearRegressionOutputsCandlestick() {
+	//arrange
+	// window 2에서 최신 bar의 값에 1을 더한다.
+	coefficients := `{
+		"type": "linear",
+		"window": 2,
+		"intercept": [1, 1, 1, 1],
+		"weights": [
+			[0, 0, 0, 0, 1, 0, 0, 0],
+			[0, 0, 0, 0, 0, 1, 0, 0],
+			[0, 0, 0, 0, 0, 0, 1, 0],
+			[0, 0, 0, 0, 0, 0, 0, 1]
+		]
+	}`
+
+	//act
+	res := suite.run(coefficients, executer.OutputCandlestick, 10, 20, 30)
+
+	//assert
+	suite.Equal([]any{
+		&model.StockAggregate{OpenTime: 1716775560, ClosedTime: 1716775620, High: 22, Low: 19, Open: 20, Close: 21},
+		&model.StockAggregate{OpenTime: 1716775620, ClosedTime: 1716775680, High: 32, Low: 29, Open: 30, Close: 31},
+	}, res)
+}
+
+func (suite *NativeTestSuite) TestNative_ShouldForecastIntegratedCloses_WhenARIMAHasDifferencing() {
+	//arrange
+	coefficients := `{"type": "arima", "ar": [0.5], "d": 1, "horizon": 2}`
+
+	//act
+	res := suite.run(coefficients, executer.OutputValueList, 10, 12, 13)
+
+	//assert
+	suite.Equal([]any{
+		model.ValueList{13, 13.5},
+		model.ValueList{13.5, 13.75},
+	}, res)
+}
+
+func (suite *NativeTestSuite) TestNative_ShouldUseResidualsOfOneStepForecasts_WhenARIMAHasMovingAverage() {
+	//arrange
+	coefficients := `{"type": "arima", "ma": [0.5], "horizon": 2}`
+
+	//act
+	res := suite.run(coefficients, executer.OutputValueList, 1, 3)
+
+	//assert
+	suite.Equal([]any{
+		model.ValueList{0, 0},
+		model.ValueList{1.5, 0},
+	}, res)
+}
+
+func (suite *NativeTestSuite) TestNative_ShouldForecastTrend_WhenExponentialSmoothingOutputsCandlestick() {
+	//arrange
+	coefficients := `{"type": "ets", "alpha": 0.5, "beta": 0.5, "horizon": 2}`
+
+	//act
+	valueList := suite.run(coefficients, executer.OutputValueList, 10, 14)
+	candlestick := suite.run(coefficients, executer.OutputCandlestick, 10, 14)
+
+	//assert
+	suite.Equal([]any{model.ValueList{10, 10}, model.ValueList{13, 14}}, valueList)
+	suite.Equal(&model.StockAggregate{OpenTime: 1716775560, ClosedTime: 1716775620, High: 14, Low: 13, Open: 14, Close: 13}, candlestick[1])
+}
+
+func (suite *NativeTestSuite) TestNewNative_ShouldReturnError_WhenCoefficientsAreInvalid() {
+	//arrange
+	models := fstest.MapFS{
+		"unknown.json":  &fstest.MapFile{Data: []byte(`{"type": "lstm"}`)},
+		"mismatch.json": &fstest.MapFile{Data: []byte(`{"type": "ridge", "window": 2, "intercept": [0], "weights": [[1, 2, 3, 4]]}`)},
+		"twoOuts.json":  &fstest.MapFile{Data: []byte(`{"type": "linear", "window": 1, "intercept": [0, 0], "weights": [[1, 0, 0, 0], [0, 1, 0, 0]]}`)},
+		"alpha.json":    &fstest.MapFile{Data: []byte(`{"type": "ets", "alpha": 1.5}`)},
+		"arWithD.json":  &fstest.MapFile{Data: []byte(`{"type": "ar", "ar": [0.5], "d": 1}`)},
+	}
+
+	for _, tc := range []struct {
+		modelID string
+		err     error
+	}{
+		{modelID: "", err: executer.ErrInvalidModel},
+		{modelID: "missing", err: fs.ErrNotExist},
+		{modelID: "unknown", err: executer.ErrUnknownModelType},
+		{modelID: "mismatch", err: executer.ErrInvalidModel},
+		{modelID: "twoOuts", err: executer.ErrInvalidModel},
+		{modelID: "alpha", err: executer.ErrInvalidModel},
+		{modelID: "arWithD", err: executer.ErrInvalidModel},
+	} {
+		//act
+		_, err := executer.NewNative(models, executer.OutputCandlestick, &job.UserParams{job.ModelID: tc.modelID})
+
+		//assert
+		suite.ErrorIs(err, tc.err, tc.modelID)
+	}
+}
+
+func TestNative(t *testing.T) {
+	suite.Run(t, new(NativeTestSuite))
+}
@@ -0,0 +1,110 @@
+package executer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+)
+
+// ModelParamPrefix is the prefix of the params sent to the inference service as the parameters of the inference requests.
+// ModelParamPrefix+{key} holds the value encoded by EncodeModelParam.
+const ModelParamPrefix = "model."
+
+var ErrInvalidModelParam = errors.New("executer: invalid model param")
+
+// EncodeModelParam encodes a parameter of the model into the value of job.UserParams.
+// The value is a string, a bool, an integer or a floating point number,
+// and it keeps its type when it is decoded, even if a floating point number has no fractional part.
+func EncodeModelParam(v any) (string, error) {
+	switch v := v.(type) {
+	case string:
+		b, err := json.Marshal(v)
+		return string(b), err
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.FormatInt(int64(v), 10), nil
+	case int32:
+		return strconv.FormatInt(int64(v), 10), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case float32:
+		return encodeFloat(float64(v))
+	case float64:
+		return encodeFloat(v)
+	default:
+		return "", fmt.Errorf("%w: unsupported type %T", ErrInvalidModelParam, v)
+	}
+}
+
+func encodeFloat(v float64) (string, error) {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return "", fmt.Errorf("%w: %v is not a finite number", ErrInvalidModelParam, v)
+	}
+
+	s := strconv.FormatFloat(v, 'g', -1, 64)
+	// 소수부가 없는 실수도 정수로 해석되지 않도록 한다.
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s, nil
+}
+
+// parseModelParams decodes the params of ModelParamPrefix into the parameters of the inference requests.
+// Integers are decoded as int64 and floating point numbers as float64.
+// It returns nil if there is no model param.
+func parseModelParams(params *job.UserParams) (map[string]any, error) {
+	var res map[string]any
+	for k, v := range *params {
+		key, ok := strings.CutPrefix(k, ModelParamPrefix)
+		if !ok {
+			continue
+		}
+
+		val, err := decodeModelParam(v)
+		if err != nil {
+			return nil, fmt.Errorf("parse model param %q: %w", key, err)
+		}
+
+		if res == nil {
+			res = make(map[string]any)
+		}
+		res[key] = val
+	}
+	return res, nil
+}
+
+func decodeModelParam(s string) (any, error) {
+	d := json.NewDecoder(bytes.NewReader([]byte(s)))
+	d.UseNumber()
+
+	var v any
+	if err := d.Decode(&v); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidModelParam, err)
+	}
+	if d.More() {
+		return nil, fmt.Errorf("%w: %q is not a single value", ErrInvalidModelParam, s)
+	}
+
+	switch v := v.(type) {
+	case string, bool:
+		return v, nil
+	case json.Number:
+		if !strings.ContainsAny(v.String(), ".eE") {
+			if i, err := v.Int64(); err == nil {
+				return i, nil
+			}
+		}
+		return v.Float64()
+	default:
+		return nil, fmt.Errorf("%w: %q is not a string, a bool or a number", ErrInvalidModelParam, s)
+	}
+}
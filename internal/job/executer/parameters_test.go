@@ -0,0 +1,27 @@
+package executer_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Goboolean/core-system.worker/internal/job/executer"
+	"github.com/stretchr/testify/suite"
+)
+
+type ParametersTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ParametersTestSuite) TestEncodeModelParam_ShouldReturnError_WhenValueIsNotScalar() {
+	for _, v := range []any{[]any{1}, map[string]any{"a": 1}, nil, math.NaN()} {
+		//act
+		_, err := executer.EncodeModelParam(v)
+
+		//assert
+		suite.ErrorIs(err, executer.ErrInvalidModelParam, "%v", v)
+	}
+}
+
+func TestParameters(t *testing.T) {
+	suite.Run(t, new(ParametersTestSuite))
+}
@@ -2,4 +2,8 @@
 
 package executer
 
-var providerRepo = map[Spec]jobProvider{}
+var providerRepo = map[Spec]jobProvider{
+	{OutputType: OutputCandlestick, Runtime: RuntimeNative}: initializeNative(OutputCandlestick),
+	{OutputType: OutputValueList, Runtime: RuntimeNative}:   initializeNative(OutputValueList),
+	{OutputType: OutputProbeDist, Runtime: RuntimeNative}:   initializeNative(OutputProbeDist),
+}
@@ -5,8 +5,13 @@ package executer
 import "github.com/Goboolean/core-system.worker/internal/job"
 
 var providerRepo = map[Spec]jobProvider{
-	{OutputType: "candlestick"}: initializeMock,
-	{OutputType: "stub"}: func(p *job.UserParams) (ModelExecutor, error) {
+	{OutputType: OutputCandlestick, Runtime: RuntimeKServe}: initializeKServe(OutputCandlestick),
+	{OutputType: OutputValueList, Runtime: RuntimeKServe}:   initializeKServe(OutputValueList),
+	{OutputType: OutputProbeDist, Runtime: RuntimeKServe}:   initializeKServe(OutputProbeDist),
+	{OutputType: "stub", Runtime: RuntimeKServe}: func(p *job.UserParams) (ModelExecutor, error) {
 		return NewStub(p)
 	},
+	{OutputType: OutputCandlestick, Runtime: RuntimeNative}: initializeNative(OutputCandlestick),
+	{OutputType: OutputValueList, Runtime: RuntimeNative}:   initializeNative(OutputValueList),
+	{OutputType: OutputProbeDist, Runtime: RuntimeNative}:   initializeNative(OutputProbeDist),
 }
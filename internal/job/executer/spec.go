@@ -1,6 +1,21 @@
 package executer
 
+const (
+	// RuntimeKServe executes a model through an inference service of KServe.
+	RuntimeKServe = "kserve"
+	// RuntimeNative executes a model in process without any inference service.
+	RuntimeNative = "native"
+)
+
 // Spec is the trait that distinguishes the executers.
 type Spec struct {
 	OutputType string
+	// Runtime is where the model is executed. RuntimeKServe|RuntimeNative
+	Runtime string
 }
+
+const (
+	OutputCandlestick = "candlestick"
+	OutputValueList   = "valueList"
+	OutputProbeDist   = "probeDist"
+)
@@ -1,9 +1,13 @@
 package executer
 
 import (
+	"fmt"
+	"reflect"
+
 	"github.com/Goboolean/core-system.worker/internal/job"
 	"github.com/Goboolean/core-system.worker/internal/model"
 	"github.com/Goboolean/core-system.worker/internal/util"
+	"github.com/Goboolean/core-system.worker/internal/util/chanutil"
 )
 
 // Stub passes the fake model output to output channel
@@ -28,6 +32,9 @@ func (m *Stub) Execute() error {
 
 	defer m.stop.NotifyStop()
 	defer close(m.out)
+	defer func() {
+		go chanutil.DummyChannelConsumer(m.in)
+	}()
 
 	for {
 		select {
@@ -39,7 +46,15 @@ func (m *Stub) Execute() error {
 				return nil
 			}
 
-			data := input.Data.(*model.StockAggregate)
+			if model.IsWatermark(input) {
+				m.out <- input
+				continue
+			}
+
+			data, ok := model.AsStockAggregate(input.Data)
+			if !ok {
+				return fmt.Errorf("model exec job: type mismatch. expected *model.StockAggregate, got %s %w", reflect.TypeOf(input.Data), job.ErrTypeMismatch)
+			}
 
 			m.out <- model.Packet{
 				Time: input.Time,
@@ -1,6 +1,7 @@
 package executer_test
 
 import (
+	"testing"
 	"time"
 
 	"github.com/Goboolean/core-system.worker/internal/job"
@@ -58,3 +59,38 @@ func (suite *StubTestSuite) TestStub_ShouldReturnNDataItems_WhenNItemsAreStubbed
 	suite.NoError(err)
 	suite.Len(res, num)
 }
+
+func (suite *StubTestSuite) TestStub_ShouldReturnError_WhenInputIsNotStockAggregate() {
+	//arrange
+	inChan := make(job.DataChan, 2)
+	inChan <- model.Packet{Time: time.Unix(0, 0), Data: model.ValueList{1}}
+	inChan <- model.Packet{Time: time.Unix(1, 0), Data: &model.StockAggregate{}}
+	close(inChan)
+
+	stub, err := executer.NewStub(&job.UserParams{})
+	suite.Require().NoError(err)
+
+	stub.SetInput(inChan)
+
+	//act
+	res := make([]model.Packet, 0)
+	g := errgroup.Group{}
+
+	g.Go(func() error {
+		for v := range stub.Output() {
+			res = append(res, v)
+		}
+		return nil
+	})
+
+	g.Go(stub.Execute)
+	err = g.Wait()
+
+	//assert
+	suite.ErrorIs(err, job.ErrTypeMismatch)
+	suite.Empty(res)
+}
+
+func TestStub(t *testing.T) {
+	suite.Run(t, new(StubTestSuite))
+}
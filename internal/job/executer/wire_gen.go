@@ -7,20 +7,25 @@
 package executer
 
 import (
+	"fmt"
 	"github.com/Goboolean/common/pkg/resolver"
+	"github.com/Goboolean/core-system.worker/internal/infrastructure/inferencecache"
 	"github.com/Goboolean/core-system.worker/internal/infrastructure/kserve"
 	"github.com/Goboolean/core-system.worker/internal/job"
+	"os"
+	"strconv"
+	"time"
 )
 
 // Injectors from wire_setup.go:
 
-func initializeMock(p *job.UserParams) (ModelExecutor, error) {
-	executerKServeConfig := provideKServeConfig()
-	clientImpl, err := provideKServe(executerKServeConfig)
+func initializeMock(d Decoder, p *job.UserParams) (*Mock, error) {
+	executerKServeConfig := provideKServeConfig(p)
+	client, err := provideKServe(executerKServeConfig, p)
 	if err != nil {
 		return nil, err
 	}
-	mock, err := NewMock(clientImpl, p)
+	mock, err := NewMock(client, d, p)
 	if err != nil {
 		return nil, err
 	}
@@ -31,11 +36,61 @@ func initializeMock(p *job.UserParams) (ModelExecutor, error) {
 
 type kServeConfig resolver.ConfigMap
 
-func provideKServeConfig() kServeConfig {
-	return kServeConfig(resolver.ConfigMap{})
+// provideKServeConfig requests inferences of the model of job.ModelID to the inference service at KSERVE_HOST.
+// KSERVE_PROTOCOL selects the protocol of the requests, "rest" or "grpc".
+// KSERVE_MODEL_VERSION pins the version of the model. The latest version is used if it is empty.
+func provideKServeConfig(p *job.UserParams) kServeConfig {
+	return kServeConfig(resolver.ConfigMap{
+		"host":         os.Getenv("KSERVE_HOST"),
+		"protocol":     os.Getenv("KSERVE_PROTOCOL"),
+		"modelID":      (*p)[job.ModelID],
+		"modelVersion": os.Getenv("KSERVE_MODEL_VERSION"),
+	})
 }
 
-func provideKServe(c kServeConfig) (*kserve.ClientImpl, error) {
+// provideKServe puts a local cache of the inference responses in front of the client
+// if INFERENCE_CACHE_DIR is set and the user does not bypass the cache.
+// INFERENCE_CACHE_TTL and INFERENCE_CACHE_MAX_BYTES limit the age and the total size of the cached responses.
+// The cached responses of the other versions of the model are removed when the version of the model changes.
+func provideKServe(c kServeConfig, p *job.UserParams) (kserve.Client, error) {
 	in := resolver.ConfigMap(c)
-	return kserve.NewClient(&in)
+	client, err := kserve.New(&in)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := os.Getenv("INFERENCE_CACHE_DIR")
+	if dir == "" || (*p)[job.BypassInferenceCache] == "true" {
+		return client, nil
+	}
+
+	opts := inferencecache.Opts{Dir: dir}
+	if s := os.Getenv("INFERENCE_CACHE_TTL"); s != "" {
+		val, err := time.ParseDuration(s)
+		if err != nil {
+			return nil, fmt.Errorf("provide kserve: %w", err)
+		}
+		opts.TTL = val
+	}
+
+	if s := os.Getenv("INFERENCE_CACHE_MAX_BYTES"); s != "" {
+		val, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("provide kserve: %w", err)
+		}
+		opts.MaxBytes = val
+	}
+
+	cache, err := inferencecache.New(client, c["modelID"].(string), c["modelVersion"].(string), opts)
+	if err != nil {
+		return nil, fmt.Errorf("provide kserve: %w", err)
+	}
+
+	// 모델 버전이 바뀌면 이전 버전의 응답은 다시 사용되지 않으므로 지운다.
+	if cache.VersionChanged() {
+		if err := cache.Invalidate(); err != nil {
+			return nil, fmt.Errorf("provide kserve: %w", err)
+		}
+	}
+	return cache, nil
 }
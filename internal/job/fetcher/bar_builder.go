@@ -0,0 +1,133 @@
+package fetcher
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Goboolean/core-system.worker/internal/model"
+)
+
+// Types of bar built from trade prints.
+const (
+	// BarTime closes a bar at the end of each period of the time frame.
+	BarTime = "time"
+	// BarTick closes a bar when the number of trades reaches the threshold.
+	BarTick = "tick"
+	// BarVolume closes a bar when the traded size reaches the threshold.
+	BarVolume = "volume"
+	// BarDollar closes a bar when the traded value (price * size) reaches the threshold.
+	BarDollar = "dollar"
+)
+
+var (
+	ErrUnknownBarType      = errors.New("fetch: unknown bar type")
+	ErrInvalidBarThreshold = errors.New("fetch: bar threshold must be positive")
+)
+
+// barBuilder builds bars from a stream of trade prints.
+//
+// A trade is never split across bars, so a volume or dollar bar may exceed the threshold by its last trade.
+// Time bars are aligned to the Unix epoch and periods without any trade produce no bar.
+type barBuilder struct {
+	barType   string
+	timeFrame int64
+	threshold float64
+
+	bar    *model.StockAggregate
+	period int64
+	acc    float64
+}
+
+func newBarBuilder(barType string, timeFrame time.Duration, threshold float64) (*barBuilder, error) {
+	switch barType {
+	case BarTime:
+		if timeFrame < time.Second {
+			return nil, ErrInvalidTimeFrame
+		}
+	case BarTick, BarVolume, BarDollar:
+		if threshold <= 0 {
+			return nil, ErrInvalidBarThreshold
+		}
+	default:
+		return nil, ErrUnknownBarType
+	}
+
+	return &barBuilder{
+		barType:   barType,
+		timeFrame: int64(timeFrame / time.Second),
+		threshold: threshold,
+	}, nil
+}
+
+// add adds the trade to the current bar and returns the bar closed by the trade, if any.
+func (b *barBuilder) add(t *model.Trade) *model.StockAggregate {
+	if b.barType == BarTime {
+		return b.addTime(t)
+	}
+
+	b.merge(t, t.Time.Unix())
+	b.bar.ClosedTime = t.Time.Unix()
+
+	switch b.barType {
+	case BarTick:
+		b.acc++
+	case BarVolume:
+		b.acc += float64(t.Size)
+	case BarDollar:
+		b.acc += float64(t.Price) * float64(t.Size)
+	}
+
+	if b.acc < b.threshold {
+		return nil
+	}
+
+	closed := b.bar
+	b.bar = nil
+	b.acc = 0
+	return closed
+}
+
+// addTime adds the trade to the bar of its period.
+// The bar of the previous period is closed when the first trade of a later period arrives.
+func (b *barBuilder) addTime(t *model.Trade) *model.StockAggregate {
+	period := floorDiv(t.Time.Unix(), b.timeFrame)
+
+	var closed *model.StockAggregate
+	if b.bar != nil && period != b.period {
+		closed = b.bar
+		b.bar = nil
+	}
+
+	b.period = period
+	b.merge(t, period*b.timeFrame)
+	b.bar.ClosedTime = (period + 1) * b.timeFrame
+	return closed
+}
+
+func (b *barBuilder) merge(t *model.Trade, openTime int64) {
+	if b.bar == nil {
+		b.bar = &model.StockAggregate{
+			OpenTime: openTime,
+			Open:     t.Price,
+			High:     t.Price,
+			Low:      t.Price,
+		}
+	}
+
+	b.bar.Close = t.Price
+	b.bar.High = max(b.bar.High, t.Price)
+	b.bar.Low = min(b.bar.Low, t.Price)
+	b.bar.Volume += t.Size
+}
+
+// flush returns the current bar if it is complete at end, that is, no more trade can be added to it.
+// Only a time bar whose period has ended can be complete.
+func (b *barBuilder) flush(end time.Time) *model.StockAggregate {
+	if b.bar == nil || b.barType != BarTime || b.bar.ClosedTime > end.Unix() {
+		return nil
+	}
+
+	closed := b.bar
+	b.bar = nil
+	return closed
+}
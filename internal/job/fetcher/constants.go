@@ -8,3 +8,13 @@ const (
 const (
 	DefaultMaxRandomDelayMilliseconds = 10
 )
+
+const (
+	DefaultReplaySpeed = "1x"
+)
+
+const (
+	DefaultSyntheticProcess  = ProcessGBM
+	DefaultNumOfGeneration   = 1000
+	DefaultSyntheticSubsteps = 10
+)
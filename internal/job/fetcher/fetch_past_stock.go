@@ -43,6 +43,8 @@ func NewPastStock(stockCursor *StockTradeCursor, parmas *job.UserParams) (*PastS
 
 	instance := &PastStock{
 		timeFrame: DefaultTimeSlice,
+		startTime: time.Unix(0, 0),
+		endTime:   time.Unix(0, 0),
 		cursor:    stockCursor,
 		stop:      util.NewStopNotifier(),
 		out:       make(job.DataChan),
@@ -0,0 +1,196 @@
+package fetcher
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/Goboolean/core-system.worker/internal/util/chanutil"
+	"golang.org/x/sync/errgroup"
+)
+
+var ErrInvalidTimeFrame = errors.New("fetch: invalid time frame")
+
+// MultiTimeFrame wraps a fetcher of the primary time frame
+// and delivers each primary bar together with the bars of additional, longer time frames.
+//
+// The bars of additional time frames are aggregated from the primary bars,
+// and a bar becomes visible only after the primary bar closing its period is received.
+// Therefore no bar of an additional time frame is visible before it closes, which prevents look-ahead bias.
+// Periods are aligned to the Unix epoch, so a daily bar closes at 00:00 UTC.
+// A period that the primary stream starts in the middle of is never delivered because it is incomplete.
+type MultiTimeFrame struct {
+	fetcher   Fetcher
+	timeFrame time.Duration
+
+	frames []*timeFrameAggregator
+
+	out job.DataChan `type:"*MultiTimeFrameAggregate"` //Job은 자신의 Output 채널에 대해 소유권을 가진다.
+}
+
+// timeFrameAggregator aggregates primary bars into the bars of a longer time frame.
+type timeFrameAggregator struct {
+	name      string
+	timeFrame int64
+
+	// building is the bar of the current period. It is nil if the period is incomplete.
+	building *model.StockAggregate
+	period   int64
+	started  bool
+
+	latest *model.StockAggregate
+}
+
+// NewMultiTimeFrame creates new instance of MultiTimeFrame wrapping f.
+//
+// Parameter List:
+// job.TimeFrame: The time frame of the bars fetched by f.
+// job.AdditionalTimeFrames: Comma separated time frames to deliver alongside. Example: "1h,24h".
+// Each of them must be a multiple of job.TimeFrame.
+func NewMultiTimeFrame(f Fetcher, params *job.UserParams) (*MultiTimeFrame, error) {
+	//여기에 기본값 입력 아웃풋 채널은 job이 소유권을 가져야 한다.
+	instance := &MultiTimeFrame{
+		fetcher: f,
+		frames:  make([]*timeFrameAggregator, 0),
+		out:     make(job.DataChan),
+	}
+
+	timeFrame := DefaultTimeSlice
+	if !params.IsKeyNilOrEmpty(job.TimeFrame) {
+		timeFrame = (*params)[job.TimeFrame]
+	}
+
+	var err error
+	instance.timeFrame, err = time.ParseDuration(timeFrame)
+	if err != nil {
+		return nil, fmt.Errorf("create multi time frame fetch job: %w", err)
+	}
+
+	if params.IsKeyNilOrEmpty(job.AdditionalTimeFrames) {
+		return instance, nil
+	}
+
+	for _, name := range strings.Split((*params)[job.AdditionalTimeFrames], ",") {
+		name = strings.TrimSpace(name)
+		d, err := time.ParseDuration(name)
+		if err != nil {
+			return nil, fmt.Errorf("create multi time frame fetch job: %w", err)
+		}
+
+		if d <= instance.timeFrame || d%instance.timeFrame != 0 || d%time.Second != 0 {
+			return nil, fmt.Errorf("create multi time frame fetch job: %w: %s is not a multiple of %s", ErrInvalidTimeFrame, name, timeFrame)
+		}
+
+		instance.frames = append(instance.frames, &timeFrameAggregator{
+			name:      name,
+			timeFrame: int64(d / time.Second),
+		})
+	}
+
+	return instance, nil
+}
+
+// Execute runs the wrapped fetcher and attaches the bars of additional time frames to its output.
+//
+// If the Job fails to perform its task, Execute returns an error.
+// If the Job completes successfully, it returns nil.
+// DO NOT CALL Execute() TWICE. IT MUST BE PANIC
+func (m *MultiTimeFrame) Execute() error {
+	defer close(m.out)
+
+	g := errgroup.Group{}
+	g.Go(m.fetcher.Execute)
+	g.Go(func() error {
+		defer func() {
+			go chanutil.DummyChannelConsumer(m.fetcher.Output())
+		}()
+
+		for p := range m.fetcher.Output() {
+			data, ok := p.Data.(*model.StockAggregate)
+			if !ok {
+				m.fetcher.NotifyStop()
+				return fmt.Errorf("multi time frame fetch job: type mismatch. expected *model.StockAggregate, got %s %w", reflect.TypeOf(p.Data), job.ErrTypeMismatch)
+			}
+
+			res := &model.MultiTimeFrameAggregate{
+				Primary:    data,
+				TimeFrames: make(map[string]*model.StockAggregate, len(m.frames)),
+			}
+
+			for _, e := range m.frames {
+				e.add(data)
+				res.TimeFrames[e.name] = e.latest
+			}
+
+			m.out <- model.Packet{
+				Time: p.Time,
+				Data: res,
+			}
+		}
+		return nil
+	})
+
+	return g.Wait()
+}
+
+// add aggregates e into the bar of its period
+// and updates the latest bar if e closes the period.
+func (a *timeFrameAggregator) add(e *model.StockAggregate) {
+	period := floorDiv(e.OpenTime, a.timeFrame)
+
+	if !a.started || period != a.period {
+		if a.building != nil {
+			// The period is closed without the primary bar closing it because of missing data.
+			a.latest = a.building
+		}
+
+		a.started = true
+		a.period = period
+		a.building = nil
+
+		// Only the period starting with this bar is complete.
+		if e.OpenTime == period*a.timeFrame {
+			a.building = &model.StockAggregate{
+				OpenTime:   period * a.timeFrame,
+				ClosedTime: (period + 1) * a.timeFrame,
+				Open:       e.Open,
+				High:       e.High,
+				Low:        e.Low,
+			}
+		}
+	}
+
+	if a.building == nil {
+		return
+	}
+
+	a.building.Close = e.Close
+	a.building.High = max(a.building.High, e.High)
+	a.building.Low = min(a.building.Low, e.Low)
+	a.building.Volume += e.Volume
+
+	if e.ClosedTime >= a.building.ClosedTime {
+		a.latest = a.building
+		a.building = nil
+	}
+}
+
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+func (m *MultiTimeFrame) Output() job.DataChan {
+	return m.out
+}
+
+func (m *MultiTimeFrame) NotifyStop() {
+	m.fetcher.NotifyStop()
+}
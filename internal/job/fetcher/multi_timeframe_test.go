@@ -0,0 +1,111 @@
+package fetcher_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/job/fetcher"
+	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/sync/errgroup"
+)
+
+type MultiTimeFrameTestSuite struct {
+	suite.Suite
+}
+
+func (suite *MultiTimeFrameTestSuite) fetch(start int64, num int, additional string) []*model.MultiTimeFrameAggregate {
+	params := &job.UserParams{
+		job.Seed:                 "3",
+		job.NumOfGeneration:      fmt.Sprint(num),
+		job.StartDate:            fmt.Sprint(start),
+		job.TimeFrame:            "1m",
+		job.AdditionalTimeFrames: additional,
+	}
+	synthetic, err := fetcher.NewSynthetic(params)
+	suite.Require().NoError(err)
+	f, err := fetcher.NewMultiTimeFrame(synthetic, params)
+	suite.Require().NoError(err)
+
+	res := make([]*model.MultiTimeFrameAggregate, 0)
+	g := errgroup.Group{}
+	g.Go(func() error {
+		for v := range f.Output() {
+			res = append(res, v.Data.(*model.MultiTimeFrameAggregate))
+		}
+		return nil
+	})
+	g.Go(f.Execute)
+	suite.Require().NoError(g.Wait())
+
+	return res
+}
+
+func (suite *MultiTimeFrameTestSuite) TestMultiTimeFrame_ShouldNotExposeBar_BeforeItCloses() {
+	//arrange
+	// 1720396800은 5분 단위로 나누어 떨어지므로, 2분 뒤에 시작하면 첫 번째 5분 봉은 불완전하다.
+	start := int64(1720396800 + 2*60)
+
+	//act
+	res := suite.fetch(start, 30, "5m")
+
+	//assert
+	suite.Require().Len(res, 30)
+	for i, e := range res {
+		higher := e.TimeFrames["5m"]
+		if e.Primary.ClosedTime < 1720396800+10*60 {
+			suite.Nil(higher, i)
+			continue
+		}
+
+		suite.Require().NotNil(higher, i)
+		suite.LessOrEqual(higher.ClosedTime, e.Primary.ClosedTime, i)
+		suite.Equal(e.Primary.ClosedTime-e.Primary.ClosedTime%300, higher.ClosedTime, i)
+	}
+}
+
+func (suite *MultiTimeFrameTestSuite) TestMultiTimeFrame_ShouldAggregatePrimaryBars_WhenPeriodCloses() {
+	//arrange
+	start := int64(1720396800)
+
+	//act
+	res := suite.fetch(start, 10, "5m")
+
+	//assert
+	bars := res[:5]
+	exp := &model.StockAggregate{
+		OpenTime:   start,
+		ClosedTime: start + 5*60,
+		Open:       bars[0].Primary.Open,
+		Close:      bars[4].Primary.Close,
+		High:       bars[0].Primary.High,
+		Low:        bars[0].Primary.Low,
+	}
+	for _, e := range bars {
+		exp.High = max(exp.High, e.Primary.High)
+		exp.Low = min(exp.Low, e.Primary.Low)
+		exp.Volume += e.Primary.Volume
+	}
+
+	suite.Equal(exp, res[4].TimeFrames["5m"])
+	suite.Equal(exp, res[8].TimeFrames["5m"])
+	suite.NotEqual(exp, res[9].TimeFrames["5m"])
+}
+
+func (suite *MultiTimeFrameTestSuite) TestNewMultiTimeFrame_ShouldReturnError_WhenTimeFrameIsNotMultipleOfPrimary() {
+	for _, additional := range []string{"90s", "1m", "30s", "abc"} {
+		//act
+		_, err := fetcher.NewMultiTimeFrame(nil, &job.UserParams{
+			job.TimeFrame:            "1m",
+			job.AdditionalTimeFrames: additional,
+		})
+
+		//assert
+		suite.Error(err, additional)
+	}
+}
+
+func TestMultiTimeFrame(t *testing.T) {
+	suite.Run(t, new(MultiTimeFrameTestSuite))
+}
@@ -0,0 +1,52 @@
+package fetcher
+
+import (
+	"context"
+	"time"
+
+	"github.com/cenkalti/backoff"
+)
+
+// pageCursor sequentially provides time-ordered data of a data source,
+// retrieving up to DefaultLimit items after the last retrieved one at a time.
+type pageCursor[T any] struct {
+	// fetch fetches at most limit items whose time is after start.
+	fetch  func(ctx context.Context, start time.Time, limit int) ([]T, error)
+	timeOf func(T) time.Time
+
+	current   time.Time
+	exhausted bool
+
+	buf []T
+	idx int
+}
+
+// next returns the next item. If there is no more item to retrieve, it returns the zero value of T and nil.
+func (c *pageCursor[T]) next(ctx context.Context) (T, error) {
+	var zero T
+
+	for c.idx >= len(c.buf) {
+		if c.exhausted {
+			return zero, nil
+		}
+
+		b := backoff.WithContext(backoff.NewExponentialBackOff(), ctx)
+		if err := backoff.Retry(func() error {
+			var err error
+			c.buf, err = c.fetch(ctx, c.current, DefaultLimit)
+			return err
+		}, b); err != nil {
+			return zero, err
+		}
+
+		c.idx = 0
+		c.exhausted = len(c.buf) < DefaultLimit
+		if len(c.buf) > 0 {
+			c.current = c.timeOf(c.buf[len(c.buf)-1])
+		}
+	}
+
+	e := c.buf[c.idx]
+	c.idx++
+	return e, nil
+}
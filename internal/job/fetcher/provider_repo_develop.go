@@ -5,9 +5,17 @@ package fetcher
 import "github.com/Goboolean/core-system.worker/internal/job"
 
 var providerRepo = map[Spec]jobProvider{
-	{Task: "backTest", ProductType: "stock"}: InitializePastStock,
+	{Task: "backTest", ProductType: "stock"}:                    InitializePastStock,
+	{Task: "realtimeTrade", ProductType: "stock", Replay: true}: InitializeReplayStock,
+	{Task: "backTest", ProductType: "quote"}:                    InitializePastQuote,
+	{Task: "realtimeTrade", ProductType: "quote", Replay: true}: InitializeReplayQuote,
+	{Task: "backTest", ProductType: "tick"}:                     InitializeTickBar,
+	{Task: "backTest", ProductType: "synthetic"}: func(p *job.UserParams) (Fetcher, error) {
+		return NewSynthetic(p)
+	},
 	{Task: "backTest", ProductType: "stockStub"}: func(p *job.UserParams) (Fetcher, error) {
-		(*p)["numOfGeneration"] = "100"
-		return NewStockStub(p)
+		(*p)[job.NumOfGeneration] = "100"
+		(*p)[job.Seed] = "0"
+		return NewSynthetic(p)
 	},
 }
@@ -0,0 +1,253 @@
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/Goboolean/core-system.worker/internal/util"
+)
+
+// QuoteSource is a data source containing past top-of-book quotes.
+// *influx.QuoteReader implements QuoteSource.
+type QuoteSource interface {
+	// FetchLimitedQuoteAfter fetches at most limit quotes of the product whose time is after start.
+	FetchLimitedQuoteAfter(ctx context.Context, productID string, start time.Time, limit int) ([]*model.Quote, error)
+	Close() error
+}
+
+// newQuoteCursor returns a cursor of the quotes of the product whose time is at or after start.
+func newQuoteCursor(source QuoteSource, productID string, start time.Time) *pageCursor[*model.Quote] {
+	return &pageCursor[*model.Quote]{
+		// source는 start 이후의 quote만 가져오므로 start 시각의 quote도 포함하도록 1ns 앞에서 시작한다.
+		// InfluxDB에 저장된 시각의 최소 단위는 1ns이다.
+		current: start.Add(-time.Nanosecond),
+		fetch: func(ctx context.Context, start time.Time, limit int) ([]*model.Quote, error) {
+			return source.FetchLimitedQuoteAfter(ctx, productID, start, limit)
+		},
+		timeOf: func(e *model.Quote) time.Time { return e.Time },
+	}
+}
+
+// rangeParams is the common parameters of the fetchers reading a range of past data.
+type rangeParams struct {
+	productID string
+	startTime time.Time
+	endTime   time.Time
+}
+
+// parseRangeParams parses the range of past data. An omitted date is the Unix epoch.
+func parseRangeParams(params *job.UserParams) (rangeParams, error) {
+	res := rangeParams{
+		startTime: time.Unix(0, 0),
+		endTime:   time.Unix(0, 0),
+	}
+
+	if !params.IsKeyNilOrEmpty(job.ProductID) {
+		res.productID = (*params)[job.ProductID]
+	}
+
+	if !params.IsKeyNilOrEmpty(job.StartDate) {
+		val, err := strconv.ParseInt((*params)[job.StartDate], 10, 64)
+		if err != nil {
+			return res, err
+		}
+
+		res.startTime = time.Unix(val, 0)
+	}
+
+	if !params.IsKeyNilOrEmpty(job.EndDate) {
+		val, err := strconv.ParseInt((*params)[job.EndDate], 10, 64)
+		if err != nil {
+			return res, err
+		}
+
+		res.endTime = time.Unix(val, 0)
+	}
+
+	return res, nil
+}
+
+// PastQuote retrieves past top-of-book quotes sequentially within the given range
+// and sends each of them to the output channel as a *model.Quote.
+type PastQuote struct {
+	rangeParams
+	source QuoteSource
+
+	out job.DataChan `type:"*Quote"` //Job은 자신의 Output 채널에 대해 소유권을 가진다.
+
+	stop *util.StopNotifier
+}
+
+// NewPastQuote creates new instance of PastQuote
+//
+// Parameter List:
+// job.ProductID: The unique identifier of the product in the format {type}.{ticker}.{locale}.
+// job.StartDate: The start date for data collection.
+// job.EndDate: The end date for data collection.
+func NewPastQuote(source QuoteSource, params *job.UserParams) (*PastQuote, error) {
+	p, err := parseRangeParams(params)
+	if err != nil {
+		return nil, fmt.Errorf("create past quote fetch job: %w", err)
+	}
+
+	return &PastQuote{
+		rangeParams: p,
+		source:      source,
+		out:         make(job.DataChan),
+		stop:        util.NewStopNotifier(),
+	}, nil
+}
+
+// Execute starts to fetch past quotes.
+//
+// If the Job fails to perform its task, Execute returns an error.
+// If the Job completes successfully, it returns nil.
+// DO NOT CALL Execute() TWICE. IT MUST BE PANIC
+func (pq *PastQuote) Execute() error {
+	defer close(pq.out)
+	defer pq.stop.NotifyStop()
+	defer pq.source.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		<-pq.stop.Done()
+		cancel()
+	}()
+
+	cursor := newQuoteCursor(pq.source, pq.productID, pq.startTime)
+	for {
+		e, err := cursor.next(ctx)
+
+		select {
+		case <-pq.stop.Done():
+			return nil
+		default:
+		}
+
+		if err != nil {
+			return fmt.Errorf("execute past quote fetch job:fail to fetch quote %w", err)
+		}
+		if e == nil || e.Time.After(pq.endTime) {
+			return nil
+		}
+
+		pq.out <- model.Packet{
+			Time: e.Time,
+			Data: e,
+		}
+	}
+}
+
+func (pq *PastQuote) Output() job.DataChan {
+	return pq.out
+}
+
+func (pq *PastQuote) NotifyStop() {
+	pq.stop.NotifyStop()
+}
+
+// ReplayQuote retrieves past top-of-book quotes like PastQuote,
+// but emits each of them at the pace of wall-clock time like ReplayStock.
+type ReplayQuote struct {
+	rangeParams
+	source QuoteSource
+
+	// speed is the multiplier applied to the pace of the replay.
+	// Zero means that the data is replayed without any delay.
+	speed float64
+
+	out job.DataChan `type:"*Quote"` //Job은 자신의 Output 채널에 대해 소유권을 가진다.
+
+	stop *util.StopNotifier
+}
+
+// NewReplayQuote creates new instance of ReplayQuote
+//
+// Parameter List:
+// job.ProductID: The unique identifier of the product in the format {type}.{ticker}.{locale}.
+// job.StartDate: The start date of the data to replay.
+// job.EndDate: The end date of the data to replay.
+// job.ReplaySpeed: The speed multiplier of the replay. Examples: "1x", "10x", "0.5x", "max".
+func NewReplayQuote(source QuoteSource, params *job.UserParams) (*ReplayQuote, error) {
+	p, err := parseRangeParams(params)
+	if err != nil {
+		return nil, fmt.Errorf("create replay quote fetch job: %w", err)
+	}
+
+	speed, _ := parseReplaySpeed(DefaultReplaySpeed)
+	if !params.IsKeyNilOrEmpty(job.ReplaySpeed) {
+		speed, err = parseReplaySpeed((*params)[job.ReplaySpeed])
+		if err != nil {
+			return nil, fmt.Errorf("create replay quote fetch job: %w", err)
+		}
+	}
+
+	return &ReplayQuote{
+		rangeParams: p,
+		source:      source,
+		speed:       speed,
+		out:         make(job.DataChan),
+		stop:        util.NewStopNotifier(),
+	}, nil
+}
+
+// Execute starts to replay past quotes.
+//
+// If the Job fails to perform its task, Execute returns an error.
+// If the Job completes successfully, it returns nil.
+// DO NOT CALL Execute() TWICE. IT MUST BE PANIC
+func (rq *ReplayQuote) Execute() error {
+	defer close(rq.out)
+	defer rq.stop.NotifyStop()
+	defer rq.source.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		<-rq.stop.Done()
+		cancel()
+	}()
+
+	pacer := replayPacer{speed: rq.speed}
+	cursor := newQuoteCursor(rq.source, rq.productID, rq.startTime)
+	for {
+		e, err := cursor.next(ctx)
+
+		select {
+		case <-rq.stop.Done():
+			return nil
+		default:
+		}
+
+		if err != nil {
+			return fmt.Errorf("execute replay quote fetch job:fail to fetch quote %w", err)
+		}
+		if e == nil || e.Time.After(rq.endTime) {
+			return nil
+		}
+
+		if !pacer.wait(e.Time, rq.stop.Done()) {
+			return nil
+		}
+
+		rq.out <- model.Packet{
+			Time: e.Time,
+			Data: e,
+		}
+	}
+}
+
+func (rq *ReplayQuote) Output() job.DataChan {
+	return rq.out
+}
+
+func (rq *ReplayQuote) NotifyStop() {
+	rq.stop.NotifyStop()
+}
@@ -0,0 +1,154 @@
+package fetcher_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/job/fetcher"
+	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/sync/errgroup"
+)
+
+// sliceQuoteSource serves quotes from memory.
+type sliceQuoteSource struct {
+	quotes []*model.Quote
+	closed bool
+}
+
+func (s *sliceQuoteSource) FetchLimitedQuoteAfter(ctx context.Context, productID string, start time.Time, limit int) ([]*model.Quote, error) {
+	res := make([]*model.Quote, 0, limit)
+	for _, e := range s.quotes {
+		if len(res) == limit {
+			break
+		}
+		if e.Time.After(start) {
+			res = append(res, e)
+		}
+	}
+	return res, nil
+}
+
+func (s *sliceQuoteSource) Close() error {
+	s.closed = true
+	return nil
+}
+
+func newSliceQuoteSource(start time.Time, num int, interval time.Duration) *sliceQuoteSource {
+	quotes := make([]*model.Quote, num)
+	for i := 0; i < num; i++ {
+		quotes[i] = &model.Quote{
+			Time:     start.Add(time.Duration(i+1) * interval),
+			BidPrice: float32(i),
+			AskPrice: float32(i + 1),
+		}
+	}
+	return &sliceQuoteSource{quotes: quotes}
+}
+
+type QuoteTestSuite struct {
+	suite.Suite
+}
+
+func (suite *QuoteTestSuite) collect(f fetcher.Fetcher) []*model.Quote {
+	res := make([]*model.Quote, 0)
+	g := errgroup.Group{}
+	g.Go(func() error {
+		for v := range f.Output() {
+			res = append(res, v.Data.(*model.Quote))
+		}
+		return nil
+	})
+	g.Go(f.Execute)
+	suite.Require().NoError(g.Wait())
+	return res
+}
+
+func (suite *QuoteTestSuite) TestPastQuote_ShouldFetchQuotesInRange_WhenQuotesSpanMultiplePages() {
+	//arrange
+	start := time.Unix(1720396800, 0)
+	source := newSliceQuoteSource(start, 3*fetcher.DefaultLimit, 100*time.Millisecond)
+	end := start.Add(time.Duration(2*fetcher.DefaultLimit) * 100 * time.Millisecond)
+
+	f, err := fetcher.NewPastQuote(source, &job.UserParams{
+		job.ProductID: "stock.aapl.usa",
+		job.StartDate: fmt.Sprint(start.Unix()),
+		job.EndDate:   fmt.Sprint(end.Unix()),
+	})
+	suite.Require().NoError(err)
+
+	//act
+	res := suite.collect(f)
+
+	//assert
+	suite.Equal(source.quotes[:2*fetcher.DefaultLimit], res)
+	suite.True(source.closed)
+}
+
+func (suite *QuoteTestSuite) TestReplayQuote_ShouldPaceQuotes_WhenSpeedIsGiven() {
+	//arrange
+	start := time.Unix(1720396800, 0)
+	source := newSliceQuoteSource(start, 5, 100*time.Millisecond)
+
+	f, err := fetcher.NewReplayQuote(source, &job.UserParams{
+		job.StartDate:   fmt.Sprint(start.Unix()),
+		job.EndDate:     fmt.Sprint(start.Add(time.Minute).Unix()),
+		job.ReplaySpeed: "2x",
+	})
+	suite.Require().NoError(err)
+
+	//act
+	began := time.Now()
+	res := suite.collect(f)
+	elapsed := time.Since(began)
+
+	//assert
+	suite.Equal(source.quotes, res)
+	// 첫 번째와 마지막 quote 사이 400ms를 2배속으로 재생한다.
+	suite.GreaterOrEqual(elapsed, 200*time.Millisecond)
+	suite.Less(elapsed, 400*time.Millisecond)
+}
+
+func (suite *QuoteTestSuite) TestQuote_ShouldIncludeQuoteAtStartDate() {
+	start := time.Unix(1720396800, 0)
+	constructors := map[string]func(fetcher.QuoteSource, *job.UserParams) (fetcher.Fetcher, error){
+		"past": func(s fetcher.QuoteSource, p *job.UserParams) (fetcher.Fetcher, error) {
+			return fetcher.NewPastQuote(s, p)
+		},
+		"replay": func(s fetcher.QuoteSource, p *job.UserParams) (fetcher.Fetcher, error) {
+			return fetcher.NewReplayQuote(s, p)
+		},
+	}
+
+	for name, newFetcher := range constructors {
+		suite.Run(name, func() {
+			//arrange
+			// StartDate 직전, StartDate, StartDate 직후의 quote
+			source := &sliceQuoteSource{quotes: []*model.Quote{
+				{Time: start.Add(-time.Nanosecond), BidPrice: 1},
+				{Time: start, BidPrice: 2},
+				{Time: start.Add(time.Nanosecond), BidPrice: 3},
+			}}
+
+			f, err := newFetcher(source, &job.UserParams{
+				job.StartDate:   fmt.Sprint(start.Unix()),
+				job.EndDate:     fmt.Sprint(start.Add(time.Second).Unix()),
+				job.ReplaySpeed: "max",
+			})
+			suite.Require().NoError(err)
+
+			//act
+			res := suite.collect(f)
+
+			//assert
+			suite.Equal(source.quotes[1:], res)
+		})
+	}
+}
+
+func TestQuote(t *testing.T) {
+	suite.Run(t, new(QuoteTestSuite))
+}
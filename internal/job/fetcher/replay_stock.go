@@ -0,0 +1,213 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/Goboolean/core-system.worker/internal/util"
+)
+
+var ErrInvalidReplaySpeed = errors.New("fetch: can't parse replay speed")
+
+// ReplayStock retrieves past stock trade data like PastStock,
+// but emits each piece at the pace of wall-clock time instead of as fast as possible.
+// It is used to exercise realtime-only behavior such as latency handling and timeouts without live markets.
+//
+// The interval between two packets is the interval between their ClosedTime divided by the replay speed.
+// With the speed "max", ReplayStock does not wait at all.
+type ReplayStock struct {
+	timeFrame string
+	startTime time.Time
+	endTime   time.Time
+	stockID   string
+
+	// speed is the multiplier applied to the pace of the replay.
+	// Zero means that the data is replayed without any delay.
+	speed float64
+
+	cursor TradeCursor
+
+	out job.DataChan `type:"*StockAggregate"` //Job은 자신의 Output 채널에 대해 소유권을 가진다.
+
+	stop *util.StopNotifier
+}
+
+// NewReplayStock creates new instance of ReplayStock
+//
+// Parameter List:
+// job.ProductID: The unique identifier of the product in the format {type}.{ticker}.{locale}.
+// job.StartDate: The start date of the data to replay.
+// job.EndDate: The end date of the data to replay.
+// job.TimeFrame: The interval at which Trade Data is stored.
+// job.ReplaySpeed: The speed multiplier of the replay. Examples: "1x", "10x", "0.5x", "max".
+func NewReplayStock(cursor TradeCursor, params *job.UserParams) (*ReplayStock, error) {
+	//여기에 기본값 입력 아웃풋 채널은 job이 소유권을 가져야 한다.
+	speed, _ := parseReplaySpeed(DefaultReplaySpeed)
+
+	instance := &ReplayStock{
+		timeFrame: DefaultTimeSlice,
+		startTime: time.Unix(0, 0),
+		endTime:   time.Unix(0, 0),
+		speed:     speed,
+		cursor:    cursor,
+		stop:      util.NewStopNotifier(),
+		out:       make(job.DataChan),
+	}
+
+	if !params.IsKeyNilOrEmpty(job.ProductID) {
+		instance.stockID = (*params)[job.ProductID]
+	}
+
+	if !params.IsKeyNilOrEmpty(job.StartDate) {
+		val, err := strconv.ParseInt((*params)[job.StartDate], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("create replay stock fetch job: %w", err)
+		}
+
+		instance.startTime = time.Unix(val, 0)
+	}
+
+	if !params.IsKeyNilOrEmpty(job.EndDate) {
+		val, err := strconv.ParseInt((*params)[job.EndDate], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("create replay stock fetch job: %w", err)
+		}
+
+		instance.endTime = time.Unix(val, 0)
+	}
+
+	if !params.IsKeyNilOrEmpty(job.TimeFrame) {
+		instance.timeFrame = (*params)[job.TimeFrame]
+	}
+
+	if !params.IsKeyNilOrEmpty(job.ReplaySpeed) {
+		val, err := parseReplaySpeed((*params)[job.ReplaySpeed])
+		if err != nil {
+			return nil, fmt.Errorf("create replay stock fetch job: %w", err)
+		}
+
+		instance.speed = val
+	}
+
+	return instance, nil
+}
+
+// parseReplaySpeed parses the speed multiplier in the format "{number}x" or "max".
+// It returns 0 for "max".
+func parseReplaySpeed(s string) (float64, error) {
+	if s == "max" {
+		return 0, nil
+	}
+
+	val, err := strconv.ParseFloat(strings.TrimSuffix(s, "x"), 64)
+	if err != nil || val <= 0 {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidReplaySpeed, s)
+	}
+
+	return val, nil
+}
+
+// replayPacer delays each piece of replayed data until the wall-clock time corresponding to its time.
+// The first piece is emitted immediately and the others follow at the interval from it divided by speed.
+type replayPacer struct {
+	// speed is the multiplier applied to the pace of the replay.
+	// Zero means that the data is replayed without any delay.
+	speed float64
+
+	startedAt time.Time
+	first     time.Time
+	started   bool
+}
+
+// wait blocks until the data of time t should be emitted.
+// It reports false if done is closed before then.
+func (p *replayPacer) wait(t time.Time, done <-chan struct{}) bool {
+	if !p.started {
+		p.startedAt = time.Now()
+		p.first = t
+		p.started = true
+	}
+
+	if p.speed <= 0 {
+		return true
+	}
+
+	elapsed := time.Duration(float64(t.Sub(p.first)) / p.speed)
+	timer := time.NewTimer(time.Until(p.startedAt.Add(elapsed)))
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// Execute starts to replay past trade data.
+//
+// If the Job fails to perform its task, Execute returns an error.
+// If the Job completes successfully, it returns nil.
+// DO NOT CALL Execute() TWICE. IT MUST BE PANIC
+func (rs *ReplayStock) Execute() error {
+
+	defer close(rs.out)
+	defer rs.stop.NotifyStop()
+	defer rs.cursor.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		<-rs.stop.Done()
+		cancel()
+	}()
+
+	if err := rs.cursor.ConfigureStockTradeCursor(rs.startTime, rs.stockID, rs.timeFrame); err != nil {
+		return fmt.Errorf("execute replay job:fail to configure trade cursor %w", err)
+	}
+
+	pacer := replayPacer{speed: rs.speed}
+	for {
+		e, err := rs.cursor.Next(ctx)
+
+		select {
+		case <-rs.stop.Done():
+			return nil
+		default:
+		}
+
+		if err != nil {
+			return fmt.Errorf("execute replay job:fail to fetch trade %w", err)
+		}
+		if e == nil {
+			return nil
+		}
+		if e.ClosedTime > rs.endTime.Unix() {
+			return nil
+		}
+
+		if !pacer.wait(time.Unix(e.ClosedTime, 0), rs.stop.Done()) {
+			return nil
+		}
+
+		rs.out <- model.Packet{
+			Time: time.Unix(e.ClosedTime, 0),
+			Data: e,
+		}
+	}
+}
+
+func (rs *ReplayStock) Output() job.DataChan {
+	return rs.out
+}
+
+func (rs *ReplayStock) NotifyStop() {
+	rs.stop.NotifyStop()
+}
@@ -0,0 +1,161 @@
+package fetcher_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/job/fetcher"
+	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/sync/errgroup"
+)
+
+// sliceCursor is a fetcher.TradeCursor that serves trade data from memory.
+type sliceCursor struct {
+	data []*model.StockAggregate
+	idx  int
+}
+
+func (c *sliceCursor) ConfigureStockTradeCursor(startTime time.Time, productID string, timeFrame string) error {
+	for c.idx < len(c.data) && c.data[c.idx].ClosedTime < startTime.Unix() {
+		c.idx++
+	}
+	return nil
+}
+
+func (c *sliceCursor) Next(ctx context.Context) (*model.StockAggregate, error) {
+	if c.idx >= len(c.data) {
+		return nil, nil
+	}
+	c.idx++
+	return c.data[c.idx-1], nil
+}
+
+func (c *sliceCursor) Close() error {
+	return nil
+}
+
+func newSliceCursor(start int64, num int, interval int64) *sliceCursor {
+	data := make([]*model.StockAggregate, num)
+	for i := 0; i < num; i++ {
+		closed := start + int64(i)*interval
+		data[i] = &model.StockAggregate{
+			OpenTime:   closed - interval,
+			ClosedTime: closed,
+			Open:       1,
+			Close:      1,
+			High:       1,
+			Low:        1,
+			Volume:     1,
+		}
+	}
+	return &sliceCursor{data: data}
+}
+
+type ReplayStockTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ReplayStockTestSuite) run(f *fetcher.ReplayStock) ([]model.Packet, time.Duration, error) {
+	res := make([]model.Packet, 0)
+	g := errgroup.Group{}
+
+	g.Go(func() error {
+		for v := range f.Output() {
+			res = append(res, v)
+		}
+		return nil
+	})
+
+	start := time.Now()
+	g.Go(f.Execute)
+	err := g.Wait()
+
+	return res, time.Since(start), err
+}
+
+func (suite *ReplayStockTestSuite) TestReplayStock_ShouldPaceDataByWallClock_WhenSpeedIsGiven() {
+	//arrange
+	start := int64(1720396800)
+	num := 5
+	// 1초 간격의 데이터를 10배속으로 재생하면 데이터 사이에 100ms의 간격이 생긴다.
+	f, err := fetcher.NewReplayStock(newSliceCursor(start, num, 1), &job.UserParams{
+		job.ProductID:   "stock.aapl.usa",
+		job.StartDate:   fmt.Sprint(start),
+		job.EndDate:     fmt.Sprint(start + int64(num)),
+		job.TimeFrame:   "1s",
+		job.ReplaySpeed: "10x",
+	})
+	suite.Require().NoError(err)
+
+	//act
+	res, elapsed, err := suite.run(f)
+
+	//assert
+	suite.NoError(err)
+	suite.Len(res, num)
+	suite.GreaterOrEqual(elapsed, time.Duration(num-1)*100*time.Millisecond)
+	suite.Less(elapsed, time.Duration(num)*200*time.Millisecond)
+	for i, e := range res {
+		suite.Equal(time.Unix(start+int64(i), 0), e.Time)
+	}
+}
+
+func (suite *ReplayStockTestSuite) TestReplayStock_ShouldNotWait_WhenSpeedIsMax() {
+	//arrange
+	start := int64(1720396800)
+	num := 100
+	f, err := fetcher.NewReplayStock(newSliceCursor(start, num, 60), &job.UserParams{
+		job.StartDate:   fmt.Sprint(start),
+		job.EndDate:     fmt.Sprint(start + int64(num)*60),
+		job.ReplaySpeed: "max",
+	})
+	suite.Require().NoError(err)
+
+	//act
+	res, elapsed, err := suite.run(f)
+
+	//assert
+	suite.NoError(err)
+	suite.Len(res, num)
+	suite.Less(elapsed, time.Second)
+}
+
+func (suite *ReplayStockTestSuite) TestReplayStock_ShouldStopReplaying_WhenNotifyStopIsCalled() {
+	//arrange
+	start := int64(1720396800)
+	f, err := fetcher.NewReplayStock(newSliceCursor(start, 10, 60), &job.UserParams{
+		job.StartDate:   fmt.Sprint(start),
+		job.EndDate:     fmt.Sprint(start + 600),
+		job.ReplaySpeed: "1x",
+	})
+	suite.Require().NoError(err)
+
+	//act
+	time.AfterFunc(100*time.Millisecond, f.NotifyStop)
+	res, elapsed, err := suite.run(f)
+
+	//assert
+	suite.NoError(err)
+	suite.Len(res, 1)
+	suite.Less(elapsed, time.Second)
+}
+
+func (suite *ReplayStockTestSuite) TestNewReplayStock_ShouldReturnError_WhenSpeedIsInvalid() {
+	for _, speed := range []string{"fast", "0x", "-1x"} {
+		//act
+		_, err := fetcher.NewReplayStock(newSliceCursor(0, 0, 1), &job.UserParams{
+			job.ReplaySpeed: speed,
+		})
+
+		//assert
+		suite.ErrorIs(err, fetcher.ErrInvalidReplaySpeed, speed)
+	}
+}
+
+func TestReplayStock(t *testing.T) {
+	suite.Run(t, new(ReplayStockTestSuite))
+}
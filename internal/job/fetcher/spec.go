@@ -4,4 +4,7 @@ package fetcher
 type Spec struct {
 	Task        string
 	ProductType string
+
+	// Replay indicates that past trade data should be emitted at the pace of real time.
+	Replay bool
 }
@@ -75,8 +75,8 @@ func (ps *StockStub) Execute() error {
 					ClosedTime: 1716775499,
 					Open:       1.0,
 					Close:      2.0,
-					High:       3.0,
-					Low:        4.0,
+					High:       4.0,
+					Low:        1.0,
 					Volume:     5.0,
 				},
 			}
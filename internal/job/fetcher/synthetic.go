@@ -0,0 +1,213 @@
+package fetcher
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/Goboolean/core-system.worker/internal/util"
+)
+
+const (
+	syntheticParamPrefix        = "synthetic."
+	syntheticVolumeDispersion   = 0.5
+	syntheticVolumeReturnWeight = 10
+)
+
+// Synthetic generates synthetic stock data following a stochastic process
+// and delivers it encapsulated in a packet to the output channel.
+//
+// Each bar is built from several intrabar steps of the process,
+// so the generated bars always satisfy Low <= Open, Close <= High.
+// Given the same seed and params, Synthetic generates exactly the same data.
+type Synthetic struct {
+	process   priceProcess
+	params    syntheticParams
+	seed      int64
+	timeFrame time.Duration
+	startTime time.Time
+	endTime   time.Time
+
+	numOfGeneration int
+	substeps        int
+
+	out  job.DataChan `type:"*StockAggregate"` //Job은 자신의 Output 채널에 대해 소유권을 가진다.
+	stop *util.StopNotifier
+}
+
+// NewSynthetic creates new instance of Synthetic
+//
+// Params list:
+// job.SyntheticProcess: The process generating prices. "gbm"|"ou"|"regime"|"jump"
+// job.Seed: The seed of the random number generator. A random seed is used if omitted.
+// job.TimeFrame: The interval of each generated bar.
+// job.StartDate: The open time of the first bar. The current time is used if omitted.
+// job.EndDate: The generation stops after the bar closing at EndDate if it is given.
+// job.NumOfGeneration: The number of bars to generate if EndDate is omitted.
+// "synthetic.{name}": The parameters of the process. See syntheticParams.
+func NewSynthetic(params *job.UserParams) (*Synthetic, error) {
+	//여기에 기본값 입력 아웃풋 채널은 job이 소유권을 가져야 한다.
+	instance := &Synthetic{
+		params:          defaultSyntheticParams(),
+		seed:            time.Now().UnixNano(),
+		numOfGeneration: DefaultNumOfGeneration,
+		substeps:        DefaultSyntheticSubsteps,
+		out:             make(job.DataChan),
+		stop:            util.NewStopNotifier(),
+	}
+
+	var err error
+	if instance.timeFrame, err = time.ParseDuration(DefaultTimeSlice); err != nil {
+		return nil, fmt.Errorf("create synthetic fetch job: %w", err)
+	}
+
+	if !params.IsKeyNilOrEmpty(job.TimeFrame) {
+		instance.timeFrame, err = time.ParseDuration((*params)[job.TimeFrame])
+		if err != nil {
+			return nil, fmt.Errorf("create synthetic fetch job: %w", err)
+		}
+	}
+
+	instance.startTime = time.Now().Truncate(instance.timeFrame)
+	if !params.IsKeyNilOrEmpty(job.StartDate) {
+		val, err := strconv.ParseInt((*params)[job.StartDate], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("create synthetic fetch job: %w", err)
+		}
+
+		instance.startTime = time.Unix(val, 0)
+	}
+
+	if !params.IsKeyNilOrEmpty(job.EndDate) {
+		val, err := strconv.ParseInt((*params)[job.EndDate], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("create synthetic fetch job: %w", err)
+		}
+
+		instance.endTime = time.Unix(val, 0)
+	}
+
+	if !params.IsKeyNilOrEmpty(job.NumOfGeneration) {
+		val, err := strconv.ParseInt((*params)[job.NumOfGeneration], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("create synthetic fetch job: %w", err)
+		}
+
+		instance.numOfGeneration = int(val)
+	}
+
+	if !params.IsKeyNilOrEmpty(job.Seed) {
+		val, err := strconv.ParseInt((*params)[job.Seed], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("create synthetic fetch job: %w", err)
+		}
+
+		instance.seed = val
+	}
+
+	for k, v := range *params {
+		name, ok := strings.CutPrefix(k, syntheticParamPrefix)
+		if !ok {
+			continue
+		}
+
+		field, ok := instance.params.fields()[name]
+		if !ok {
+			return nil, fmt.Errorf("create synthetic fetch job: unknown param %s", k)
+		}
+
+		val, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("create synthetic fetch job: %w", err)
+		}
+		*field = val
+	}
+
+	if err := instance.params.validate(); err != nil {
+		return nil, fmt.Errorf("create synthetic fetch job: %w", err)
+	}
+
+	processName := DefaultSyntheticProcess
+	if !params.IsKeyNilOrEmpty(job.SyntheticProcess) {
+		processName = (*params)[job.SyntheticProcess]
+	}
+
+	instance.process, err = newPriceProcess(processName, instance.params)
+	if err != nil {
+		return nil, fmt.Errorf("create synthetic fetch job: %w", err)
+	}
+
+	return instance, nil
+}
+
+// Execute starts to generate synthetic data.
+//
+// If the Job fails to perform its task, Execute returns an error.
+// If the Job completes successfully, it returns nil.
+// DO NOT CALL Execute() TWICE. IT MUST BE PANIC
+func (s *Synthetic) Execute() error {
+
+	defer close(s.out)
+	defer s.stop.NotifyStop()
+
+	r := rand.New(rand.NewSource(s.seed))
+	x := math.Log(s.params.initialPrice)
+	dt := 1 / float64(s.substeps)
+	step := int64(s.timeFrame.Seconds())
+
+	for i := 0; s.shouldGenerate(i); i++ {
+		open := x
+		high, low := x, x
+		for j := 0; j < s.substeps; j++ {
+			x = s.process.step(r, x, dt)
+			high = math.Max(high, x)
+			low = math.Min(low, x)
+		}
+
+		openTime := s.startTime.Unix() + int64(i)*step
+		volume := s.params.baseVolume *
+			math.Exp(syntheticVolumeDispersion*r.NormFloat64()) *
+			(1 + syntheticVolumeReturnWeight*math.Abs(x-open))
+
+		select {
+		case <-s.stop.Done():
+			return nil
+		case s.out <- model.Packet{
+			Time: time.Unix(openTime+step, 0),
+			Data: &model.StockAggregate{
+				OpenTime:   openTime,
+				ClosedTime: openTime + step,
+				Open:       float32(math.Exp(open)),
+				Close:      float32(math.Exp(x)),
+				High:       float32(math.Exp(high)),
+				Low:        float32(math.Exp(low)),
+				Volume:     float32(volume),
+			},
+		}:
+		}
+	}
+
+	return nil
+}
+
+// shouldGenerate reports whether the i-th bar should be generated.
+func (s *Synthetic) shouldGenerate(i int) bool {
+	if !s.endTime.IsZero() {
+		closedTime := s.startTime.Add(time.Duration(i+1) * s.timeFrame)
+		return !closedTime.After(s.endTime)
+	}
+	return i < s.numOfGeneration
+}
+
+func (s *Synthetic) Output() job.DataChan {
+	return s.out
+}
+
+func (s *Synthetic) NotifyStop() {
+	s.stop.NotifyStop()
+}
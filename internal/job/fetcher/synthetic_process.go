@@ -0,0 +1,183 @@
+package fetcher
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+var (
+	ErrUnknownSyntheticProcess = errors.New("fetch: unknown synthetic process")
+	ErrInvalidSyntheticParam   = errors.New("fetch: synthetic param is out of range")
+)
+
+// Names of the stochastic processes supported by Synthetic.
+const (
+	ProcessGBM           = "gbm"
+	ProcessOU            = "ou"
+	ProcessRegimeSwitch  = "regime"
+	ProcessJumpDiffusion = "jump"
+)
+
+// priceProcess describes how the log price of a synthetic product evolves.
+//
+// All parameters of the processes are expressed per bar,
+// so dt is the fraction of a bar that elapses in one step.
+type priceProcess interface {
+	step(r *rand.Rand, logPrice float64, dt float64) float64
+}
+
+// gbm is a geometric Brownian motion.
+//
+// d(log S) = (mu - sigma^2/2)dt + sigma dW
+type gbm struct {
+	mu    float64
+	sigma float64
+}
+
+func (p *gbm) step(r *rand.Rand, x float64, dt float64) float64 {
+	return x + (p.mu-p.sigma*p.sigma/2)*dt + p.sigma*math.Sqrt(dt)*r.NormFloat64()
+}
+
+// ou is an Ornstein-Uhlenbeck process of the log price, which reverts to log(mean).
+//
+// d(log S) = theta(log(mean) - log S)dt + sigma dW
+type ou struct {
+	theta   float64
+	logMean float64
+	sigma   float64
+}
+
+func (p *ou) step(r *rand.Rand, x float64, dt float64) float64 {
+	return x + p.theta*(p.logMean-x)*dt + p.sigma*math.Sqrt(dt)*r.NormFloat64()
+}
+
+// regimeSwitch is a geometric Brownian motion whose drift and volatility
+// switch between a calm regime and a volatile regime following a Markov chain.
+type regimeSwitch struct {
+	regimes []gbm
+	// switchProb is the probability of leaving the current regime within a bar.
+	switchProb float64
+	current    int
+}
+
+func (p *regimeSwitch) step(r *rand.Rand, x float64, dt float64) float64 {
+	if r.Float64() < p.switchProb*dt {
+		p.current = (p.current + 1) % len(p.regimes)
+	}
+	return p.regimes[p.current].step(r, x, dt)
+}
+
+// jumpDiffusion is a Merton jump diffusion process,
+// a geometric Brownian motion with jumps arriving as a Poisson process.
+type jumpDiffusion struct {
+	gbm
+	// lambda is the expected number of jumps in a bar.
+	lambda   float64
+	jumpMean float64
+	jumpStd  float64
+}
+
+func (p *jumpDiffusion) step(r *rand.Rand, x float64, dt float64) float64 {
+	x = p.gbm.step(r, x, dt)
+	if r.Float64() < p.lambda*dt {
+		x += p.jumpMean + p.jumpStd*r.NormFloat64()
+	}
+	return x
+}
+
+// syntheticParams holds the parameters of all synthetic processes.
+// Each field can be overridden by the user param "synthetic.{name}".
+type syntheticParams struct {
+	initialPrice float64
+	baseVolume   float64
+	mu           float64
+	sigma        float64
+	theta        float64
+	mean         float64
+	switchProb   float64
+	volatileMul  float64
+	lambda       float64
+	jumpMean     float64
+	jumpStd      float64
+}
+
+func defaultSyntheticParams() syntheticParams {
+	return syntheticParams{
+		initialPrice: 100,
+		baseVolume:   1000,
+		mu:           0,
+		sigma:        0.01,
+		theta:        0.05,
+		mean:         100,
+		switchProb:   0.02,
+		volatileMul:  3,
+		lambda:       0.01,
+		jumpMean:     0,
+		jumpStd:      0.05,
+	}
+}
+
+// fields returns pointers to the parameters keyed by their user param names.
+func (p *syntheticParams) fields() map[string]*float64 {
+	return map[string]*float64{
+		"initialPrice": &p.initialPrice,
+		"baseVolume":   &p.baseVolume,
+		"mu":           &p.mu,
+		"sigma":        &p.sigma,
+		"theta":        &p.theta,
+		"mean":         &p.mean,
+		"switchProb":   &p.switchProb,
+		"volatileMul":  &p.volatileMul,
+		"lambda":       &p.lambda,
+		"jumpMean":     &p.jumpMean,
+		"jumpStd":      &p.jumpStd,
+	}
+}
+
+// validate checks the ranges of the parameters.
+// The comparisons are negated so that NaN is rejected as well.
+func (p *syntheticParams) validate() error {
+	switch {
+	case !(p.initialPrice > 0):
+		return fmt.Errorf("%w: initialPrice must be positive, got %v", ErrInvalidSyntheticParam, p.initialPrice)
+	case !(p.mean > 0):
+		return fmt.Errorf("%w: mean must be positive, got %v", ErrInvalidSyntheticParam, p.mean)
+	case !(p.baseVolume >= 0):
+		return fmt.Errorf("%w: baseVolume must not be negative, got %v", ErrInvalidSyntheticParam, p.baseVolume)
+	case !(p.switchProb >= 0 && p.switchProb <= 1):
+		return fmt.Errorf("%w: switchProb must be in [0, 1], got %v", ErrInvalidSyntheticParam, p.switchProb)
+	case !(p.sigma >= 0):
+		return fmt.Errorf("%w: sigma must not be negative, got %v", ErrInvalidSyntheticParam, p.sigma)
+	case !(p.lambda >= 0):
+		return fmt.Errorf("%w: lambda must not be negative, got %v", ErrInvalidSyntheticParam, p.lambda)
+	}
+	return nil
+}
+
+func newPriceProcess(name string, p syntheticParams) (priceProcess, error) {
+	switch name {
+	case ProcessGBM:
+		return &gbm{mu: p.mu, sigma: p.sigma}, nil
+	case ProcessOU:
+		return &ou{theta: p.theta, logMean: math.Log(p.mean), sigma: p.sigma}, nil
+	case ProcessRegimeSwitch:
+		return &regimeSwitch{
+			regimes: []gbm{
+				{mu: p.mu, sigma: p.sigma},
+				{mu: -p.mu, sigma: p.sigma * p.volatileMul},
+			},
+			switchProb: p.switchProb,
+		}, nil
+	case ProcessJumpDiffusion:
+		return &jumpDiffusion{
+			gbm:      gbm{mu: p.mu, sigma: p.sigma},
+			lambda:   p.lambda,
+			jumpMean: p.jumpMean,
+			jumpStd:  p.jumpStd,
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSyntheticProcess, name)
+	}
+}
@@ -0,0 +1,170 @@
+package fetcher_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/job/fetcher"
+	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/sync/errgroup"
+)
+
+type SyntheticTestSuite struct {
+	suite.Suite
+}
+
+var syntheticProcesses = []string{
+	fetcher.ProcessGBM,
+	fetcher.ProcessOU,
+	fetcher.ProcessRegimeSwitch,
+	fetcher.ProcessJumpDiffusion,
+}
+
+func (suite *SyntheticTestSuite) generate(params *job.UserParams) []*model.StockAggregate {
+	f, err := fetcher.NewSynthetic(params)
+	suite.Require().NoError(err)
+
+	res := make([]*model.StockAggregate, 0)
+	g := errgroup.Group{}
+	g.Go(func() error {
+		for v := range f.Output() {
+			res = append(res, v.Data.(*model.StockAggregate))
+		}
+		return nil
+	})
+	g.Go(f.Execute)
+	suite.Require().NoError(g.Wait())
+
+	return res
+}
+
+func (suite *SyntheticTestSuite) TestSynthetic_ShouldGenerateValidBars_WhenAnyProcessIsSelected() {
+	for _, process := range syntheticProcesses {
+		//arrange
+		num := 500
+		start := int64(1720396800)
+
+		//act
+		res := suite.generate(&job.UserParams{
+			job.SyntheticProcess: process,
+			job.Seed:             "42",
+			job.NumOfGeneration:  fmt.Sprint(num),
+			job.StartDate:        fmt.Sprint(start),
+			job.TimeFrame:        "1m",
+		})
+
+		//assert
+		suite.Require().Len(res, num, process)
+		for i, e := range res {
+			suite.Equal(start+int64(i)*60, e.OpenTime, process)
+			suite.Equal(e.OpenTime+60, e.ClosedTime, process)
+			suite.LessOrEqual(e.Low, e.Open, process)
+			suite.LessOrEqual(e.Low, e.Close, process)
+			suite.GreaterOrEqual(e.High, e.Open, process)
+			suite.GreaterOrEqual(e.High, e.Close, process)
+			suite.Greater(e.Low, float32(0), process)
+			suite.Greater(e.Volume, float32(0), process)
+			suite.False(math.IsNaN(float64(e.Close)), process)
+			if i > 0 {
+				suite.Equal(res[i-1].Close, e.Open, process)
+			}
+		}
+	}
+}
+
+func (suite *SyntheticTestSuite) TestSynthetic_ShouldGenerateSameData_WhenSeedIsSame() {
+	for _, process := range syntheticProcesses {
+		//arrange
+		params := func(seed string) *job.UserParams {
+			return &job.UserParams{
+				job.SyntheticProcess: process,
+				job.Seed:             seed,
+				job.NumOfGeneration:  "100",
+				job.StartDate:        "1720396800",
+			}
+		}
+
+		//act
+		first := suite.generate(params("7"))
+		second := suite.generate(params("7"))
+		other := suite.generate(params("8"))
+
+		//assert
+		suite.Equal(first, second, process)
+		suite.NotEqual(first, other, process)
+	}
+}
+
+func (suite *SyntheticTestSuite) TestSynthetic_ShouldStopAtEndDate_WhenEndDateIsGiven() {
+	//act
+	res := suite.generate(&job.UserParams{
+		job.StartDate:       "1720396800",
+		job.EndDate:         fmt.Sprint(1720396800 + 10*60),
+		job.NumOfGeneration: "100",
+		job.TimeFrame:       "1m",
+	})
+
+	//assert
+	suite.Len(res, 10)
+}
+
+func (suite *SyntheticTestSuite) TestSynthetic_ShouldRevertToMean_WhenProcessIsOU() {
+	//act
+	res := suite.generate(&job.UserParams{
+		job.SyntheticProcess:     fetcher.ProcessOU,
+		job.Seed:                 "1",
+		job.NumOfGeneration:      "2000",
+		"synthetic.initialPrice": "200",
+		"synthetic.mean":         "100",
+		"synthetic.theta":        "0.1",
+	})
+
+	//assert
+	sum := 0.0
+	tail := res[len(res)-500:]
+	for _, e := range tail {
+		sum += float64(e.Close)
+	}
+	suite.InDelta(100, sum/float64(len(tail)), 10)
+}
+
+func (suite *SyntheticTestSuite) TestNewSynthetic_ShouldReturnError_WhenParamIsInvalid() {
+	for _, params := range []job.UserParams{
+		{job.SyntheticProcess: "unknown"},
+		{"synthetic.unknown": "1"},
+		{"synthetic.sigma": "abc"},
+		{job.Seed: "abc"},
+	} {
+		//act
+		_, err := fetcher.NewSynthetic(&params)
+
+		//assert
+		suite.Error(err)
+	}
+}
+
+func (suite *SyntheticTestSuite) TestNewSynthetic_ShouldReturnError_WhenParamIsOutOfRange() {
+	for _, params := range []job.UserParams{
+		{"synthetic.initialPrice": "0"},
+		{"synthetic.initialPrice": "NaN"},
+		{job.SyntheticProcess: fetcher.ProcessOU, "synthetic.mean": "-1"},
+		{"synthetic.baseVolume": "-1"},
+		{"synthetic.switchProb": "1.5"},
+		{"synthetic.switchProb": "-0.1"},
+		{"synthetic.sigma": "-0.01"},
+		{"synthetic.lambda": "-1"},
+	} {
+		//act
+		_, err := fetcher.NewSynthetic(&params)
+
+		//assert
+		suite.ErrorIs(err, fetcher.ErrInvalidSyntheticParam, params)
+	}
+}
+
+func TestSynthetic(t *testing.T) {
+	suite.Run(t, new(SyntheticTestSuite))
+}
@@ -0,0 +1,152 @@
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/Goboolean/core-system.worker/internal/util"
+)
+
+// TradePrintSource is a data source containing past trade prints.
+// *influx.TradeReader implements TradePrintSource.
+type TradePrintSource interface {
+	// FetchLimitedTradePrintAfter fetches at most limit trade prints of the product whose time is after start.
+	FetchLimitedTradePrintAfter(ctx context.Context, productID string, start time.Time, limit int) ([]*model.Trade, error)
+	Close() error
+}
+
+// TickBar retrieves past trade prints sequentially and builds bars from them in a streaming way.
+// Each bar is sent to the output channel as a *model.StockAggregate as soon as it is closed,
+// so the rest of the pipeline consumes it like pre-aggregated bars.
+// A bar which is not closed when the data runs out is not sent.
+type TickBar struct {
+	rangeParams
+	source  TradePrintSource
+	builder *barBuilder
+
+	out job.DataChan `type:"*StockAggregate"` //Job은 자신의 Output 채널에 대해 소유권을 가진다.
+
+	stop *util.StopNotifier
+}
+
+// NewTickBar creates new instance of TickBar
+//
+// Parameter List:
+// job.ProductID: The unique identifier of the product in the format {type}.{ticker}.{locale}.
+// job.StartDate: The start date for data collection.
+// job.EndDate: The end date for data collection.
+// job.BarType: The type of bar to build. "time"|"tick"|"volume"|"dollar"
+// job.TimeFrame: The time frame of time bars.
+// job.BarThreshold: The number of trades, size or value closing a tick, volume or dollar bar.
+func NewTickBar(source TradePrintSource, params *job.UserParams) (*TickBar, error) {
+	p, err := parseRangeParams(params)
+	if err != nil {
+		return nil, fmt.Errorf("create tick bar fetch job: %w", err)
+	}
+
+	barType := BarTime
+	if !params.IsKeyNilOrEmpty(job.BarType) {
+		barType = (*params)[job.BarType]
+	}
+
+	timeFrame := DefaultTimeSlice
+	if !params.IsKeyNilOrEmpty(job.TimeFrame) {
+		timeFrame = (*params)[job.TimeFrame]
+	}
+
+	d, err := time.ParseDuration(timeFrame)
+	if err != nil {
+		return nil, fmt.Errorf("create tick bar fetch job: %w", err)
+	}
+
+	var threshold float64
+	if !params.IsKeyNilOrEmpty(job.BarThreshold) {
+		threshold, err = strconv.ParseFloat((*params)[job.BarThreshold], 64)
+		if err != nil {
+			return nil, fmt.Errorf("create tick bar fetch job: %w", err)
+		}
+	}
+
+	builder, err := newBarBuilder(barType, d, threshold)
+	if err != nil {
+		return nil, fmt.Errorf("create tick bar fetch job: %w", err)
+	}
+
+	return &TickBar{
+		rangeParams: p,
+		source:      source,
+		builder:     builder,
+		out:         make(job.DataChan),
+		stop:        util.NewStopNotifier(),
+	}, nil
+}
+
+// Execute starts to build bars from past trade prints.
+//
+// If the Job fails to perform its task, Execute returns an error.
+// If the Job completes successfully, it returns nil.
+// DO NOT CALL Execute() TWICE. IT MUST BE PANIC
+func (tb *TickBar) Execute() error {
+	defer close(tb.out)
+	defer tb.stop.NotifyStop()
+	defer tb.source.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		<-tb.stop.Done()
+		cancel()
+	}()
+
+	cursor := &pageCursor[*model.Trade]{
+		current: tb.startTime,
+		fetch: func(ctx context.Context, start time.Time, limit int) ([]*model.Trade, error) {
+			return tb.source.FetchLimitedTradePrintAfter(ctx, tb.productID, start, limit)
+		},
+		timeOf: func(e *model.Trade) time.Time { return e.Time },
+	}
+
+	for {
+		e, err := cursor.next(ctx)
+
+		select {
+		case <-tb.stop.Done():
+			return nil
+		default:
+		}
+
+		if err != nil {
+			return fmt.Errorf("execute tick bar fetch job:fail to fetch trade print %w", err)
+		}
+		if e == nil || e.Time.After(tb.endTime) {
+			tb.send(tb.builder.flush(tb.endTime))
+			return nil
+		}
+
+		tb.send(tb.builder.add(e))
+	}
+}
+
+func (tb *TickBar) send(bar *model.StockAggregate) {
+	if bar == nil {
+		return
+	}
+
+	tb.out <- model.Packet{
+		Time: time.Unix(bar.ClosedTime, 0),
+		Data: bar,
+	}
+}
+
+func (tb *TickBar) Output() job.DataChan {
+	return tb.out
+}
+
+func (tb *TickBar) NotifyStop() {
+	tb.stop.NotifyStop()
+}
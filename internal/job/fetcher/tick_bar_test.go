@@ -0,0 +1,167 @@
+package fetcher_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/job/fetcher"
+	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/sync/errgroup"
+)
+
+// sliceTradePrintSource serves trade prints from memory.
+type sliceTradePrintSource struct {
+	trades []*model.Trade
+}
+
+func (s *sliceTradePrintSource) FetchLimitedTradePrintAfter(ctx context.Context, productID string, start time.Time, limit int) ([]*model.Trade, error) {
+	res := make([]*model.Trade, 0, limit)
+	for _, e := range s.trades {
+		if len(res) == limit {
+			break
+		}
+		if e.Time.After(start) {
+			res = append(res, e)
+		}
+	}
+	return res, nil
+}
+
+func (s *sliceTradePrintSource) Close() error {
+	return nil
+}
+
+type TickBarTestSuite struct {
+	suite.Suite
+	start  time.Time
+	source *sliceTradePrintSource
+}
+
+func (suite *TickBarTestSuite) SetupTest() {
+	suite.start = time.Unix(1720396800, 0)
+
+	// 10초 간격으로 가격 1~300, 수량 1~3이 반복되는 체결 300개
+	trades := make([]*model.Trade, 300)
+	for i := range trades {
+		trades[i] = &model.Trade{
+			Time:  suite.start.Add(time.Duration(i+1) * 10 * time.Second),
+			Price: float32(i + 1),
+			Size:  float32(i%3 + 1),
+		}
+	}
+	suite.source = &sliceTradePrintSource{trades: trades}
+}
+
+func (suite *TickBarTestSuite) build(params job.UserParams) []*model.StockAggregate {
+	params[job.StartDate] = fmt.Sprint(suite.start.Unix())
+	params[job.EndDate] = fmt.Sprint(suite.start.Add(time.Hour).Unix())
+
+	f, err := fetcher.NewTickBar(suite.source, &params)
+	suite.Require().NoError(err)
+
+	res := make([]*model.StockAggregate, 0)
+	g := errgroup.Group{}
+	g.Go(func() error {
+		for v := range f.Output() {
+			res = append(res, v.Data.(*model.StockAggregate))
+		}
+		return nil
+	})
+	g.Go(f.Execute)
+	suite.Require().NoError(g.Wait())
+
+	return res
+}
+
+func (suite *TickBarTestSuite) TestTickBar_ShouldBuildTimeBars_WhenBarTypeIsTime() {
+	//act
+	res := suite.build(job.UserParams{
+		job.BarType:   fetcher.BarTime,
+		job.TimeFrame: "1m",
+	})
+
+	//assert
+	// 10초~3000초의 체결이므로 51개의 1분 봉이 만들어진다.
+	// 마지막 봉은 endDate 이전에 닫히므로 함께 전달된다.
+	suite.Require().Len(res, 51)
+	suite.Equal(&model.StockAggregate{
+		OpenTime:   suite.start.Unix(),
+		ClosedTime: suite.start.Unix() + 60,
+		Open:       1,
+		Close:      5,
+		High:       5,
+		Low:        1,
+		Volume:     1 + 2 + 3 + 1 + 2,
+	}, res[0])
+	for i := 1; i < len(res); i++ {
+		suite.Equal(res[i-1].ClosedTime, res[i].OpenTime)
+	}
+}
+
+func (suite *TickBarTestSuite) TestTickBar_ShouldBuildThresholdBars_WhenBarTypeIsTickOrVolumeOrDollar() {
+	for _, tc := range []struct {
+		barType   string
+		threshold string
+		num       int
+		first     *model.StockAggregate
+	}{
+		{
+			barType:   fetcher.BarTick,
+			threshold: "10",
+			num:       30,
+			first:     &model.StockAggregate{Open: 1, Close: 10, High: 10, Low: 1, Volume: 19},
+		},
+		{
+			barType:   fetcher.BarVolume,
+			threshold: "6",
+			num:       100,
+			first:     &model.StockAggregate{Open: 1, Close: 3, High: 3, Low: 1, Volume: 6},
+		},
+		{
+			// 1*1 + 2*2 + 3*3 + 4*1 = 18
+			barType:   fetcher.BarDollar,
+			threshold: "15",
+			first:     &model.StockAggregate{Open: 1, Close: 4, High: 4, Low: 1, Volume: 7},
+		},
+	} {
+		//act
+		res := suite.build(job.UserParams{
+			job.BarType:      tc.barType,
+			job.BarThreshold: tc.threshold,
+		})
+
+		//assert
+		suite.Require().NotEmpty(res, tc.barType)
+		if tc.num > 0 {
+			suite.Len(res, tc.num, tc.barType)
+		}
+
+		first := *res[0]
+		suite.Equal(suite.start.Unix()+10, first.OpenTime, tc.barType)
+		first.OpenTime, first.ClosedTime = 0, 0
+		suite.Equal(tc.first, &first, tc.barType)
+	}
+}
+
+func (suite *TickBarTestSuite) TestNewTickBar_ShouldReturnError_WhenParamIsInvalid() {
+	for _, params := range []job.UserParams{
+		{job.BarType: "unknown"},
+		{job.BarType: fetcher.BarVolume},
+		{job.BarType: fetcher.BarTick, job.BarThreshold: "-1"},
+		{job.BarType: fetcher.BarTime, job.TimeFrame: "100ms"},
+	} {
+		//act
+		_, err := fetcher.NewTickBar(suite.source, &params)
+
+		//assert
+		suite.Error(err)
+	}
+}
+
+func TestTickBar(t *testing.T) {
+	suite.Run(t, new(TickBarTestSuite))
+}
@@ -6,16 +6,35 @@ import (
 	"time"
 
 	"github.com/Goboolean/core-system.worker/internal/model"
-	"github.com/Goboolean/fetch-system.IaC/pkg/influx"
 	infraModel "github.com/Goboolean/fetch-system.IaC/pkg/model"
 	"github.com/cenkalti/backoff"
 )
 
+// TradeSource is a data source containing past trading data.
+// *influx.DB and *barcache.Cache implement TradeSource.
+type TradeSource interface {
+	FetchLimitedTradeAfter(ctx context.Context, productID string, timeFrame string, start time.Time, limit int) ([]*infraModel.StockAggregate, error)
+	Close() error
+}
+
+// TradeCursor is an interface for sequentially accessing past stock trade data.
+type TradeCursor interface {
+	// ConfigureStockTradeCursor selects the data that the cursor will retrieve.
+	ConfigureStockTradeCursor(startTime time.Time, productID string, timeFrame string) error
+
+	// Next fetches the current trade data pointed by the cursor and moves the cursor to the next trade data.
+	// If there is no more data to retrieve, it returns (nil, nil).
+	Next(ctx context.Context) (*model.StockAggregate, error)
+
+	// Close releases the data source of the cursor.
+	Close() error
+}
+
 // StockTradeCursor is a cursor structure designed for sequentially accessing stock trade data.
 // It retrieves an appropriate amount of data from a data source containing past trading data,
 // stores it in a buffer, and sequentially provides this data.
 type StockTradeCursor struct {
-	pastTradeDataSource *influx.DB
+	pastTradeDataSource TradeSource
 	current             time.Time
 	limit               int
 
@@ -35,7 +54,7 @@ type StockTradeCursor struct {
 
 const DefaultLimit = 100
 
-func NewStockTradeCursor(dataSource *influx.DB) (*StockTradeCursor, error) {
+func NewStockTradeCursor(dataSource TradeSource) (*StockTradeCursor, error) {
 	return &StockTradeCursor{
 		pastTradeDataSource: dataSource,
 		limit:               DefaultLimit,
@@ -7,9 +7,13 @@
 package fetcher
 
 import (
+	"fmt"
+	"github.com/Goboolean/core-system.worker/internal/infrastructure/barcache"
+	influx2 "github.com/Goboolean/core-system.worker/internal/infrastructure/influx"
 	"github.com/Goboolean/core-system.worker/internal/job"
 	"github.com/Goboolean/fetch-system.IaC/pkg/influx"
 	"os"
+	"strconv"
 )
 
 // Injectors from wire_setup.go:
@@ -20,7 +24,11 @@ func InitializePastStock(p *job.UserParams) (Fetcher, error) {
 	if err != nil {
 		return nil, err
 	}
-	stockTradeCursor, err := NewStockTradeCursor(db)
+	tradeSource, err := provideTradeSource(p, db)
+	if err != nil {
+		return nil, err
+	}
+	stockTradeCursor, err := NewStockTradeCursor(tradeSource)
 	if err != nil {
 		return nil, err
 	}
@@ -31,8 +39,91 @@ func InitializePastStock(p *job.UserParams) (Fetcher, error) {
 	return pastStock, nil
 }
 
+func InitializeReplayStock(p *job.UserParams) (Fetcher, error) {
+	opts := provideInfluxConfig()
+	db, err := influx.NewDB(opts)
+	if err != nil {
+		return nil, err
+	}
+	tradeSource, err := provideTradeSource(p, db)
+	if err != nil {
+		return nil, err
+	}
+	stockTradeCursor, err := NewStockTradeCursor(tradeSource)
+	if err != nil {
+		return nil, err
+	}
+	replayStock, err := NewReplayStock(stockTradeCursor, p)
+	if err != nil {
+		return nil, err
+	}
+	return replayStock, nil
+}
+
+func InitializePastQuote(p *job.UserParams) (Fetcher, error) {
+	opts := provideQuoteReaderConfig()
+	quoteReader, err := influx2.NewQuoteReader(opts)
+	if err != nil {
+		return nil, err
+	}
+	pastQuote, err := NewPastQuote(quoteReader, p)
+	if err != nil {
+		return nil, err
+	}
+	return pastQuote, nil
+}
+
+func InitializeReplayQuote(p *job.UserParams) (Fetcher, error) {
+	opts := provideQuoteReaderConfig()
+	quoteReader, err := influx2.NewQuoteReader(opts)
+	if err != nil {
+		return nil, err
+	}
+	replayQuote, err := NewReplayQuote(quoteReader, p)
+	if err != nil {
+		return nil, err
+	}
+	return replayQuote, nil
+}
+
+func InitializeTickBar(p *job.UserParams) (Fetcher, error) {
+	opts := provideTradeReaderConfig()
+	tradeReader, err := influx2.NewTradeReader(opts)
+	if err != nil {
+		return nil, err
+	}
+	tickBar, err := NewTickBar(tradeReader, p)
+	if err != nil {
+		return nil, err
+	}
+	return tickBar, nil
+}
+
 // wire_setup.go:
 
+// provideTradeSource puts a local cache of past trade data in front of the database
+// if BAR_CACHE_DIR is set and the user does not bypass the cache.
+func provideTradeSource(p *job.UserParams, db *influx.DB) (TradeSource, error) {
+	dir := os.Getenv("BAR_CACHE_DIR")
+	if dir == "" || (*p)[job.BypassCache] == "true" {
+		return db, nil
+	}
+
+	var maxBytes int64
+	if s := os.Getenv("BAR_CACHE_MAX_BYTES"); s != "" {
+		val, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("provide trade source: %w", err)
+		}
+		maxBytes = val
+	}
+
+	return barcache.New(db, barcache.Opts{
+		Dir:      dir,
+		MaxBytes: maxBytes,
+	})
+}
+
 func provideInfluxConfig() *influx.Opts {
 	return &influx.Opts{
 		URL:             os.Getenv("INFLUXDB_URL"),
@@ -41,3 +132,21 @@ func provideInfluxConfig() *influx.Opts {
 		TradeBucketName: os.Getenv("INFLUXDB_TRADE_BUCKET"),
 	}
 }
+
+func provideQuoteReaderConfig() *influx2.Opts {
+	return &influx2.Opts{
+		URL:        os.Getenv("INFLUXDB_URL"),
+		Token:      os.Getenv("INFLUXDB_TOKEN"),
+		Org:        os.Getenv("INFLUXDB_ORG"),
+		BucketName: os.Getenv("INFLUXDB_QUOTE_BUCKET"),
+	}
+}
+
+func provideTradeReaderConfig() *influx2.Opts {
+	return &influx2.Opts{
+		URL:        os.Getenv("INFLUXDB_URL"),
+		Token:      os.Getenv("INFLUXDB_TOKEN"),
+		Org:        os.Getenv("INFLUXDB_ORG"),
+		BucketName: os.Getenv("INFLUXDB_TRADE_BUCKET"),
+	}
+}
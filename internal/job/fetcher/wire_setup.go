@@ -4,8 +4,12 @@
 package fetcher
 
 import (
+	"fmt"
 	"os"
+	"strconv"
 
+	"github.com/Goboolean/core-system.worker/internal/infrastructure/barcache"
+	influxInfra "github.com/Goboolean/core-system.worker/internal/infrastructure/influx"
 	"github.com/Goboolean/core-system.worker/internal/job"
 	"github.com/Goboolean/fetch-system.IaC/pkg/influx"
 
@@ -21,12 +25,96 @@ func provideInfluxConfig() *influx.Opts {
 	}
 }
 
+func provideQuoteReaderConfig() *influxInfra.Opts {
+	return &influxInfra.Opts{
+		URL:        os.Getenv("INFLUXDB_URL"),
+		Token:      os.Getenv("INFLUXDB_TOKEN"),
+		Org:        os.Getenv("INFLUXDB_ORG"),
+		BucketName: os.Getenv("INFLUXDB_QUOTE_BUCKET"),
+	}
+}
+
+func provideTradeReaderConfig() *influxInfra.Opts {
+	return &influxInfra.Opts{
+		URL:        os.Getenv("INFLUXDB_URL"),
+		Token:      os.Getenv("INFLUXDB_TOKEN"),
+		Org:        os.Getenv("INFLUXDB_ORG"),
+		BucketName: os.Getenv("INFLUXDB_TRADE_BUCKET"),
+	}
+}
+
+// provideTradeSource puts a local cache of past trade data in front of the database
+// if BAR_CACHE_DIR is set and the user does not bypass the cache.
+func provideTradeSource(p *job.UserParams, db *influx.DB) (TradeSource, error) {
+	dir := os.Getenv("BAR_CACHE_DIR")
+	if dir == "" || (*p)[job.BypassCache] == "true" {
+		return db, nil
+	}
+
+	var maxBytes int64
+	if s := os.Getenv("BAR_CACHE_MAX_BYTES"); s != "" {
+		val, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("provide trade source: %w", err)
+		}
+		maxBytes = val
+	}
+
+	return barcache.New(db, barcache.Opts{
+		Dir:      dir,
+		MaxBytes: maxBytes,
+	})
+}
+
 func InitializePastStock(p *job.UserParams) (Fetcher, error) {
 	wire.Build(
 		provideInfluxConfig,
 		influx.NewDB,
+		provideTradeSource,
 		NewStockTradeCursor,
 		NewPastStock,
 		wire.Bind(new(Fetcher), new(*PastStock)))
 	return &PastStock{}, nil
 }
+
+func InitializeReplayStock(p *job.UserParams) (Fetcher, error) {
+	wire.Build(
+		provideInfluxConfig,
+		influx.NewDB,
+		provideTradeSource,
+		NewStockTradeCursor,
+		NewReplayStock,
+		wire.Bind(new(TradeCursor), new(*StockTradeCursor)),
+		wire.Bind(new(Fetcher), new(*ReplayStock)))
+	return &ReplayStock{}, nil
+}
+
+func InitializePastQuote(p *job.UserParams) (Fetcher, error) {
+	wire.Build(
+		provideQuoteReaderConfig,
+		influxInfra.NewQuoteReader,
+		NewPastQuote,
+		wire.Bind(new(QuoteSource), new(*influxInfra.QuoteReader)),
+		wire.Bind(new(Fetcher), new(*PastQuote)))
+	return &PastQuote{}, nil
+}
+
+func InitializeReplayQuote(p *job.UserParams) (Fetcher, error) {
+	wire.Build(
+		provideQuoteReaderConfig,
+		influxInfra.NewQuoteReader,
+		NewReplayQuote,
+		wire.Bind(new(QuoteSource), new(*influxInfra.QuoteReader)),
+		wire.Bind(new(Fetcher), new(*ReplayQuote)))
+	return &ReplayQuote{}, nil
+}
+
+func InitializeTickBar(p *job.UserParams) (Fetcher, error) {
+	wire.Build(
+		provideTradeReaderConfig,
+		influxInfra.NewTradeReader,
+		NewTickBar,
+		wire.Bind(new(TradePrintSource), new(*influxInfra.TradeReader)),
+		wire.Bind(new(Fetcher), new(*TickBar)))
+	return &TickBar{}, nil
+}
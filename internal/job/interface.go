@@ -9,3 +9,22 @@ type Common interface {
 	// DO NOT CALL Execute() TWICE. IT MUST BE PANIC
 	Execute() error
 }
+
+// AnnotationEmitter is an interface for jobs that publish annotations
+// in addition to the data sent to their output channel.
+// Pipelines merge the annotations into the input of the transmitter.
+type AnnotationEmitter interface {
+
+	// Annotations returns the channel of annotations.
+	// The channel is closed when Execute returns.
+	Annotations() DataChan
+}
+
+// LateEmitter is an interface for jobs that route the packets arriving after the watermark of their input
+// to a side output instead of dropping them.
+type LateEmitter interface {
+
+	// Late returns the channel of late packets. The Data of each packet is *model.LatePacket.
+	// The channel MUST be consumed, and it is closed when Execute returns.
+	Late() DataChan
+}
@@ -0,0 +1,323 @@
+package joiner
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/Goboolean/core-system.worker/internal/util/chanutil"
+)
+
+// User param keys
+const (
+	// ToleranceParam is the maximum distance from a model packet back to its reference packet. Example: "30s"
+	ToleranceParam = "join.tolerance"
+	// UnmatchedParam is the policy of the model packets that have no reference packet within the tolerance.
+	UnmatchedParam = "join.unmatched"
+)
+
+const (
+	// UnmatchedDrop drops the unmatched model packets.
+	UnmatchedDrop = "drop"
+	// UnmatchedFail fails the job at the first unmatched model packet.
+	UnmatchedFail = "fail"
+)
+
+var ErrUnmatched = errors.New("join: unmatched model packet")
+
+// AsOf pairs each model output packet with the latest reference packet at or before it
+// within the tolerance, and passes them as model.Pair objects.
+// A reference packet can be paired with more than one model packet.
+//
+// The inputs may be out of order within the allowed lateness, and late packets are routed to Late as ByTime does.
+// A model packet is paired as soon as the watermark of the reference input passes it or the reference input is closed.
+// The buffers are capped by MaxBufferParam, and OverflowParam decides what happens when a buffer is full.
+// When the inputs are closed, AsOf publishes a model.JoinSummary as an annotation.
+type AsOf struct {
+	tolerance time.Duration
+	unmatched string
+	limit     bufferLimit
+
+	refClock   eventClock
+	modelClock eventClock
+
+	// refs is the reference packets that later model packets can still be paired with.
+	// matched[i] reports whether refs[i] has been paired.
+	refs    []model.Packet
+	matched []bool
+	pending []model.Packet
+
+	summary   model.JoinSummary
+	lastPoint time.Time
+
+	refIn       job.DataChan
+	modelIn     job.DataChan
+	out         job.DataChan //Job은 자신의 Output 채널에 대해 소유권을 가진다.
+	annotations job.DataChan `type:"model.JoinSummary"`
+	late        job.DataChan `type:"*model.LatePacket"`
+}
+
+// NewAsOf creates new instance of AsOf
+//
+// Params list:
+// ToleranceParam: the maximum distance from a model packet back to its reference packet. Defaults to 0, which pairs only the same Time.
+// UnmatchedParam: the policy of the unmatched model packets. UnmatchedDrop|UnmatchedFail. Defaults to UnmatchedDrop.
+// AllowedLatenessParam: how long the packets behind the watermark are still joined. Defaults to 0.
+// MaxBufferParam: the maximum number of packets each input buffers. Defaults to 0, which is unlimited.
+// OverflowParam: the policy applied when a buffer is full. OverflowBlock|OverflowDropOldest|OverflowFail. Defaults to OverflowBlock.
+func NewAsOf(params *job.UserParams) (*AsOf, error) {
+	limit, err := parseBufferLimit(params)
+	if err != nil {
+		return nil, fmt.Errorf("create join job: %w", err)
+	}
+
+	lateness, err := parseAllowedLateness(params)
+	if err != nil {
+		return nil, fmt.Errorf("create join job: %w", err)
+	}
+
+	instance := &AsOf{
+		unmatched:   UnmatchedDrop,
+		limit:       limit,
+		refClock:    eventClock{lateness: lateness},
+		modelClock:  eventClock{lateness: lateness},
+		out:         make(job.DataChan),
+		annotations: make(job.DataChan, 1),
+		late:        make(job.DataChan),
+	}
+
+	if !params.IsKeyNilOrEmpty(ToleranceParam) {
+		val, err := time.ParseDuration((*params)[ToleranceParam])
+		if err != nil {
+			return nil, fmt.Errorf("create join job: %w", err)
+		}
+
+		if val < 0 {
+			return nil, fmt.Errorf("create join job: %s must not be negative", ToleranceParam)
+		}
+
+		instance.tolerance = val
+	}
+
+	if !params.IsKeyNilOrEmpty(UnmatchedParam) {
+		switch policy := (*params)[UnmatchedParam]; policy {
+		case UnmatchedDrop, UnmatchedFail:
+			instance.unmatched = policy
+		default:
+			return nil, fmt.Errorf("create join job: unknown unmatched policy %q", policy)
+		}
+	}
+
+	return instance, nil
+}
+
+// Execute starts to receive and join data
+//
+// If the Job fails to perform its task, Execute returns an error.
+// If the Job completes successfully, it returns nil.
+// DO NOT CALL Execute() TWICE. IT MUST BE PANIC
+func (a *AsOf) Execute() error {
+	defer close(a.late)
+	defer close(a.annotations)
+	defer close(a.out)
+	defer func() {
+		go chanutil.DummyChannelConsumer(a.refIn)
+		go chanutil.DummyChannelConsumer(a.modelIn)
+	}()
+
+	err := a.join()
+
+	// 짝을 찾지 못한 채 남은 reference packet을 센다.
+	for _, e := range a.matched {
+		if !e {
+			a.summary.UnmatchedRef++
+		}
+	}
+
+	a.annotations <- model.Packet{
+		Time: a.lastPoint,
+		Data: a.summary,
+	}
+	return err
+}
+
+func (a *AsOf) join() error {
+	refIn, modelIn := a.refIn, a.modelIn
+	for refIn != nil || modelIn != nil {
+		// block 정책에서는 버퍼가 가득 찬 입력을 받지 않는다.
+		refCh, modelCh := refIn, modelIn
+		if a.limit.blocks(len(a.refs)) {
+			refCh = nil
+		}
+		if a.limit.blocks(len(a.pending)) {
+			modelCh = nil
+		}
+		if refCh == nil && modelCh == nil {
+			return fmt.Errorf("join job: %w: both buffers are full of packets that may still be paired", ErrBufferFull)
+		}
+
+		select {
+		case p, ok := <-refCh:
+			if !ok {
+				refIn = nil
+				break
+			}
+
+			if model.IsWatermark(p) {
+				a.refClock.advance(p.Time)
+				break
+			}
+
+			a.lastPoint = maxTime(a.lastPoint, p.Time)
+			if !a.refClock.accept(p.Time) {
+				a.routeLate(RefInput, &a.refClock, p)
+				break
+			}
+
+			// 더 이상 model packet이 오지 않으면 버퍼에 쌓지 않는다.
+			if modelIn == nil && len(a.pending) == 0 {
+				a.summary.UnmatchedRef++
+				break
+			}
+
+			evict, err := a.limit.admit(len(a.refs))
+			if err != nil {
+				return fmt.Errorf("join job: reference input: %w", err)
+			}
+			if evict {
+				if !a.matched[0] {
+					a.summary.Evicted++
+				}
+				a.refs, a.matched = a.refs[1:], a.matched[1:]
+			}
+
+			var i int
+			a.refs, i = insertByTime(a.refs, p)
+			a.matched = slices.Insert(a.matched, i, false)
+		case p, ok := <-modelCh:
+			if !ok {
+				modelIn = nil
+				break
+			}
+
+			if model.IsWatermark(p) {
+				a.modelClock.advance(p.Time)
+				break
+			}
+
+			a.lastPoint = maxTime(a.lastPoint, p.Time)
+			if !a.modelClock.accept(p.Time) {
+				a.routeLate(ModelInput, &a.modelClock, p)
+				break
+			}
+
+			evict, err := a.limit.admit(len(a.pending))
+			if err != nil {
+				return fmt.Errorf("join job: model input: %w", err)
+			}
+			if evict {
+				a.pending = a.pending[1:]
+				a.summary.Evicted++
+			}
+
+			a.pending, _ = insertByTime(a.pending, p)
+		}
+
+		if err := a.pair(refIn == nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pair pairs the pending model packets whose reference packet is decided.
+func (a *AsOf) pair(refClosed bool) error {
+	// 버퍼가 더 이상 짝지어질 수 없는 reference packet으로 차지 않도록 한다.
+	defer a.prune()
+
+	for len(a.pending) > 0 {
+		m := a.pending[0]
+		// reference input의 watermark가 m.Time을 지나기 전에는 더 가까운 packet이 올 수 있다.
+		if !refClosed && !a.refClock.passed(m.Time) {
+			return nil
+		}
+		a.pending = a.pending[1:]
+
+		i := findLargestPacketIndexByTime(a.refs, m.Time)
+		if i >= 0 && m.Time.Sub(a.refs[i].Time) <= a.tolerance {
+			a.out <- model.Packet{
+				Time: m.Time,
+				Data: &model.Pair{
+					RefData:   a.refs[i].Data,
+					ModelData: m.Data,
+				},
+			}
+			a.matched[i] = true
+			a.summary.Joined++
+		} else {
+			a.summary.UnmatchedModel++
+			if a.unmatched == UnmatchedFail {
+				return fmt.Errorf("join job: %w: no reference packet within %s before %s", ErrUnmatched, a.tolerance, m.Time)
+			}
+		}
+	}
+	return nil
+}
+
+// prune discards the reference packets that no model packet can be paired with.
+// A model packet is paired with the latest reference packet at or before it,
+// and no model packet before the earliest pending one or the horizon of the model input can still arrive.
+func (a *AsOf) prune() {
+	t := a.modelClock.horizon()
+	if len(a.pending) > 0 && a.pending[0].Time.Before(t) {
+		t = a.pending[0].Time
+	}
+	a.discard(max(findLargestPacketIndexByTime(a.refs, t), 0))
+}
+
+// routeLate routes the late packet p arrived at the input to Late.
+func (a *AsOf) routeLate(input string, clock *eventClock, p model.Packet) {
+	a.summary.Late++
+	a.late <- clock.latePacket(input, p)
+}
+
+// discard removes the first n reference packets, counting those never paired.
+func (a *AsOf) discard(n int) {
+	for _, e := range a.matched[:n] {
+		if !e {
+			a.summary.UnmatchedRef++
+		}
+	}
+	a.refs = a.refs[n:]
+	a.matched = a.matched[n:]
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+func (a *AsOf) SetRefInput(in job.DataChan) {
+	a.refIn = in
+}
+
+func (a *AsOf) SetModelInput(in job.DataChan) {
+	a.modelIn = in
+}
+
+func (a *AsOf) Output() job.DataChan {
+	return a.out
+}
+
+func (a *AsOf) Annotations() job.DataChan {
+	return a.annotations
+}
+
+func (a *AsOf) Late() job.DataChan {
+	return a.late
+}
@@ -0,0 +1,137 @@
+package joiner_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/job/joiner"
+	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type AsOfTestSuite struct {
+	suite.Suite
+	start time.Time
+}
+
+func (suite *AsOfTestSuite) SetupTest() {
+	suite.start = time.Unix(1720396800, 0)
+}
+
+func (suite *AsOfTestSuite) at(seconds int) time.Time {
+	return suite.start.Add(time.Duration(seconds) * time.Second)
+}
+
+// packets creates packets whose Data is the seconds of their Time.
+func (suite *AsOfTestSuite) packets(seconds ...int) []model.Packet {
+	res := make([]model.Packet, len(seconds))
+	for i, e := range seconds {
+		res[i] = model.Packet{Time: suite.at(e), Data: e}
+	}
+	return res
+}
+
+// run joins the inputs sent concurrently and returns the pairs as [ref, model] seconds with the summary.
+func (suite *AsOfTestSuite) run(params job.UserParams, ref, modelIn []model.Packet) ([][2]int, model.JoinSummary, error) {
+	j, err := joiner.NewAsOf(&params)
+	suite.Require().NoError(err)
+
+	refChan := make(job.DataChan)
+	modelChan := make(job.DataChan)
+	go func() {
+		defer close(refChan)
+		for _, e := range ref {
+			refChan <- e
+		}
+	}()
+	go func() {
+		defer close(modelChan)
+		for _, e := range modelIn {
+			modelChan <- e
+		}
+	}()
+	j.SetRefInput(refChan)
+	j.SetModelInput(modelChan)
+
+	pairs := make([][2]int, 0)
+	var summary model.JoinSummary
+	wg := &sync.WaitGroup{}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for p := range j.Output() {
+			pair := p.Data.(*model.Pair)
+			suite.Equal(suite.at(pair.ModelData.(int)), p.Time)
+			pairs = append(pairs, [2]int{pair.RefData.(int), pair.ModelData.(int)})
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for p := range j.Annotations() {
+			summary = p.Data.(model.JoinSummary)
+		}
+	}()
+
+	err = j.Execute()
+	wg.Wait()
+	return pairs, summary, err
+}
+
+func (suite *AsOfTestSuite) TestAsOf_ShouldPairLatestReferenceWithinTolerance() {
+	for i := 0; i < 100; i++ {
+		//act
+		pairs, summary, err := suite.run(
+			job.UserParams{joiner.ToleranceParam: "2s"},
+			suite.packets(0, 3, 6, 10),
+			suite.packets(1, 4, 5, 9, 10),
+		)
+
+		//assert
+		suite.NoError(err)
+		suite.Equal([][2]int{{0, 1}, {3, 4}, {3, 5}, {10, 10}}, pairs)
+		suite.Equal(model.JoinSummary{Joined: 4, UnmatchedModel: 1, UnmatchedRef: 1}, summary)
+	}
+}
+
+func (suite *AsOfTestSuite) TestAsOf_ShouldPairOnlySameTime_WhenToleranceIsNotGiven() {
+	//act
+	pairs, summary, err := suite.run(job.UserParams{}, suite.packets(0, 1, 2), suite.packets(1, 3))
+
+	//assert
+	suite.NoError(err)
+	suite.Equal([][2]int{{1, 1}}, pairs)
+	suite.Equal(model.JoinSummary{Joined: 1, UnmatchedModel: 1, UnmatchedRef: 2}, summary)
+}
+
+func (suite *AsOfTestSuite) TestAsOf_ShouldReturnError_WhenModelPacketIsUnmatchedAndPolicyIsFail() {
+	//act
+	_, summary, err := suite.run(
+		job.UserParams{joiner.ToleranceParam: "1s", joiner.UnmatchedParam: joiner.UnmatchedFail},
+		suite.packets(0, 5, 6),
+		suite.packets(0, 3, 6),
+	)
+
+	//assert
+	suite.ErrorIs(err, joiner.ErrUnmatched)
+	suite.Equal(1, summary.UnmatchedModel)
+}
+
+func (suite *AsOfTestSuite) TestNewAsOf_ShouldReturnError_WhenParamsAreInvalid() {
+	for _, params := range []job.UserParams{
+		{joiner.ToleranceParam: "soon"},
+		{joiner.ToleranceParam: "-1s"},
+		{joiner.UnmatchedParam: "ignore"},
+	} {
+		//act
+		_, err := joiner.NewAsOf(&params)
+
+		//assert
+		suite.Error(err, params)
+	}
+}
+
+func TestAsOf(t *testing.T) {
+	suite.Run(t, new(AsOfTestSuite))
+}
@@ -0,0 +1,91 @@
+package joiner
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+)
+
+// User param keys
+const (
+	// MaxBufferParam is the maximum number of packets each input of a joiner buffers. Zero means unlimited.
+	MaxBufferParam = "join.maxBuffer"
+	// OverflowParam is the policy applied when a buffer of a joiner is full.
+	OverflowParam = "join.overflow"
+)
+
+const (
+	// OverflowBlock stops receiving from the input until its buffer has room.
+	// The other input must be able to progress without it, so the buffer must hold the lag between the inputs.
+	OverflowBlock = "block"
+	// OverflowDropOldest evicts the oldest packet of the buffer.
+	OverflowDropOldest = "dropOldest"
+	// OverflowFail fails the job.
+	OverflowFail = "fail"
+)
+
+var ErrBufferFull = errors.New("join: buffer is full")
+
+// bufferLimit is the cap of the buffers of a joiner and the policy applied when it is reached.
+type bufferLimit struct {
+	max      int
+	overflow string
+}
+
+// parseBufferLimit parses MaxBufferParam and OverflowParam. The policy defaults to OverflowBlock.
+func parseBufferLimit(params *job.UserParams) (bufferLimit, error) {
+	l := bufferLimit{overflow: OverflowBlock}
+
+	if !params.IsKeyNilOrEmpty(MaxBufferParam) {
+		val, err := strconv.Atoi((*params)[MaxBufferParam])
+		if err != nil {
+			return bufferLimit{}, err
+		}
+
+		if val < 0 {
+			return bufferLimit{}, fmt.Errorf("%s must not be negative", MaxBufferParam)
+		}
+
+		l.max = val
+	}
+
+	if !params.IsKeyNilOrEmpty(OverflowParam) {
+		switch policy := (*params)[OverflowParam]; policy {
+		case OverflowBlock, OverflowDropOldest, OverflowFail:
+			l.overflow = policy
+		default:
+			return bufferLimit{}, fmt.Errorf("unknown overflow policy %q", policy)
+		}
+	}
+
+	return l, nil
+}
+
+// full reports whether a buffer of n packets is full.
+func (l bufferLimit) full(n int) bool {
+	return l.max > 0 && n >= l.max
+}
+
+// blocks reports whether the input of a buffer of n packets must not be received.
+func (l bufferLimit) blocks(n int) bool {
+	return l.overflow == OverflowBlock && l.full(n)
+}
+
+// admit makes room for a new packet in a buffer of n packets.
+// It reports whether the oldest packet must be evicted, or returns ErrBufferFull if the policy is OverflowFail.
+func (l bufferLimit) admit(n int) (bool, error) {
+	if !l.full(n) {
+		return false, nil
+	}
+
+	switch l.overflow {
+	case OverflowDropOldest:
+		return true, nil
+	case OverflowFail:
+		return false, fmt.Errorf("%w: %d packets", ErrBufferFull, n)
+	default:
+		return false, nil
+	}
+}
@@ -1,26 +1,65 @@
 package joiner
 
 import (
-	"container/list"
+	"fmt"
 	"time"
 
 	"github.com/Goboolean/core-system.worker/internal/job"
 	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/Goboolean/core-system.worker/internal/util/chanutil"
 )
 
 // ByTime pairs reference data and model output data that share the same Time
 // and passes them as model.Pair objects.
+//
+// The inputs may be out of order within the allowed lateness.
+// The watermark of each input is taken from the model.Watermark packets of the input,
+// or from the latest Time of its packets if it sends no watermark.
+// A packet before the watermark of its input minus AllowedLatenessParam is late, and it is routed to Late.
+// A packet is unmatched once the watermark of the other input passes its Time, and it is evicted from the buffer.
+// The buffers are capped by MaxBufferParam, and OverflowParam decides what happens when a buffer is full.
+// When the inputs are closed, ByTime publishes a model.JoinSummary as an annotation.
 type ByTime struct {
-	refIn   job.DataChan
-	modelIn job.DataChan
-	out     job.DataChan
+	limit bufferLimit
+
+	refClock   eventClock
+	modelClock eventClock
+
+	summary   model.JoinSummary
+	lastPoint time.Time
+
+	refIn       job.DataChan
+	modelIn     job.DataChan
+	out         job.DataChan
+	annotations job.DataChan `type:"model.JoinSummary"`
+	late        job.DataChan `type:"*model.LatePacket"`
 }
 
 // NewByTime creates new instance of ByTime
+//
+// Params list:
+// AllowedLatenessParam: how long the packets behind the watermark are still joined. Defaults to 0.
+// MaxBufferParam: the maximum number of packets each input buffers. Defaults to 0, which is unlimited.
+// OverflowParam: the policy applied when a buffer is full. OverflowBlock|OverflowDropOldest|OverflowFail. Defaults to OverflowBlock.
 func NewByTime(params *job.UserParams) (*ByTime, error) {
 
+	limit, err := parseBufferLimit(params)
+	if err != nil {
+		return nil, fmt.Errorf("create join job: %w", err)
+	}
+
+	lateness, err := parseAllowedLateness(params)
+	if err != nil {
+		return nil, fmt.Errorf("create join job: %w", err)
+	}
+
 	instance := &ByTime{
-		out: make(job.DataChan),
+		limit:       limit,
+		refClock:    eventClock{lateness: lateness},
+		modelClock:  eventClock{lateness: lateness},
+		out:         make(job.DataChan),
+		annotations: make(job.DataChan, 1),
+		late:        make(job.DataChan),
 	}
 
 	return instance, nil
@@ -32,66 +71,145 @@ func NewByTime(params *job.UserParams) (*ByTime, error) {
 // If the Job completes successfully, it returns nil.
 // DO NOT CALL Execute() TWICE. IT MUST BE PANIC
 func (b *ByTime) Execute() error {
+	defer close(b.late)
+	defer close(b.annotations)
 	defer close(b.out)
+	defer func() {
+		go chanutil.DummyChannelConsumer(b.refIn)
+		go chanutil.DummyChannelConsumer(b.modelIn)
+	}()
 
-	referenceInputBuf := make([]model.Packet, 0, 100)
-	modelInputList := list.New()
+	err := b.join()
+
+	b.annotations <- model.Packet{
+		Time: b.lastPoint,
+		Data: b.summary,
+	}
+	return err
+}
 
-	refInChanClosed := false
-	modelInChanClosed := false
+func (b *ByTime) join() error {
+	refs := make([]model.Packet, 0, 100)
+	models := make([]model.Packet, 0, 100)
 
-	for {
-		if refInChanClosed && modelInChanClosed {
-			return nil
+	refIn, modelIn := b.refIn, b.modelIn
+	for refIn != nil || modelIn != nil {
+		// block 정책에서는 버퍼가 가득 찬 입력을 받지 않는다.
+		refCh, modelCh := refIn, modelIn
+		if b.limit.blocks(len(refs)) {
+			refCh = nil
+		}
+		if b.limit.blocks(len(models)) {
+			modelCh = nil
+		}
+		if refCh == nil && modelCh == nil {
+			return fmt.Errorf("join job: %w: both buffers are full of packets that may still be paired", ErrBufferFull)
 		}
 
+		var err error
 		select {
-		case referenceDataPacket, ok := <-b.refIn:
+		case p, ok := <-refCh:
 			if !ok {
-				refInChanClosed = true
-				continue
+				refIn = nil
+				break
 			}
 
-			referenceInputBuf = append(referenceInputBuf, referenceDataPacket)
-		case modelDataPacket, ok := <-b.modelIn:
+			refs, err = b.receive(RefInput, &b.refClock, refs, p)
+			if err != nil {
+				return fmt.Errorf("join job: reference input: %w", err)
+			}
+		case p, ok := <-modelCh:
 			if !ok {
-				modelInChanClosed = true
-				continue
+				modelIn = nil
+				break
 			}
 
-			modelInputList.PushBack(modelDataPacket)
-
-		}
-
-		for e := modelInputList.Front(); e != nil; e = e.Next() {
-			if len(referenceInputBuf) == 0 {
-				break
+			models, err = b.receive(ModelInput, &b.modelClock, models, p)
+			if err != nil {
+				return fmt.Errorf("join job: model input: %w", err)
 			}
-			location := findLargestPacketIndexByTime(referenceInputBuf, e.Value.(model.Packet).Time)
+		}
 
-			if referenceInputBuf[location].Time != e.Value.(model.Packet).Time {
+		for i := 0; i < len(models); {
+			t := models[i].Time
+			location := findLargestPacketIndexByTime(refs, t)
+			if location < 0 || !refs[location].Time.Equal(t) {
+				i++
 				continue
 			}
 
 			b.out <- model.Packet{
-				Time: e.Value.(model.Packet).Time,
+				Time: t,
 				Data: &model.Pair{
-					RefData:   referenceInputBuf[location].Data,
-					ModelData: e.Value.(model.Packet).Data,
+					RefData:   refs[location].Data,
+					ModelData: models[i].Data,
 				},
 			}
+			b.summary.Joined++
 
-			referenceInputBuf = referenceInputBuf[min(len(referenceInputBuf), location+1):]
-			modelInputList.Remove(e)
+			refs = append(refs[:location], refs[location+1:]...)
+			models = append(models[:i], models[i+1:]...)
 		}
 
+		// 상대 입력의 watermark가 지나간 시간의 packet은 더 이상 짝지어질 수 없으므로 버퍼에서 제거한다.
+		// 입력이 닫히면 상대 버퍼의 모든 packet이 짝을 잃는다.
+		n := evictable(models, refIn == nil, &b.refClock)
+		models = models[n:]
+		b.summary.UnmatchedModel += n
+
+		n = evictable(refs, modelIn == nil, &b.modelClock)
+		refs = refs[n:]
+		b.summary.UnmatchedRef += n
 	}
+	return nil
+}
+
+// receive inserts p into buf in the order of time, or routes it to Late if it is late.
+func (b *ByTime) receive(input string, clock *eventClock, buf []model.Packet, p model.Packet) ([]model.Packet, error) {
+	if model.IsWatermark(p) {
+		clock.advance(p.Time)
+		return buf, nil
+	}
+
+	b.lastPoint = maxTime(b.lastPoint, p.Time)
+	if !clock.accept(p.Time) {
+		b.summary.Late++
+		b.late <- clock.latePacket(input, p)
+		return buf, nil
+	}
+
+	evict, err := b.limit.admit(len(buf))
+	if err != nil {
+		return nil, err
+	}
+	if evict {
+		buf = buf[1:]
+		b.summary.Evicted++
+	}
+
+	buf, _ = insertByTime(buf, p)
+	return buf, nil
+}
+
+// evictable returns the number of the leading packets of buf that can no longer be paired
+// because the other input is closed or its watermark has passed them.
+func evictable(buf []model.Packet, otherClosed bool, other *eventClock) int {
+	if otherClosed {
+		return len(buf)
+	}
+
+	n := 0
+	for n < len(buf) && other.passed(buf[n].Time) {
+		n++
+	}
+	return n
 }
 
 // findLargestPacketIndexBySequence returns the index of the packet with the latest time
 // that is before or at the target time.
 // -1 means all element has time that is later than target
 // WARNING: TO BE USED ONLY WITH ARRAYS SORTED IN ASCENDING ORDER
+// The buffers of the joiners are kept sorted by insertByTime.
 func findLargestPacketIndexByTime(data []model.Packet, target time.Time) int {
 	// data는 순서가 보장돼 있고 대부분 앞 부분에 찾고자 하는 값이 있을 것이라
 	// 예상할 수 있으므로 순차탐색
@@ -131,3 +249,11 @@ func (b *ByTime) SetModelInput(in job.DataChan) {
 func (b *ByTime) Output() job.DataChan {
 	return b.out
 }
+
+func (b *ByTime) Annotations() job.DataChan {
+	return b.annotations
+}
+
+func (b *ByTime) Late() job.DataChan {
+	return b.late
+}
@@ -113,6 +113,153 @@ func (suite *ByTimeTestSuite) TestByTime_ShouldPackDataTogether_WhenDataFromRefe
 	}
 }
 
+// run joins the inputs sent concurrently and returns the number of the outputs and the summary.
+func (suite *ByTimeTestSuite) run(params job.UserParams, ref, modelIn []model.Packet) (int, model.JoinSummary, error) {
+	joinJob, err := joiner.NewByTime(&params)
+	suite.Require().NoError(err)
+
+	refChan := make(job.DataChan)
+	modelChan := make(job.DataChan)
+	go func() {
+		defer close(refChan)
+		for _, e := range ref {
+			refChan <- e
+		}
+	}()
+	go func() {
+		defer close(modelChan)
+		for _, e := range modelIn {
+			modelChan <- e
+		}
+	}()
+	joinJob.SetRefInput(refChan)
+	joinJob.SetModelInput(modelChan)
+
+	num := 0
+	var summary model.JoinSummary
+	wg := &sync.WaitGroup{}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for range joinJob.Output() {
+			num++
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for p := range joinJob.Annotations() {
+			summary = p.Data.(model.JoinSummary)
+		}
+	}()
+
+	err = joinJob.Execute()
+	wg.Wait()
+	return num, summary, err
+}
+
+func (suite *ByTimeTestSuite) TestByTime_ShouldEvictUnmatchedPackets_WhenStreamsDiverge() {
+	//arrange
+	start := time.Unix(1720396800, 0)
+	ref := make([]model.Packet, 0)
+	modelInput := make([]model.Packet, 0)
+	for i := 0; i < 100; i++ {
+		ref = append(ref, model.Packet{Time: start.Add(time.Duration(2*i) * time.Second), Data: 1})
+		modelInput = append(modelInput, model.Packet{Time: start.Add(time.Duration(2*i+1) * time.Second), Data: 2})
+	}
+	// 마지막 packet만 짝지어진다.
+	ref = append(ref, model.Packet{Time: start.Add(200 * time.Second), Data: 1})
+	modelInput = append(modelInput, model.Packet{Time: start.Add(200 * time.Second), Data: 2})
+
+	//act
+	// 버퍼가 가득 차도 짝지어질 수 없는 packet이 제거되므로 block되지 않아야 한다.
+	num, summary, err := suite.run(job.UserParams{joiner.MaxBufferParam: "2"}, ref, modelInput)
+
+	//assert
+	suite.NoError(err)
+	suite.Equal(1, num)
+	suite.Equal(model.JoinSummary{Joined: 1, UnmatchedModel: 100, UnmatchedRef: 100}, summary)
+}
+
+// overflow sends model packets that no reference packet passes and closes the inputs after every packet is received.
+func (suite *ByTimeTestSuite) overflow(params job.UserParams, num int) (model.JoinSummary, error) {
+	joinJob, err := joiner.NewByTime(&params)
+	suite.Require().NoError(err)
+
+	refChan := make(job.DataChan)
+	modelChan := make(job.DataChan)
+	joinJob.SetRefInput(refChan)
+	joinJob.SetModelInput(modelChan)
+
+	var summary model.JoinSummary
+	wg := &sync.WaitGroup{}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for range joinJob.Output() {
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for p := range joinJob.Annotations() {
+			summary = p.Data.(model.JoinSummary)
+		}
+	}()
+
+	go func() {
+		defer close(refChan)
+		defer close(modelChan)
+		start := time.Unix(1720396800, 0)
+		for i := 0; i < num; i++ {
+			select {
+			case modelChan <- model.Packet{Time: start.Add(time.Duration(i) * time.Second), Data: 2}:
+			case <-time.After(time.Second):
+				return
+			}
+		}
+	}()
+
+	err = joinJob.Execute()
+	wg.Wait()
+	return summary, err
+}
+
+func (suite *ByTimeTestSuite) TestByTime_ShouldEvictOldestPacket_WhenBufferIsFullAndPolicyIsDropOldest() {
+	//act
+	summary, err := suite.overflow(job.UserParams{
+		joiner.MaxBufferParam: "3",
+		joiner.OverflowParam:  joiner.OverflowDropOldest,
+	}, 10)
+
+	//assert
+	suite.NoError(err)
+	suite.Equal(model.JoinSummary{UnmatchedModel: 3, Evicted: 7}, summary)
+}
+
+func (suite *ByTimeTestSuite) TestByTime_ShouldReturnError_WhenBufferIsFullAndPolicyIsFail() {
+	//act
+	_, err := suite.overflow(job.UserParams{
+		joiner.MaxBufferParam: "3",
+		joiner.OverflowParam:  joiner.OverflowFail,
+	}, 10)
+
+	//assert
+	suite.ErrorIs(err, joiner.ErrBufferFull)
+}
+
+func (suite *ByTimeTestSuite) TestNewByTime_ShouldReturnError_WhenParamsAreInvalid() {
+	for _, params := range []job.UserParams{
+		{joiner.MaxBufferParam: "many"},
+		{joiner.MaxBufferParam: "-1"},
+		{joiner.OverflowParam: "ignore"},
+	} {
+		//act
+		_, err := joiner.NewByTime(&params)
+
+		//assert
+		suite.Error(err, params)
+	}
+}
+
 func TestByTime(t *testing.T) {
 	suite.Run(t, new(ByTimeTestSuite))
 }
@@ -0,0 +1,28 @@
+package joiner
+
+import (
+	"fmt"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+)
+
+// jobProvider is a type alias for a function
+// that creates a Joiner based on job.UserParams
+type jobProvider func(p *job.UserParams) (Joiner, error)
+
+// Create generates an appropriate joiner based on the given spec.
+// Create passes userParam to the job during this process
+func Create(spec Spec, p *job.UserParams) (Joiner, error) {
+
+	var provider, ok = providerRepo[spec]
+	if !ok {
+		return nil, fmt.Errorf("create join job: %w", job.ErrNotFoundJob)
+	}
+
+	j, err := provider(p)
+	if err != nil {
+		return nil, fmt.Errorf("create join job: %w", err)
+	}
+
+	return j, nil
+}
@@ -18,3 +18,16 @@ type Joiner interface {
 	// Output returns the output data channel for the joiner.
 	Output() job.DataChan
 }
+
+// MultiJoiner is an interface for the joiners that join any number of named inputs into model.Tuple objects.
+type MultiJoiner interface {
+	job.Common
+
+	// AddInput adds a named input data channel for the joiner.
+	// The name is the key of the data of the input in model.Tuple.
+	// It replaces the input of the same name if there is one.
+	AddInput(name string, in job.DataChan)
+
+	// Output returns the output data channel for the joiner.
+	Output() job.DataChan
+}
@@ -0,0 +1,499 @@
+package joiner
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/Goboolean/core-system.worker/internal/util/chanutil"
+)
+
+// User param keys
+const (
+	// StrategyParam is how Multi joins its inputs. StrategyInner|StrategyLeft|StrategyAsOf
+	StrategyParam = "join.strategy"
+	// RefInputParam is the name of the reference input of Multi.
+	RefInputParam = "join.ref"
+)
+
+const (
+	// StrategyInner emits a tuple only at the points in time that every input has a packet at.
+	StrategyInner = "inner"
+	// StrategyLeft emits a tuple at every reference packet with the packets of the other inputs at the same Time.
+	StrategyLeft = "left"
+	// StrategyAsOf emits a tuple at every reference packet with the latest packets of the other inputs
+	// at or before it within the tolerance.
+	StrategyAsOf = "asOf"
+)
+
+// DefaultRefInput is the name of the reference input when RefInputParam is not given.
+const DefaultRefInput = RefInput
+
+// multiInput is a named input of Multi and the packets buffered from it.
+type multiInput struct {
+	name   string
+	in     job.DataChan
+	buf    []model.Packet
+	closed bool
+	clock  eventClock
+
+	// latest is the latest packet at or before the current reference packet. It is used by StrategyAsOf.
+	// used reports whether latest has been joined into a tuple.
+	latest *model.Packet
+	used   bool
+}
+
+// Multi joins any number of named inputs and passes a model.Tuple object per point in time.
+// The strategy decides which points in time are emitted and which packets they hold.
+//
+// Every input must have at most one packet at a point in time, but it may be out of order within the allowed lateness.
+// The watermark of each input is taken from the model.Watermark packets of the input,
+// or from the latest Time of its packets if it sends no watermark.
+// A packet before the watermark of its input minus AllowedLatenessParam is late, and it is routed to Late.
+// A point in time is joined only after the watermark of every input reaches it.
+// The buffers are capped by MaxBufferParam, and OverflowParam decides what happens when a buffer is full.
+// Multi is also a Joiner, so that a pipeline can join the reference data and the model output with more inputs.
+// When the inputs are closed, Multi publishes a model.JoinSummary as an annotation:
+// Joined counts the tuples, and the unmatched packets of the reference input and the other inputs
+// are counted as UnmatchedRef and UnmatchedModel.
+type Multi struct {
+	strategy  string
+	refName   string
+	tolerance time.Duration
+	lateness  time.Duration
+	limit     bufferLimit
+
+	inputs []*multiInput
+
+	summary   model.JoinSummary
+	lastPoint time.Time
+
+	out         job.DataChan //Job은 자신의 Output 채널에 대해 소유권을 가진다.
+	annotations job.DataChan `type:"model.JoinSummary"`
+	late        job.DataChan `type:"*model.LatePacket"`
+}
+
+// NewMulti creates new instance of Multi
+//
+// Params list:
+// StrategyParam: how the inputs are joined. StrategyInner|StrategyLeft|StrategyAsOf. Defaults to StrategyInner.
+// RefInputParam: the name of the reference input. Defaults to DefaultRefInput.
+// ToleranceParam: the maximum distance from a reference packet back to the packets of the other inputs. Used by StrategyAsOf. Defaults to 0.
+// AllowedLatenessParam: how long the packets behind the watermark are still joined. Defaults to 0.
+// MaxBufferParam: the maximum number of packets each input buffers. Defaults to 0, which is unlimited.
+// OverflowParam: the policy applied when a buffer is full. OverflowBlock|OverflowDropOldest|OverflowFail. Defaults to OverflowBlock.
+func NewMulti(params *job.UserParams) (*Multi, error) {
+	limit, err := parseBufferLimit(params)
+	if err != nil {
+		return nil, fmt.Errorf("create join job: %w", err)
+	}
+
+	lateness, err := parseAllowedLateness(params)
+	if err != nil {
+		return nil, fmt.Errorf("create join job: %w", err)
+	}
+
+	instance := &Multi{
+		strategy:    StrategyInner,
+		refName:     DefaultRefInput,
+		lateness:    lateness,
+		limit:       limit,
+		out:         make(job.DataChan),
+		annotations: make(job.DataChan, 1),
+		late:        make(job.DataChan),
+	}
+
+	if !params.IsKeyNilOrEmpty(StrategyParam) {
+		switch strategy := (*params)[StrategyParam]; strategy {
+		case StrategyInner, StrategyLeft, StrategyAsOf:
+			instance.strategy = strategy
+		default:
+			return nil, fmt.Errorf("create join job: unknown strategy %q", strategy)
+		}
+	}
+
+	if !params.IsKeyNilOrEmpty(RefInputParam) {
+		instance.refName = (*params)[RefInputParam]
+	}
+
+	if !params.IsKeyNilOrEmpty(ToleranceParam) {
+		val, err := time.ParseDuration((*params)[ToleranceParam])
+		if err != nil {
+			return nil, fmt.Errorf("create join job: %w", err)
+		}
+
+		if val < 0 {
+			return nil, fmt.Errorf("create join job: %s must not be negative", ToleranceParam)
+		}
+
+		instance.tolerance = val
+	}
+
+	return instance, nil
+}
+
+// Execute starts to receive and join data
+//
+// If the Job fails to perform its task, Execute returns an error.
+// If the Job completes successfully, it returns nil.
+// DO NOT CALL Execute() TWICE. IT MUST BE PANIC
+func (m *Multi) Execute() error {
+	defer close(m.late)
+	defer close(m.annotations)
+	defer close(m.out)
+	defer func() {
+		for _, e := range m.inputs {
+			go chanutil.DummyChannelConsumer(e.in)
+		}
+	}()
+
+	if err := m.validate(); err != nil {
+		return err
+	}
+
+	err := m.join()
+
+	// 튜플에 포함되지 못한 채 남은 packet을 센다.
+	for _, e := range m.inputs {
+		m.countUnmatched(e, len(e.buf))
+		if e.latest != nil && !e.used {
+			m.countUnmatched(e, 1)
+		}
+	}
+
+	m.annotations <- model.Packet{
+		Time: m.lastPoint,
+		Data: m.summary,
+	}
+	return err
+}
+
+func (m *Multi) validate() error {
+	for _, e := range m.inputs {
+		if e.in == nil {
+			return fmt.Errorf("join job: input %q is nil", e.name)
+		}
+	}
+
+	if len(m.inputs) < 2 {
+		return fmt.Errorf("join job: at least 2 inputs are required, got %d", len(m.inputs))
+	}
+	if m.strategy != StrategyInner && m.ref() == nil {
+		return fmt.Errorf("join job: reference input %q is not added", m.refName)
+	}
+	return nil
+}
+
+func (m *Multi) join() error {
+	for {
+		// block 정책에서는 버퍼가 가득 찬 입력을 받지 않는다.
+		cases := make([]reflect.SelectCase, 0, len(m.inputs))
+		receiving := make([]*multiInput, 0, len(m.inputs))
+		open := false
+		for _, e := range m.inputs {
+			if e.closed {
+				continue
+			}
+			open = true
+
+			if m.limit.blocks(len(e.buf)) {
+				continue
+			}
+			cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(e.in)})
+			receiving = append(receiving, e)
+		}
+
+		if !open {
+			return nil
+		}
+		if len(cases) == 0 {
+			return fmt.Errorf("join job: %w: every buffer is full of packets that may still be joined", ErrBufferFull)
+		}
+
+		chosen, v, ok := reflect.Select(cases)
+		e := receiving[chosen]
+		if !ok {
+			e.closed = true
+		} else if err := m.receive(e, v.Interface().(model.Packet)); err != nil {
+			return fmt.Errorf("join job: input %q: %w", e.name, err)
+		}
+
+		switch m.strategy {
+		case StrategyInner:
+			m.joinInner()
+		case StrategyLeft:
+			m.joinLeft()
+		case StrategyAsOf:
+			m.joinAsOf()
+		}
+	}
+}
+
+// receive inserts p into the buffer of the input in the order of time, or routes it to Late if it is late.
+func (m *Multi) receive(e *multiInput, p model.Packet) error {
+	if model.IsWatermark(p) {
+		e.clock.advance(p.Time)
+		return nil
+	}
+
+	m.lastPoint = maxTime(m.lastPoint, p.Time)
+	if !e.clock.accept(p.Time) {
+		m.summary.Late++
+		m.late <- e.clock.latePacket(e.name, p)
+		return nil
+	}
+
+	evict, err := m.limit.admit(len(e.buf))
+	if err != nil {
+		return err
+	}
+	if evict {
+		e.buf = e.buf[1:]
+		m.summary.Evicted++
+	}
+
+	e.buf, _ = insertByTime(e.buf, p)
+	return nil
+}
+
+// decided reports whether the input can no longer send a packet at or before t that is not late.
+func (e *multiInput) decided(t time.Time) bool {
+	if e.closed || e.clock.passed(t) {
+		return true
+	}
+
+	// t 이전의 packet은 늦은 packet이 되므로, t의 packet을 이미 받았는지만 확인한다.
+	if !e.clock.horizon().Equal(t) {
+		return false
+	}
+	if e.latest != nil && e.latest.Time.Equal(t) {
+		return true
+	}
+	for _, p := range e.buf {
+		if !p.Time.Before(t) {
+			return p.Time.Equal(t)
+		}
+	}
+	return false
+}
+
+// joinInner emits the earliest buffered point in time while every input is decided about it.
+func (m *Multi) joinInner() {
+	for {
+		var t time.Time
+		found := false
+		for _, e := range m.inputs {
+			if len(e.buf) > 0 && (!found || e.buf[0].Time.Before(t)) {
+				t, found = e.buf[0].Time, true
+			}
+		}
+		if !found {
+			return
+		}
+
+		for _, e := range m.inputs {
+			if !e.decided(t) {
+				return
+			}
+		}
+
+		tuple := make(model.Tuple, len(m.inputs))
+		for _, e := range m.inputs {
+			if len(e.buf) > 0 && e.buf[0].Time.Equal(t) {
+				tuple[e.name] = e.buf[0].Data
+			}
+		}
+
+		for _, e := range m.inputs {
+			if len(e.buf) > 0 && e.buf[0].Time.Equal(t) {
+				e.buf = e.buf[1:]
+				if len(tuple) != len(m.inputs) {
+					m.countUnmatched(e, 1)
+				}
+			}
+		}
+
+		if len(tuple) == len(m.inputs) {
+			m.emit(t, tuple)
+		}
+	}
+}
+
+// joinLeft emits the reference packets with the packets of the other inputs at the same Time.
+func (m *Multi) joinLeft() {
+	ref := m.ref()
+	for len(ref.buf) > 0 {
+		t := ref.buf[0].Time
+
+		// t 이전의 reference packet이 아직 올 수 있으면 기다린다.
+		if !ref.decided(t) {
+			return
+		}
+
+		for _, e := range m.others() {
+			// 이후의 reference packet과 만날 수 없는 packet은 버린다.
+			n := 0
+			for n < len(e.buf) && e.buf[n].Time.Before(t) {
+				n++
+			}
+			e.buf = e.buf[n:]
+			m.countUnmatched(e, n)
+
+			if !e.decided(t) {
+				return
+			}
+		}
+
+		tuple := model.Tuple{ref.name: ref.buf[0].Data}
+		ref.buf = ref.buf[1:]
+		for _, e := range m.others() {
+			if len(e.buf) > 0 && e.buf[0].Time.Equal(t) {
+				tuple[e.name] = e.buf[0].Data
+				e.buf = e.buf[1:]
+			}
+		}
+		m.emit(t, tuple)
+	}
+
+	m.dropIfRefDone()
+}
+
+// joinAsOf emits the reference packets with the latest packets of the other inputs at or before them within the tolerance.
+func (m *Multi) joinAsOf() {
+	ref := m.ref()
+	for len(ref.buf) > 0 {
+		t := ref.buf[0].Time
+
+		// t 이전의 reference packet이 아직 올 수 있으면 기다린다.
+		if !ref.decided(t) {
+			return
+		}
+
+		for _, e := range m.others() {
+			n := 0
+			for n < len(e.buf) && !e.buf[n].Time.After(t) {
+				p := e.buf[n]
+				n++
+
+				// latest보다 늦게 도착한 이전 시간의 packet은 더 가까운 packet이 있으므로 사용하지 않는다.
+				if e.latest != nil && p.Time.Before(e.latest.Time) {
+					m.countUnmatched(e, 1)
+					continue
+				}
+				if e.latest != nil && !e.used {
+					m.countUnmatched(e, 1)
+				}
+				e.latest, e.used = &p, false
+			}
+			e.buf = e.buf[n:]
+
+			// watermark가 t에 도달하기 전에는 더 가까운 packet이 올 수 있다.
+			if !e.decided(t) {
+				return
+			}
+		}
+
+		tuple := model.Tuple{ref.name: ref.buf[0].Data}
+		ref.buf = ref.buf[1:]
+		for _, e := range m.others() {
+			if e.latest != nil && t.Sub(e.latest.Time) <= m.tolerance {
+				tuple[e.name] = e.latest.Data
+				e.used = true
+			}
+		}
+		m.emit(t, tuple)
+	}
+
+	m.dropIfRefDone()
+}
+
+// dropIfRefDone drops the packets of the other inputs once no reference packet can arrive,
+// so that they do not occupy the buffers.
+func (m *Multi) dropIfRefDone() {
+	ref := m.ref()
+	if !ref.closed || len(ref.buf) > 0 {
+		return
+	}
+
+	for _, e := range m.others() {
+		m.countUnmatched(e, len(e.buf))
+		e.buf = nil
+	}
+}
+
+func (m *Multi) emit(t time.Time, tuple model.Tuple) {
+	m.out <- model.Packet{
+		Time: t,
+		Data: tuple,
+	}
+	m.summary.Joined++
+}
+
+func (m *Multi) countUnmatched(e *multiInput, n int) {
+	if e.name == m.refName {
+		m.summary.UnmatchedRef += n
+	} else {
+		m.summary.UnmatchedModel += n
+	}
+}
+
+func (m *Multi) ref() *multiInput {
+	for _, e := range m.inputs {
+		if e.name == m.refName {
+			return e
+		}
+	}
+	return nil
+}
+
+func (m *Multi) others() []*multiInput {
+	res := make([]*multiInput, 0, len(m.inputs)-1)
+	for _, e := range m.inputs {
+		if e.name != m.refName {
+			res = append(res, e)
+		}
+	}
+	return res
+}
+
+// AddInput adds the input of the name.
+// It replaces the input of the same name if there is one.
+func (m *Multi) AddInput(name string, in job.DataChan) {
+	for _, e := range m.inputs {
+		if e.name == name {
+			e.in = in
+			return
+		}
+	}
+	m.inputs = append(m.inputs, &multiInput{
+		name:  name,
+		in:    in,
+		clock: eventClock{lateness: m.lateness},
+	})
+}
+
+// SetRefInput sets the reference input, whose name is given by RefInputParam.
+// It replaces the input of the same name if there is one.
+func (m *Multi) SetRefInput(in job.DataChan) {
+	m.AddInput(m.refName, in)
+}
+
+// SetModelInput sets the input named ModelInput.
+// It replaces the input of the same name if there is one.
+func (m *Multi) SetModelInput(in job.DataChan) {
+	m.AddInput(ModelInput, in)
+}
+
+func (m *Multi) Output() job.DataChan {
+	return m.out
+}
+
+func (m *Multi) Annotations() job.DataChan {
+	return m.annotations
+}
+
+func (m *Multi) Late() job.DataChan {
+	return m.late
+}
@@ -0,0 +1,334 @@
+package joiner_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/job/joiner"
+	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type MultiTestSuite struct {
+	suite.Suite
+	start time.Time
+}
+
+func (suite *MultiTestSuite) SetupTest() {
+	suite.start = time.Unix(1720396800, 0)
+}
+
+func (suite *MultiTestSuite) at(seconds int) time.Time {
+	return suite.start.Add(time.Duration(seconds) * time.Second)
+}
+
+// packets creates packets whose Data is the seconds of their Time.
+func (suite *MultiTestSuite) packets(seconds ...int) []model.Packet {
+	res := make([]model.Packet, len(seconds))
+	for i, e := range seconds {
+		res[i] = model.Packet{Time: suite.at(e), Data: e}
+	}
+	return res
+}
+
+type namedInput struct {
+	name    string
+	packets []model.Packet
+}
+
+func (suite *MultiTestSuite) watermark(seconds int) model.Packet {
+	return model.Packet{Time: suite.at(seconds), Data: model.Watermark{}}
+}
+
+// run joins the inputs sent concurrently and returns the tuples, the late packets and the summary.
+func (suite *MultiTestSuite) run(params job.UserParams, inputs ...namedInput) ([]model.Tuple, []*model.LatePacket, model.JoinSummary, error) {
+	j, err := joiner.NewMulti(&params)
+	suite.Require().NoError(err)
+
+	for _, e := range inputs {
+		ch := make(job.DataChan)
+		go func(packets []model.Packet) {
+			defer close(ch)
+			for _, p := range packets {
+				ch <- p
+			}
+		}(e.packets)
+		j.AddInput(e.name, ch)
+	}
+
+	tuples := make([]model.Tuple, 0)
+	late := make([]*model.LatePacket, 0)
+	var summary model.JoinSummary
+	wg := &sync.WaitGroup{}
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		for p := range j.Output() {
+			tuples = append(tuples, p.Data.(model.Tuple))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for p := range j.Late() {
+			late = append(late, p.Data.(*model.LatePacket))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for p := range j.Annotations() {
+			summary = p.Data.(model.JoinSummary)
+		}
+	}()
+
+	err = j.Execute()
+	wg.Wait()
+	return tuples, late, summary, err
+}
+
+func (suite *MultiTestSuite) TestMulti_ShouldEmitOnlyCommonTimes_WhenStrategyIsInner() {
+	for i := 0; i < 100; i++ {
+		//act
+		tuples, _, summary, err := suite.run(
+			job.UserParams{},
+			namedInput{"ref", suite.packets(0, 1, 2, 3)},
+			namedInput{"a", suite.packets(1, 2, 3)},
+			namedInput{"b", suite.packets(0, 1, 3, 4)},
+		)
+
+		//assert
+		suite.NoError(err)
+		suite.Equal([]model.Tuple{
+			{"ref": 1, "a": 1, "b": 1},
+			{"ref": 3, "a": 3, "b": 3},
+		}, tuples)
+		suite.Equal(model.JoinSummary{Joined: 2, UnmatchedRef: 2, UnmatchedModel: 3}, summary)
+	}
+}
+
+func (suite *MultiTestSuite) TestMulti_ShouldEmitEveryReference_WhenStrategyIsLeft() {
+	for i := 0; i < 100; i++ {
+		//act
+		tuples, _, summary, err := suite.run(
+			job.UserParams{joiner.StrategyParam: joiner.StrategyLeft, joiner.RefInputParam: "bar"},
+			namedInput{"model1", suite.packets(1, 2, 5)},
+			namedInput{"bar", suite.packets(0, 1, 2, 3)},
+			namedInput{"model2", suite.packets(0, 2)},
+		)
+
+		//assert
+		suite.NoError(err)
+		suite.Equal([]model.Tuple{
+			{"bar": 0, "model2": 0},
+			{"bar": 1, "model1": 1},
+			{"bar": 2, "model1": 2, "model2": 2},
+			{"bar": 3},
+		}, tuples)
+		suite.Equal(model.JoinSummary{Joined: 4, UnmatchedModel: 1}, summary)
+	}
+}
+
+func (suite *MultiTestSuite) TestMulti_ShouldEmitLatestWithinTolerance_WhenStrategyIsAsOf() {
+	for i := 0; i < 100; i++ {
+		//act
+		tuples, _, summary, err := suite.run(
+			job.UserParams{joiner.StrategyParam: joiner.StrategyAsOf, joiner.ToleranceParam: "2s"},
+			namedInput{"ref", suite.packets(0, 3, 6, 10)},
+			namedInput{"news", suite.packets(1, 2, 7)},
+			namedInput{"fundamental", suite.packets(0)},
+		)
+
+		//assert
+		suite.NoError(err)
+		suite.Equal([]model.Tuple{
+			{"ref": 0, "fundamental": 0},
+			{"ref": 3, "news": 2},
+			{"ref": 6},
+			{"ref": 10},
+		}, tuples)
+		suite.Equal(model.JoinSummary{Joined: 4, UnmatchedModel: 2}, summary)
+	}
+}
+
+func (suite *MultiTestSuite) TestMulti_ShouldJoinOutOfOrderPackets_WhenTheyAreWithinAllowedLateness() {
+	for i := 0; i < 100; i++ {
+		//act
+		tuples, late, summary, err := suite.run(
+			job.UserParams{joiner.AllowedLatenessParam: "2s"},
+			namedInput{"ref", suite.packets(0, 2, 1, 3)},
+			namedInput{"a", suite.packets(1, 0, 2, 3)},
+		)
+
+		//assert
+		suite.NoError(err)
+		suite.Equal([]model.Tuple{
+			{"ref": 0, "a": 0},
+			{"ref": 1, "a": 1},
+			{"ref": 2, "a": 2},
+			{"ref": 3, "a": 3},
+		}, tuples)
+		suite.Empty(late)
+		suite.Equal(model.JoinSummary{Joined: 4}, summary)
+	}
+}
+
+func (suite *MultiTestSuite) TestMulti_ShouldRouteLatePacketToSideOutput_WhenItIsBehindWatermark() {
+	for i := 0; i < 100; i++ {
+		//act
+		tuples, late, summary, err := suite.run(
+			job.UserParams{joiner.StrategyParam: joiner.StrategyLeft},
+			namedInput{"ref", suite.packets(0, 2, 1, 3)},
+			namedInput{"a", suite.packets(0, 1, 2, 3)},
+		)
+
+		//assert
+		suite.NoError(err)
+		suite.Equal([]model.Tuple{
+			{"ref": 0, "a": 0},
+			{"ref": 2, "a": 2},
+			{"ref": 3, "a": 3},
+		}, tuples)
+		suite.Equal([]*model.LatePacket{{Input: "ref", Watermark: suite.at(2), Data: 1}}, late)
+		suite.Equal(model.JoinSummary{Joined: 3, UnmatchedModel: 1, Late: 1}, summary)
+	}
+}
+
+func (suite *MultiTestSuite) TestMulti_ShouldWaitForWatermark_WhenInputSendsWatermarkPackets() {
+	for i := 0; i < 100; i++ {
+		//arrange
+		// watermark를 보내는 입력은 watermark 이후의 packet을 순서와 관계없이 보낼 수 있다.
+		a := []model.Packet{
+			suite.watermark(0),
+			{Time: suite.at(1), Data: 1},
+			{Time: suite.at(0), Data: 0},
+			suite.watermark(1),
+			{Time: suite.at(2), Data: 2},
+			suite.watermark(5),
+		}
+
+		//act
+		tuples, late, summary, err := suite.run(
+			job.UserParams{joiner.StrategyParam: joiner.StrategyAsOf},
+			namedInput{"ref", suite.packets(0, 1, 2)},
+			namedInput{"a", a},
+		)
+
+		//assert
+		suite.NoError(err)
+		suite.Equal([]model.Tuple{
+			{"ref": 0, "a": 0},
+			{"ref": 1, "a": 1},
+			{"ref": 2, "a": 2},
+		}, tuples)
+		suite.Empty(late)
+		suite.Equal(model.JoinSummary{Joined: 3}, summary)
+	}
+}
+
+func (suite *MultiTestSuite) TestCreate_ShouldJoinRefAndModelInputs_WhenModeIsMulti() {
+	//arrange
+	j, err := joiner.Create(joiner.Spec{Mode: joiner.ModeMulti}, &job.UserParams{})
+	suite.Require().NoError(err)
+
+	send := func(packets []model.Packet) job.DataChan {
+		ch := make(job.DataChan)
+		go func() {
+			defer close(ch)
+			for _, p := range packets {
+				ch <- p
+			}
+		}()
+		return ch
+	}
+
+	j.SetRefInput(send(suite.packets(0, 1)))
+	// 같은 이름의 입력을 다시 설정하면 이전 입력을 대체한다.
+	j.SetModelInput(make(job.DataChan))
+	j.SetModelInput(send(suite.packets(0, 1)))
+
+	go func() {
+		for range j.(job.AnnotationEmitter).Annotations() {
+		}
+	}()
+	go func() {
+		for range j.(job.LateEmitter).Late() {
+		}
+	}()
+
+	//act
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- j.Execute()
+	}()
+
+	tuples := make([]model.Tuple, 0)
+	for p := range j.Output() {
+		tuples = append(tuples, p.Data.(model.Tuple))
+	}
+
+	//assert
+	suite.NoError(<-errChan)
+	suite.Equal([]model.Tuple{
+		{joiner.RefInput: 0, joiner.ModelInput: 0},
+		{joiner.RefInput: 1, joiner.ModelInput: 1},
+	}, tuples)
+}
+
+func (suite *MultiTestSuite) TestMulti_ShouldReplaceInput_WhenNameIsAddedAgain() {
+	//act
+	tuples, _, _, err := suite.run(
+		job.UserParams{},
+		namedInput{"ref", suite.packets(0, 1)},
+		namedInput{"a", suite.packets()},
+		namedInput{"a", suite.packets(0, 1)},
+	)
+
+	//assert
+	suite.NoError(err)
+	suite.Equal([]model.Tuple{
+		{"ref": 0, "a": 0},
+		{"ref": 1, "a": 1},
+	}, tuples)
+}
+
+func (suite *MultiTestSuite) TestMulti_ShouldReturnError_WhenInputsAreInvalid() {
+	for name, tc := range map[string]struct {
+		params job.UserParams
+		names  []string
+	}{
+		"single input":      {job.UserParams{}, []string{"ref"}},
+		"missing reference": {job.UserParams{joiner.StrategyParam: joiner.StrategyLeft}, []string{"a", "b"}},
+	} {
+		//arrange
+		inputs := make([]namedInput, len(tc.names))
+		for i, e := range tc.names {
+			inputs[i] = namedInput{e, suite.packets(0)}
+		}
+
+		//act
+		_, _, _, err := suite.run(tc.params, inputs...)
+
+		//assert
+		suite.Error(err, name)
+	}
+}
+
+func (suite *MultiTestSuite) TestNewMulti_ShouldReturnError_WhenParamsAreInvalid() {
+	for _, params := range []job.UserParams{
+		{joiner.StrategyParam: "outer"},
+		{joiner.ToleranceParam: "-1s"},
+		{joiner.AllowedLatenessParam: "-1s"},
+		{joiner.MaxBufferParam: "many"},
+	} {
+		//act
+		_, err := joiner.NewMulti(&params)
+
+		//assert
+		suite.Error(err, params)
+	}
+}
+
+func TestMulti(t *testing.T) {
+	suite.Run(t, new(MultiTestSuite))
+}
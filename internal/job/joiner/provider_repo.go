@@ -0,0 +1,15 @@
+package joiner
+
+import "github.com/Goboolean/core-system.worker/internal/job"
+
+var providerRepo = map[Spec]jobProvider{
+	{Mode: ModeExact}: func(p *job.UserParams) (Joiner, error) {
+		return NewByTime(p)
+	},
+	{Mode: ModeAsOf}: func(p *job.UserParams) (Joiner, error) {
+		return NewAsOf(p)
+	},
+	{Mode: ModeMulti}: func(p *job.UserParams) (Joiner, error) {
+		return NewMulti(p)
+	},
+}
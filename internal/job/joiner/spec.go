@@ -0,0 +1,16 @@
+package joiner
+
+const (
+	// ModeExact pairs the packets that share the same Time.
+	ModeExact = "exact"
+	// ModeAsOf pairs each model packet with the latest reference packet at or before it.
+	ModeAsOf = "asOf"
+	// ModeMulti joins the reference, the model and any number of additional named inputs into model.Tuple objects.
+	ModeMulti = "multi"
+)
+
+// Spec is the trait that distinguishes the joiners.
+type Spec struct {
+	// Mode is how the packets are paired. ModeExact|ModeAsOf|ModeMulti
+	Mode string
+}
@@ -0,0 +1,108 @@
+package joiner
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/model"
+)
+
+// User param keys
+const (
+	// AllowedLatenessParam is how long a joiner keeps waiting for the packets behind the watermark of their input. Example: "5s"
+	AllowedLatenessParam = "join.allowedLateness"
+)
+
+// Names of the inputs of the joiners pairing the model outputs with the reference data.
+// They are used as model.LatePacket.Input.
+const (
+	RefInput   = "ref"
+	ModelInput = "model"
+)
+
+// eventClock tracks the progress of event time of an input of a joiner.
+//
+// The watermark is the latest model.Watermark received from the input.
+// If the input sends no watermark, the latest Time of its packets is used instead.
+// The packets before the watermark minus the allowed lateness are late.
+type eventClock struct {
+	lateness  time.Duration
+	watermark time.Time
+	explicit  bool
+}
+
+func parseAllowedLateness(params *job.UserParams) (time.Duration, error) {
+	if params.IsKeyNilOrEmpty(AllowedLatenessParam) {
+		return 0, nil
+	}
+
+	val, err := time.ParseDuration((*params)[AllowedLatenessParam])
+	if err != nil {
+		return 0, err
+	}
+
+	if val < 0 {
+		return 0, fmt.Errorf("%s must not be negative", AllowedLatenessParam)
+	}
+	return val, nil
+}
+
+// advance advances the watermark by the watermark packet received from the input.
+func (c *eventClock) advance(watermark time.Time) {
+	c.explicit = true
+	c.watermark = maxTime(c.watermark, watermark)
+}
+
+// accept reports whether the data packet at t is on time, and advances the clock by it if so.
+func (c *eventClock) accept(t time.Time) bool {
+	if c.passed(t) {
+		return false
+	}
+
+	if !c.explicit {
+		c.watermark = maxTime(c.watermark, t)
+	}
+	return true
+}
+
+// passed reports whether the packets at t arriving from now on are late.
+func (c *eventClock) passed(t time.Time) bool {
+	return !c.watermark.IsZero() && t.Before(c.watermark.Add(-c.lateness))
+}
+
+// horizon returns the earliest Time of the packets that are not late.
+func (c *eventClock) horizon() time.Time {
+	if c.watermark.IsZero() {
+		return time.Time{}
+	}
+	return c.watermark.Add(-c.lateness)
+}
+
+// latePacket wraps p arrived at the input into the packet of the side output.
+func (c *eventClock) latePacket(input string, p model.Packet) model.Packet {
+	return model.Packet{
+		Time: p.Time,
+		Data: &model.LatePacket{
+			Input:     input,
+			Watermark: c.watermark,
+			Data:      p.Data,
+		},
+	}
+}
+
+// insertByTime inserts p into buf sorted by Time after the packets at the same Time.
+// It returns the index of p.
+func insertByTime(buf []model.Packet, p model.Packet) ([]model.Packet, int) {
+	// 대부분의 packet은 순서대로 도착하므로 뒤에서부터 찾는다.
+	i := len(buf)
+	if i > 0 && p.Time.Before(buf[i-1].Time) {
+		i = sort.Search(len(buf), func(j int) bool { return buf[j].Time.After(p.Time) })
+	}
+
+	buf = append(buf, model.Packet{})
+	copy(buf[i+1:], buf[i:])
+	buf[i] = p
+	return buf, i
+}
@@ -0,0 +1,174 @@
+package joiner_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/job/joiner"
+	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type LateTestSuite struct {
+	suite.Suite
+	start time.Time
+}
+
+func (suite *LateTestSuite) SetupTest() {
+	suite.start = time.Unix(1720396800, 0)
+}
+
+func (suite *LateTestSuite) at(seconds int) time.Time {
+	return suite.start.Add(time.Duration(seconds) * time.Second)
+}
+
+// packets creates packets whose Data is the seconds of their Time.
+func (suite *LateTestSuite) packets(seconds ...int) []model.Packet {
+	res := make([]model.Packet, len(seconds))
+	for i, e := range seconds {
+		res[i] = model.Packet{Time: suite.at(e), Data: e}
+	}
+	return res
+}
+
+func (suite *LateTestSuite) watermark(seconds int) model.Packet {
+	return model.Packet{Time: suite.at(seconds), Data: model.Watermark{}}
+}
+
+// run joins the inputs sent concurrently and returns the pairs as [ref, model] seconds,
+// the late packets and the summary.
+func (suite *LateTestSuite) run(spec joiner.Spec, params job.UserParams, ref, modelIn []model.Packet) ([][2]int, []*model.LatePacket, model.JoinSummary, error) {
+	j, err := joiner.Create(spec, &params)
+	suite.Require().NoError(err)
+
+	refChan := make(job.DataChan)
+	modelChan := make(job.DataChan)
+	go func() {
+		defer close(refChan)
+		for _, e := range ref {
+			refChan <- e
+		}
+	}()
+	go func() {
+		defer close(modelChan)
+		for _, e := range modelIn {
+			modelChan <- e
+		}
+	}()
+	j.SetRefInput(refChan)
+	j.SetModelInput(modelChan)
+
+	pairs := make([][2]int, 0)
+	late := make([]*model.LatePacket, 0)
+	var summary model.JoinSummary
+	wg := &sync.WaitGroup{}
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		for p := range j.Output() {
+			pair := p.Data.(*model.Pair)
+			pairs = append(pairs, [2]int{pair.RefData.(int), pair.ModelData.(int)})
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for p := range j.(job.LateEmitter).Late() {
+			late = append(late, p.Data.(*model.LatePacket))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for p := range j.(job.AnnotationEmitter).Annotations() {
+			summary = p.Data.(model.JoinSummary)
+		}
+	}()
+
+	err = j.Execute()
+	wg.Wait()
+	return pairs, late, summary, err
+}
+
+func (suite *LateTestSuite) TestByTime_ShouldJoinOutOfOrderPackets_WhenTheyAreWithinAllowedLateness() {
+	for i := 0; i < 100; i++ {
+		//act
+		pairs, late, summary, err := suite.run(
+			joiner.Spec{Mode: joiner.ModeExact},
+			job.UserParams{joiner.AllowedLatenessParam: "2s"},
+			suite.packets(0, 2, 1, 3),
+			suite.packets(1, 0, 2, 3),
+		)
+
+		//assert
+		suite.NoError(err)
+		suite.ElementsMatch([][2]int{{0, 0}, {1, 1}, {2, 2}, {3, 3}}, pairs)
+		suite.Empty(late)
+		suite.Equal(model.JoinSummary{Joined: 4}, summary)
+	}
+}
+
+func (suite *LateTestSuite) TestByTime_ShouldRouteLatePacketToSideOutput_WhenItIsBehindWatermark() {
+	for i := 0; i < 100; i++ {
+		//act
+		pairs, late, summary, err := suite.run(
+			joiner.Spec{Mode: joiner.ModeExact},
+			job.UserParams{},
+			suite.packets(0, 2, 1, 3),
+			suite.packets(0, 1, 2, 3),
+		)
+
+		//assert
+		suite.NoError(err)
+		suite.Equal([][2]int{{0, 0}, {2, 2}, {3, 3}}, pairs)
+		suite.Equal([]*model.LatePacket{{Input: joiner.RefInput, Watermark: suite.at(2), Data: 1}}, late)
+		suite.Equal(model.JoinSummary{Joined: 3, UnmatchedModel: 1, Late: 1}, summary)
+	}
+}
+
+func (suite *LateTestSuite) TestAsOf_ShouldUseWatermarkPackets_WhenInputSendsThem() {
+	for i := 0; i < 100; i++ {
+		//arrange
+		ref := []model.Packet{
+			{Time: suite.at(0), Data: 0},
+			suite.watermark(3),
+			{Time: suite.at(2), Data: 2},
+			{Time: suite.at(4), Data: 4},
+			suite.watermark(4),
+		}
+		modelIn := append(suite.packets(0, 2), suite.watermark(2), model.Packet{Time: suite.at(4), Data: 4})
+
+		//act
+		pairs, late, summary, err := suite.run(
+			joiner.Spec{Mode: joiner.ModeAsOf},
+			job.UserParams{joiner.ToleranceParam: "5s"},
+			ref,
+			modelIn,
+		)
+
+		//assert
+		suite.NoError(err)
+		suite.Equal([][2]int{{0, 0}, {0, 2}, {4, 4}}, pairs)
+		suite.Equal([]*model.LatePacket{{Input: joiner.RefInput, Watermark: suite.at(3), Data: 2}}, late)
+		suite.Equal(model.JoinSummary{Joined: 3, Late: 1}, summary)
+	}
+}
+
+func (suite *LateTestSuite) TestCreate_ShouldReturnError_WhenAllowedLatenessIsInvalid() {
+	for _, mode := range []string{joiner.ModeExact, joiner.ModeAsOf} {
+		for _, params := range []job.UserParams{
+			{joiner.AllowedLatenessParam: "soon"},
+			{joiner.AllowedLatenessParam: "-1s"},
+		} {
+			//act
+			_, err := joiner.Create(joiner.Spec{Mode: mode}, &params)
+
+			//assert
+			suite.Error(err, params)
+		}
+	}
+}
+
+func TestLate(t *testing.T) {
+	suite.Run(t, new(LateTestSuite))
+}
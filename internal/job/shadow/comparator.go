@@ -0,0 +1,253 @@
+// Package shadow compares a shadow model running alongside the primary model of a pipeline.
+package shadow
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/Goboolean/core-system.worker/internal/util/chanutil"
+)
+
+// Comparator compares the predictions of the primary and the shadow model with the reference data
+// and emits model.ShadowComparison as annotations.
+//
+// A prediction made at a point is the close of the next bar,
+// so the predictions of a point are compared when the next reference bar arrives.
+// A point is not compared if either model sends no prediction of it.
+// The predictions are *model.StockAggregate whose Close is compared or model.ValueList whose first value is compared.
+// model.Watermark packets are ignored.
+type Comparator struct {
+	refIn     job.DataChan `type:"*StockAggregate"`
+	primaryIn job.DataChan `type:"*StockAggregate|ValueList"`
+	shadowIn  job.DataChan `type:"*StockAggregate|ValueList"`
+
+	annotations job.DataChan `type:"model.ShadowComparison"` //Job은 자신의 Output 채널에 대해 소유권을 가진다.
+
+	// refs is the reference bars not yet compared in the order of time.
+	refs    []refPoint
+	primary stream
+	shadow  stream
+
+	metrics metrics
+}
+
+type refPoint struct {
+	time  time.Time
+	close float32
+}
+
+// stream is the predictions of a model not yet compared.
+type stream struct {
+	predictions map[time.Time]float32
+	last        time.Time
+	closed      bool
+}
+
+// decided reports whether the prediction of t has arrived or will never arrive.
+func (s *stream) decided(t time.Time) bool {
+	if _, ok := s.predictions[t]; ok {
+		return true
+	}
+	// 예측은 시간 순서대로 도착하므로 t 이후의 예측이 도착했으면 t의 예측은 오지 않는다.
+	return s.closed || s.last.After(t)
+}
+
+type metrics struct {
+	count                          int
+	primaryAbs, shadowAbs          float64
+	primarySq, shadowSq            float64
+	primaryHits, shadowHits, agree int
+}
+
+// NewComparator creates a new instance of Comparator.
+func NewComparator(params *job.UserParams) (*Comparator, error) {
+	return &Comparator{
+		primary:     stream{predictions: make(map[time.Time]float32)},
+		shadow:      stream{predictions: make(map[time.Time]float32)},
+		annotations: make(job.DataChan),
+	}, nil
+}
+
+// Execute starts to receive the reference data and the predictions and compare them
+//
+// If the Job fails to perform its task, Execute returns an error.
+// If the Job completes successfully, it returns nil.
+// DO NOT CALL Execute() TWICE. IT MUST BE PANIC
+func (c *Comparator) Execute() error {
+	defer close(c.annotations)
+	defer func() {
+		go chanutil.DummyChannelConsumer(c.refIn)
+		go chanutil.DummyChannelConsumer(c.primaryIn)
+		go chanutil.DummyChannelConsumer(c.shadowIn)
+	}()
+
+	refIn, primaryIn, shadowIn := c.refIn, c.primaryIn, c.shadowIn
+	for refIn != nil || primaryIn != nil || shadowIn != nil {
+		select {
+		case p, ok := <-refIn:
+			if !ok {
+				refIn = nil
+				break
+			}
+
+			if model.IsWatermark(p) {
+				break
+			}
+
+			data, ok := model.AsStockAggregate(p.Data)
+			if !ok {
+				return fmt.Errorf("compare shadow job: type mismatch. expected *model.StockAggregate, got %s %w", reflect.TypeOf(p.Data), job.ErrTypeMismatch)
+			}
+			c.refs = append(c.refs, refPoint{time: p.Time, close: data.Close})
+		case p, ok := <-primaryIn:
+			if !ok {
+				primaryIn, c.primary.closed = nil, true
+				break
+			}
+
+			if err := c.primary.add(p); err != nil {
+				return err
+			}
+		case p, ok := <-shadowIn:
+			if !ok {
+				shadowIn, c.shadow.closed = nil, true
+				break
+			}
+
+			if err := c.shadow.add(p); err != nil {
+				return err
+			}
+		}
+
+		c.compare()
+	}
+
+	return nil
+}
+
+func (s *stream) add(p model.Packet) error {
+	if model.IsWatermark(p) {
+		return nil
+	}
+
+	v, ok := prediction(p.Data)
+	if !ok {
+		return fmt.Errorf("compare shadow job: type mismatch. expected *model.StockAggregate or model.ValueList, got %s %w", reflect.TypeOf(p.Data), job.ErrTypeMismatch)
+	}
+
+	s.predictions[p.Time] = v
+	s.last = p.Time
+	return nil
+}
+
+// prediction returns the predicted close of the next bar.
+func prediction(data any) (float32, bool) {
+	switch v := data.(type) {
+	case *model.StockAggregate:
+		return v.Close, true
+	case model.ValueList:
+		if len(v) == 0 {
+			return 0, false
+		}
+		return v[0], true
+	default:
+		return 0, false
+	}
+}
+
+// compare compares the predictions of the oldest reference bars whose next bar has arrived.
+func (c *Comparator) compare() {
+	for len(c.refs) >= 2 {
+		cur, next := c.refs[0], c.refs[1]
+		if !c.primary.decided(cur.time) || !c.shadow.decided(cur.time) {
+			return
+		}
+
+		primary, primaryOk := c.primary.predictions[cur.time]
+		shadow, shadowOk := c.shadow.predictions[cur.time]
+		delete(c.primary.predictions, cur.time)
+		delete(c.shadow.predictions, cur.time)
+		c.refs = c.refs[1:]
+
+		if !primaryOk || !shadowOk {
+			continue
+		}
+
+		c.annotations <- model.Packet{
+			Time: cur.time,
+			Data: c.metrics.add(cur.close, next.close, primary, shadow),
+		}
+	}
+}
+
+// add accumulates the comparison of the predictions and returns it.
+func (m *metrics) add(current, actual, primary, shadow float32) model.ShadowComparison {
+	primaryErr := float64(primary - actual)
+	shadowErr := float64(shadow - actual)
+	direction := sign(actual - current)
+
+	m.count++
+	m.primaryAbs += math.Abs(primaryErr)
+	m.shadowAbs += math.Abs(shadowErr)
+	m.primarySq += primaryErr * primaryErr
+	m.shadowSq += shadowErr * shadowErr
+
+	if sign(primary-current) == direction {
+		m.primaryHits++
+	}
+	if sign(shadow-current) == direction {
+		m.shadowHits++
+	}
+
+	agreement := sign(primary-current) == sign(shadow-current)
+	if agreement {
+		m.agree++
+	}
+
+	n := float64(m.count)
+	return model.ShadowComparison{
+		PrimaryPrediction:  primary,
+		ShadowPrediction:   shadow,
+		Actual:             actual,
+		DirectionAgreement: agreement,
+		Count:              m.count,
+		PrimaryMAE:         m.primaryAbs / n,
+		ShadowMAE:          m.shadowAbs / n,
+		PrimaryRMSE:        math.Sqrt(m.primarySq / n),
+		ShadowRMSE:         math.Sqrt(m.shadowSq / n),
+		PrimaryHitRate:     float64(m.primaryHits) / n,
+		ShadowHitRate:      float64(m.shadowHits) / n,
+		AgreementRate:      float64(m.agree) / n,
+	}
+}
+
+func sign(v float32) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+func (c *Comparator) SetRefInput(in job.DataChan) {
+	c.refIn = in
+}
+
+func (c *Comparator) SetPrimaryInput(in job.DataChan) {
+	c.primaryIn = in
+}
+
+func (c *Comparator) SetShadowInput(in job.DataChan) {
+	c.shadowIn = in
+}
+
+func (c *Comparator) Annotations() job.DataChan {
+	return c.annotations
+}
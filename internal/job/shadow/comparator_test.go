@@ -0,0 +1,127 @@
+package shadow_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/job/shadow"
+	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/sync/errgroup"
+)
+
+type ComparatorTestSuite struct {
+	suite.Suite
+	start time.Time
+}
+
+func (suite *ComparatorTestSuite) SetupTest() {
+	suite.start = time.Unix(1720396800, 0)
+}
+
+func (suite *ComparatorTestSuite) at(i int) time.Time {
+	return suite.start.Add(time.Duration(i) * time.Minute)
+}
+
+// run feeds the closes of the reference bars and the predictions of each model keyed by the index of the bar.
+func (suite *ComparatorTestSuite) run(closes []float32, primary, shadowPredictions map[int]any) ([]model.ShadowComparison, error) {
+	c, err := shadow.NewComparator(&job.UserParams{})
+	suite.Require().NoError(err)
+
+	refIn := make(job.DataChan, len(closes))
+	for i, e := range closes {
+		refIn <- model.Packet{Time: suite.at(i), Data: &model.StockAggregate{Close: e}}
+	}
+	close(refIn)
+
+	feed := func(predictions map[int]any) job.DataChan {
+		ch := make(job.DataChan, len(closes))
+		for i := range closes {
+			if v, ok := predictions[i]; ok {
+				ch <- model.Packet{Time: suite.at(i), Data: v}
+			}
+		}
+		close(ch)
+		return ch
+	}
+
+	c.SetRefInput(refIn)
+	c.SetPrimaryInput(feed(primary))
+	c.SetShadowInput(feed(shadowPredictions))
+
+	g := errgroup.Group{}
+	g.Go(c.Execute)
+
+	res := make([]model.ShadowComparison, 0)
+	for p := range c.Annotations() {
+		res = append(res, p.Data.(model.ShadowComparison))
+	}
+	return res, g.Wait()
+}
+
+func (suite *ComparatorTestSuite) TestComparator_ShouldCompareWithNextClose() {
+	//arrange
+	closes := []float32{10, 12, 11}
+	primary := map[int]any{
+		0: &model.StockAggregate{Close: 11},
+		1: &model.StockAggregate{Close: 13},
+	}
+	shadowPredictions := map[int]any{
+		0: model.ValueList{14, 0},
+		1: model.ValueList{10},
+	}
+
+	//act
+	res, err := suite.run(closes, primary, shadowPredictions)
+
+	//assert
+	suite.NoError(err)
+	suite.Equal([]model.ShadowComparison{
+		{
+			PrimaryPrediction: 11, ShadowPrediction: 14, Actual: 12, DirectionAgreement: true,
+			Count: 1, PrimaryMAE: 1, ShadowMAE: 2, PrimaryRMSE: 1, ShadowRMSE: 2,
+			PrimaryHitRate: 1, ShadowHitRate: 1, AgreementRate: 1,
+		},
+		{
+			// 12에서 11로 하락했으므로 shadow만 방향을 맞췄다.
+			PrimaryPrediction: 13, ShadowPrediction: 10, Actual: 11, DirectionAgreement: false,
+			Count: 2, PrimaryMAE: 1.5, ShadowMAE: 1.5, PrimaryRMSE: 1.5811388300841898, ShadowRMSE: 1.5811388300841898,
+			PrimaryHitRate: 0.5, ShadowHitRate: 1, AgreementRate: 0.5,
+		},
+	}, res)
+}
+
+func (suite *ComparatorTestSuite) TestComparator_ShouldSkipPoint_WhenEitherModelHasNoPrediction() {
+	//arrange
+	closes := []float32{10, 12, 11, 13}
+	primary := map[int]any{
+		0: &model.StockAggregate{Close: 11},
+		2: &model.StockAggregate{Close: 12},
+	}
+	shadowPredictions := map[int]any{
+		1: &model.StockAggregate{Close: 13},
+		2: &model.StockAggregate{Close: 14},
+	}
+
+	//act
+	res, err := suite.run(closes, primary, shadowPredictions)
+
+	//assert
+	suite.NoError(err)
+	suite.Require().Len(res, 1)
+	suite.Equal(float32(13), res[0].Actual)
+	suite.Equal(1, res[0].Count)
+}
+
+func (suite *ComparatorTestSuite) TestComparator_ShouldReturnError_WhenPredictionTypeIsUnsupported() {
+	//act
+	_, err := suite.run([]float32{10, 11}, map[int]any{0: &model.ProbeDist{}}, map[int]any{})
+
+	//assert
+	suite.ErrorIs(err, job.ErrTypeMismatch)
+}
+
+func TestComparator(t *testing.T) {
+	suite.Run(t, new(ComparatorTestSuite))
+}
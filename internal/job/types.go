@@ -21,6 +21,25 @@ const (
 	Task      = "task"
 	TaskID    = "taskID"
 	TimeFrame = "timeFrame"
+	ModelID   = "modelID"
+
+	Concurrency = "concurrency"
+
+	OutputLabels  = "outputLabels"
+	OutputSoftmax = "outputSoftmax"
+
+	AdditionalTimeFrames = "additionalTimeFrames"
+
+	BarType      = "barType"
+	BarThreshold = "barThreshold"
+
+	ReplaySpeed = "replaySpeed"
+	BypassCache = "bypassCache"
+
+	BypassInferenceCache = "bypassInferenceCache"
+
+	Seed             = "seed"
+	SyntheticProcess = "syntheticProcess"
 
 	NumOfGeneration            = "numOfGeneration"
 	MaxRandomDelayMilliseconds = "maxRandomDelayMilliseconds"
@@ -0,0 +1,127 @@
+package validator
+
+import (
+	"math"
+
+	"github.com/Goboolean/core-system.worker/internal/model"
+)
+
+// Types of violation that Validator detects.
+const (
+	// ViolationNaN means that a price is NaN or infinite.
+	ViolationNaN = "nan"
+	// ViolationVolume means that the volume is negative, NaN or infinite.
+	ViolationVolume = "volume"
+	// ViolationOHLC means that High or Low does not bound Open and Close, or High < Low.
+	ViolationOHLC = "ohlc"
+	// ViolationOrder means that ClosedTime is earlier than the ClosedTime of the previous data.
+	ViolationOrder = "order"
+	// ViolationDuplicate means that ClosedTime is the same as the ClosedTime of the previous data.
+	ViolationDuplicate = "duplicate"
+	// ViolationSpike means that the log return of Close is beyond the z-score threshold.
+	ViolationSpike = "spike"
+)
+
+// Policies applied to a violation.
+const (
+	// PolicyDrop drops the data.
+	PolicyDrop = "drop"
+	// PolicyRepair repairs the data. Data that cannot be repaired is dropped.
+	PolicyRepair = "repair"
+	// PolicyFail stops the pipeline with ErrDataQuality.
+	PolicyFail = "fail"
+)
+
+// violations lists the types of violation in the order they are checked.
+var violations = []string{
+	ViolationNaN,
+	ViolationVolume,
+	ViolationOHLC,
+	ViolationOrder,
+	ViolationDuplicate,
+	ViolationSpike,
+}
+
+var defaultPolicies = map[string]string{
+	ViolationNaN:       PolicyDrop,
+	ViolationVolume:    PolicyRepair,
+	ViolationOHLC:      PolicyRepair,
+	ViolationOrder:     PolicyDrop,
+	ViolationDuplicate: PolicyDrop,
+	ViolationSpike:     PolicyRepair,
+}
+
+func isInvalidFloat(f float32) bool {
+	return math.IsNaN(float64(f)) || math.IsInf(float64(f), 0)
+}
+
+func hasInvalidPrice(e *model.StockAggregate) bool {
+	return isInvalidFloat(e.Open) || isInvalidFloat(e.Close) || isInvalidFloat(e.High) || isInvalidFloat(e.Low)
+}
+
+// repairInvalidPrice replaces invalid prices with the close of the previous data.
+func repairInvalidPrice(e *model.StockAggregate, prevClose float32) {
+	for _, f := range []*float32{&e.Open, &e.Close, &e.High, &e.Low} {
+		if isInvalidFloat(*f) {
+			*f = prevClose
+		}
+	}
+}
+
+func hasInvalidVolume(e *model.StockAggregate) bool {
+	return isInvalidFloat(e.Volume) || e.Volume < 0
+}
+
+func hasInvalidOHLC(e *model.StockAggregate) bool {
+	return e.High < e.Low ||
+		e.High < e.Open || e.High < e.Close ||
+		e.Low > e.Open || e.Low > e.Close
+}
+
+// repairOHLC makes High and Low bound every price of the data.
+func repairOHLC(e *model.StockAggregate) {
+	high := max(e.Open, e.Close, e.High, e.Low)
+	low := min(e.Open, e.Close, e.High, e.Low)
+	e.High, e.Low = high, low
+}
+
+// returnStats keeps the mean and standard deviation of the latest log returns.
+type returnStats struct {
+	window []float64
+	next   int
+	size   int
+	sum    float64
+	sumSq  float64
+}
+
+func newReturnStats(size int) *returnStats {
+	return &returnStats{
+		window: make([]float64, size),
+	}
+}
+
+func (s *returnStats) ready() bool {
+	return s.size == len(s.window)
+}
+
+func (s *returnStats) add(r float64) {
+	if s.ready() {
+		old := s.window[s.next]
+		s.sum -= old
+		s.sumSq -= old * old
+	} else {
+		s.size++
+	}
+
+	s.window[s.next] = r
+	s.sum += r
+	s.sumSq += r * r
+	s.next = (s.next + 1) % len(s.window)
+}
+
+func (s *returnStats) meanStd() (float64, float64) {
+	n := float64(s.size)
+	mean := s.sum / n
+	variance := s.sumSq/n - mean*mean
+	return mean, math.Sqrt(math.Max(variance, 0))
+}
@@ -0,0 +1,270 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/job/fetcher"
+	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/Goboolean/core-system.worker/internal/util/chanutil"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	DefaultSpikeWindow = 20
+)
+
+// User param keys
+const (
+	// PolicyParamPrefix is the prefix of the user params selecting the policy of each violation.
+	// For example, "validation.ohlc": "fail".
+	PolicyParamPrefix = "validation."
+	SpikeZScore       = "validation.spikeZScore"
+	SpikeWindow       = "validation.spikeWindow"
+)
+
+var (
+	ErrDataQuality   = errors.New("validate: data quality violation")
+	ErrInvalidPolicy = errors.New("validate: invalid policy")
+)
+
+// Validator validates the OHLCV data fetched by a fetcher
+// and handles each violation by dropping, repairing the data or failing.
+//
+// Validator wraps a fetcher and implements fetcher.Fetcher itself,
+// so it can be inserted after any fetcher.
+// When the fetcher completes, Validator publishes a model.DataQualitySummary as an annotation.
+type Validator struct {
+	fetcher fetcher.Fetcher
+
+	policies    map[string]string
+	spikeZScore float64
+
+	spikes    *returnStats
+	prev      *model.StockAggregate
+	summary   model.DataQualitySummary
+	lastPoint time.Time
+
+	out         job.DataChan `type:"*StockAggregate"` //Job은 자신의 Output 채널에 대해 소유권을 가진다.
+	annotations job.DataChan `type:"model.DataQualitySummary"`
+}
+
+// New creates a new Validator validating the output of f.
+//
+// Params list:
+// "validation.{violation}": The policy of the violation. "drop"|"repair"|"fail"
+// SpikeZScore: The z-score of log return beyond which Close is considered a spike. Zero disables the spike check.
+// SpikeWindow: The number of latest log returns used to compute the z-score.
+func New(f fetcher.Fetcher, params *job.UserParams) (*Validator, error) {
+	instance := &Validator{
+		fetcher:  f,
+		policies: make(map[string]string, len(defaultPolicies)),
+		summary: model.DataQualitySummary{
+			Violations: make(map[string]int),
+		},
+		out:         make(job.DataChan),
+		annotations: make(job.DataChan, 1),
+	}
+
+	for k, v := range defaultPolicies {
+		instance.policies[k] = v
+	}
+
+	for _, v := range violations {
+		key := PolicyParamPrefix + v
+		if params.IsKeyNilOrEmpty(key) {
+			continue
+		}
+
+		policy := (*params)[key]
+		if policy != PolicyDrop && policy != PolicyRepair && policy != PolicyFail {
+			return nil, fmt.Errorf("create validate job: %w: %s=%s", ErrInvalidPolicy, key, policy)
+		}
+		instance.policies[v] = policy
+	}
+
+	if !params.IsKeyNilOrEmpty(SpikeZScore) {
+		val, err := strconv.ParseFloat((*params)[SpikeZScore], 64)
+		if err != nil {
+			return nil, fmt.Errorf("create validate job: %w", err)
+		}
+
+		instance.spikeZScore = val
+	}
+
+	window := DefaultSpikeWindow
+	if !params.IsKeyNilOrEmpty(SpikeWindow) {
+		val, err := strconv.ParseInt((*params)[SpikeWindow], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("create validate job: %w", err)
+		}
+
+		if val <= 0 {
+			return nil, fmt.Errorf("create validate job: %s must be positive", SpikeWindow)
+		}
+
+		window = int(val)
+	}
+	instance.spikes = newReturnStats(window)
+
+	return instance, nil
+}
+
+// Execute runs the wrapped fetcher and validates its output.
+//
+// If the Job fails to perform its task, Execute returns an error.
+// If the Job completes successfully, it returns nil.
+// DO NOT CALL Execute() TWICE. IT MUST BE PANIC
+func (v *Validator) Execute() error {
+	defer close(v.annotations)
+	defer close(v.out)
+
+	g := errgroup.Group{}
+	g.Go(v.fetcher.Execute)
+	g.Go(func() error {
+		defer func() {
+			go chanutil.DummyChannelConsumer(v.fetcher.Output())
+		}()
+
+		for p := range v.fetcher.Output() {
+			validated, err := v.validate(p)
+			if err != nil {
+				v.fetcher.NotifyStop()
+				return err
+			}
+
+			if validated != nil {
+				v.out <- *validated
+			}
+		}
+		return nil
+	})
+
+	err := g.Wait()
+
+	v.annotations <- model.Packet{
+		Time: v.lastPoint,
+		Data: v.summary,
+	}
+	return err
+}
+
+// validate checks the packet and applies the policy of each detected violation.
+// It returns nil if the packet is dropped.
+func (v *Validator) validate(p model.Packet) (*model.Packet, error) {
+	data, ok := p.Data.(*model.StockAggregate)
+	if !ok {
+		return nil, fmt.Errorf("validate job: type mismatch. expected *model.StockAggregate, got %s %w", reflect.TypeOf(p.Data), job.ErrTypeMismatch)
+	}
+
+	v.summary.Checked++
+	v.lastPoint = p.Time
+
+	e := *data
+	repaired := false
+
+	for _, violation := range violations {
+		if !v.detect(violation, &e) {
+			continue
+		}
+
+		v.summary.Violations[violation]++
+		switch v.policies[violation] {
+		case PolicyFail:
+			return nil, fmt.Errorf("validate job: %w: %s at %s", ErrDataQuality, violation, p.Time)
+		case PolicyRepair:
+			if v.repair(violation, &e) {
+				repaired = true
+				continue
+			}
+		}
+
+		v.summary.Dropped++
+		return nil, nil
+	}
+
+	if repaired {
+		v.summary.Repaired++
+	}
+
+	if v.prev != nil && v.spikeZScore > 0 {
+		v.spikes.add(math.Log(float64(e.Close) / float64(v.prev.Close)))
+	}
+	v.prev = &e
+
+	return &model.Packet{
+		Time: p.Time,
+		Data: &e,
+	}, nil
+}
+
+func (v *Validator) detect(violation string, e *model.StockAggregate) bool {
+	switch violation {
+	case ViolationNaN:
+		return hasInvalidPrice(e)
+	case ViolationVolume:
+		return hasInvalidVolume(e)
+	case ViolationOHLC:
+		return hasInvalidOHLC(e)
+	case ViolationOrder:
+		return v.prev != nil && e.ClosedTime < v.prev.ClosedTime
+	case ViolationDuplicate:
+		return v.prev != nil && e.ClosedTime == v.prev.ClosedTime
+	case ViolationSpike:
+		if v.prev == nil || v.spikeZScore <= 0 || !v.spikes.ready() {
+			return false
+		}
+		mean, std := v.spikes.meanStd()
+		r := math.Log(float64(e.Close) / float64(v.prev.Close))
+		return std > 0 && math.Abs(r-mean)/std > v.spikeZScore
+	default:
+		return false
+	}
+}
+
+// repair repairs the violation of e and reports whether it is repaired.
+func (v *Validator) repair(violation string, e *model.StockAggregate) bool {
+	switch violation {
+	case ViolationNaN:
+		if v.prev == nil {
+			return false
+		}
+		repairInvalidPrice(e, v.prev.Close)
+		return true
+	case ViolationVolume:
+		e.Volume = 0
+		return true
+	case ViolationOHLC:
+		repairOHLC(e)
+		return true
+	case ViolationSpike:
+		// Clamp every price within the band of the z-score threshold.
+		mean, std := v.spikes.meanStd()
+		lower := float32(float64(v.prev.Close) * math.Exp(mean-v.spikeZScore*std))
+		upper := float32(float64(v.prev.Close) * math.Exp(mean+v.spikeZScore*std))
+		for _, f := range []*float32{&e.Open, &e.Close, &e.High, &e.Low} {
+			*f = min(max(*f, lower), upper)
+		}
+		return true
+	default:
+		// Data out of order or duplicated cannot be repaired.
+		return false
+	}
+}
+
+func (v *Validator) Output() job.DataChan {
+	return v.out
+}
+
+func (v *Validator) Annotations() job.DataChan {
+	return v.annotations
+}
+
+func (v *Validator) NotifyStop() {
+	v.fetcher.NotifyStop()
+}
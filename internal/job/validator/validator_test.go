@@ -0,0 +1,220 @@
+package validator_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/job/validator"
+	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/Goboolean/core-system.worker/internal/util"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/sync/errgroup"
+)
+
+// sliceFetcher sends the given bars to its output channel.
+type sliceFetcher struct {
+	bars []*model.StockAggregate
+	out  job.DataChan
+	stop *util.StopNotifier
+}
+
+func newSliceFetcher(bars ...*model.StockAggregate) *sliceFetcher {
+	return &sliceFetcher{
+		bars: bars,
+		out:  make(job.DataChan),
+		stop: util.NewStopNotifier(),
+	}
+}
+
+func (f *sliceFetcher) Execute() error {
+	defer close(f.out)
+	for _, e := range f.bars {
+		select {
+		case <-f.stop.Done():
+			return nil
+		case f.out <- model.Packet{Time: time.Unix(e.ClosedTime, 0), Data: e}:
+		}
+	}
+	return nil
+}
+
+func (f *sliceFetcher) Output() job.DataChan {
+	return f.out
+}
+
+func (f *sliceFetcher) NotifyStop() {
+	f.stop.NotifyStop()
+}
+
+func bar(closedTime int64, open, close, high, low, volume float32) *model.StockAggregate {
+	return &model.StockAggregate{
+		OpenTime:   closedTime - 60,
+		ClosedTime: closedTime,
+		Open:       open,
+		Close:      close,
+		High:       high,
+		Low:        low,
+		Volume:     volume,
+	}
+}
+
+type ValidatorTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ValidatorTestSuite) run(params *job.UserParams, bars ...*model.StockAggregate) ([]*model.StockAggregate, model.DataQualitySummary, error) {
+	v, err := validator.New(newSliceFetcher(bars...), params)
+	suite.Require().NoError(err)
+
+	res := make([]*model.StockAggregate, 0)
+	var summary model.DataQualitySummary
+
+	g := errgroup.Group{}
+	g.Go(func() error {
+		for p := range v.Output() {
+			res = append(res, p.Data.(*model.StockAggregate))
+		}
+		return nil
+	})
+	g.Go(func() error {
+		for p := range v.Annotations() {
+			summary = p.Data.(model.DataQualitySummary)
+		}
+		return nil
+	})
+
+	err = v.Execute()
+	suite.Require().NoError(g.Wait())
+	return res, summary, err
+}
+
+func (suite *ValidatorTestSuite) TestValidator_ShouldPassData_WhenDataIsValid() {
+	//arrange
+	bars := []*model.StockAggregate{
+		bar(60, 1, 2, 3, 1, 10),
+		bar(120, 2, 3, 3, 2, 10),
+		bar(180, 3, 2, 3, 1, 10),
+	}
+
+	//act
+	res, summary, err := suite.run(&job.UserParams{}, bars...)
+
+	//assert
+	suite.NoError(err)
+	suite.Equal(bars, res)
+	suite.Equal(model.DataQualitySummary{Checked: 3, Violations: map[string]int{}}, summary)
+}
+
+func (suite *ValidatorTestSuite) TestValidator_ShouldApplyDefaultPolicies_WhenPoliciesAreNotGiven() {
+	//arrange
+	nan := float32(math.NaN())
+	bars := []*model.StockAggregate{
+		bar(60, nan, 2, 3, 1, 10),  // nan without previous close: dropped
+		bar(120, 2, 3, 3, 2, -1),   // volume: repaired
+		bar(180, 3, 5, 4, 2, 10),   // ohlc: repaired
+		bar(120, 2, 3, 3, 2, 10),   // order: dropped
+		bar(180, 3, 2, 3, 1, 10),   // duplicate: dropped
+		bar(240, nan, 2, 3, 1, 10), // nan: dropped
+	}
+
+	//act
+	res, summary, err := suite.run(&job.UserParams{}, bars...)
+
+	//assert
+	suite.NoError(err)
+	suite.Equal([]*model.StockAggregate{
+		bar(120, 2, 3, 3, 2, 0),
+		bar(180, 3, 5, 5, 2, 10),
+	}, res)
+	suite.Equal(model.DataQualitySummary{
+		Checked:  6,
+		Dropped:  4,
+		Repaired: 2,
+		Violations: map[string]int{
+			validator.ViolationNaN:       2,
+			validator.ViolationVolume:    1,
+			validator.ViolationOHLC:      1,
+			validator.ViolationOrder:     1,
+			validator.ViolationDuplicate: 1,
+		},
+	}, summary)
+}
+
+func (suite *ValidatorTestSuite) TestValidator_ShouldRepairNaN_WhenPolicyIsRepair() {
+	//arrange
+	nan := float32(math.NaN())
+
+	//act
+	res, summary, err := suite.run(&job.UserParams{
+		"validation.nan": validator.PolicyRepair,
+	},
+		bar(60, 1, 2, 3, 1, 10),
+		bar(120, nan, 3, 3, nan, 10),
+	)
+
+	//assert
+	suite.NoError(err)
+	suite.Equal(bar(120, 2, 3, 3, 2, 10), res[1])
+	suite.Equal(1, summary.Repaired)
+}
+
+func (suite *ValidatorTestSuite) TestValidator_ShouldReturnError_WhenPolicyIsFail() {
+	//act
+	res, summary, err := suite.run(&job.UserParams{
+		"validation.ohlc": validator.PolicyFail,
+	},
+		bar(60, 1, 2, 3, 1, 10),
+		bar(120, 1, 5, 3, 1, 10),
+		bar(180, 1, 2, 3, 1, 10),
+	)
+
+	//assert
+	suite.ErrorIs(err, validator.ErrDataQuality)
+	suite.Len(res, 1)
+	suite.Equal(2, summary.Checked)
+	suite.Equal(1, summary.Violations[validator.ViolationOHLC])
+}
+
+func (suite *ValidatorTestSuite) TestValidator_ShouldClampSpike_WhenLogReturnExceedsZScore() {
+	//arrange
+	bars := make([]*model.StockAggregate, 0)
+	for i := 0; i < 10; i++ {
+		price := float32(100 + i%2)
+		bars = append(bars, bar(int64(i+1)*60, price, price, price, price, 10))
+	}
+	bars = append(bars, bar(660, 100, 1000, 1000, 100, 10))
+
+	//act
+	res, summary, err := suite.run(&job.UserParams{
+		validator.SpikeZScore: "3",
+		validator.SpikeWindow: "5",
+	}, bars...)
+
+	//assert
+	suite.NoError(err)
+	suite.Len(res, len(bars))
+	suite.Equal(1, summary.Violations[validator.ViolationSpike])
+	suite.Less(res[len(res)-1].Close, float32(110))
+	suite.LessOrEqual(res[len(res)-1].Low, res[len(res)-1].Close)
+}
+
+func (suite *ValidatorTestSuite) TestNew_ShouldReturnError_WhenParamIsInvalid() {
+	for _, params := range []job.UserParams{
+		{"validation.nan": "ignore"},
+		{validator.SpikeZScore: "abc"},
+		{validator.SpikeWindow: "abc"},
+		{validator.SpikeWindow: "0"},
+	} {
+		//act
+		_, err := validator.New(newSliceFetcher(), &params)
+
+		//assert
+		suite.Error(err)
+	}
+}
+
+func TestValidator(t *testing.T) {
+	suite.Run(t, new(ValidatorTestSuite))
+}
@@ -0,0 +1,102 @@
+package watermark
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/Goboolean/core-system.worker/internal/util/chanutil"
+)
+
+// User param keys
+const (
+	// DelayParam is how far the watermark lags behind the latest Time of the fetched data. Example: "5s"
+	DelayParam = "watermark.delay"
+)
+
+// Generator passes the fetched data through and inserts model.Watermark packets into it.
+//
+// The watermark is the latest Time of the packets minus the delay,
+// so the packets out of order by up to the delay are still on time.
+// A watermark packet is sent after each packet that advances the watermark.
+// The watermark does not advance while no data is fetched.
+type Generator struct {
+	delay     time.Duration
+	watermark time.Time
+
+	in  job.DataChan
+	out job.DataChan //Job은 자신의 Output 채널에 대해 소유권을 가진다.
+}
+
+// New creates new instance of Generator
+//
+// Params list:
+// DelayParam: how far the watermark lags behind the latest Time. Defaults to 0.
+func New(params *job.UserParams) (*Generator, error) {
+	instance := &Generator{
+		out: make(job.DataChan),
+	}
+
+	if !params.IsKeyNilOrEmpty(DelayParam) {
+		val, err := time.ParseDuration((*params)[DelayParam])
+		if err != nil {
+			return nil, fmt.Errorf("create watermark job: %w", err)
+		}
+
+		if val < 0 {
+			return nil, fmt.Errorf("create watermark job: %s must not be negative", DelayParam)
+		}
+
+		instance.delay = val
+	}
+
+	return instance, nil
+}
+
+// Execute starts to pass the data with watermarks
+//
+// If the Job fails to perform its task, Execute returns an error.
+// If the Job completes successfully, it returns nil.
+// DO NOT CALL Execute() TWICE. IT MUST BE PANIC
+func (g *Generator) Execute() error {
+	defer close(g.out)
+	defer func() {
+		go chanutil.DummyChannelConsumer(g.in)
+	}()
+
+	for p := range g.in {
+		g.out <- p
+
+		// 상위 job이 보낸 watermark는 그대로 전달한다.
+		if model.IsWatermark(p) {
+			g.watermark = maxTime(g.watermark, p.Time)
+			continue
+		}
+
+		if watermark := p.Time.Add(-g.delay); watermark.After(g.watermark) {
+			g.watermark = watermark
+			g.out <- model.Packet{
+				Time: watermark,
+				Data: model.Watermark{},
+			}
+		}
+	}
+
+	return nil
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+func (g *Generator) SetInput(in job.DataChan) {
+	g.in = in
+}
+
+func (g *Generator) Output() job.DataChan {
+	return g.out
+}
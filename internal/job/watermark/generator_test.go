@@ -0,0 +1,81 @@
+package watermark_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/job/watermark"
+	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type GeneratorTestSuite struct {
+	suite.Suite
+	start time.Time
+}
+
+func (suite *GeneratorTestSuite) SetupTest() {
+	suite.start = time.Unix(1720396800, 0)
+}
+
+func (suite *GeneratorTestSuite) at(seconds int) time.Time {
+	return suite.start.Add(time.Duration(seconds) * time.Second)
+}
+
+// run sends packets at the given seconds and returns the output as the seconds of data and watermarks.
+// The seconds of watermarks are negated.
+func (suite *GeneratorTestSuite) run(params job.UserParams, seconds ...int) ([]int, error) {
+	g, err := watermark.New(&params)
+	suite.Require().NoError(err)
+
+	in := make(job.DataChan, len(seconds))
+	for _, e := range seconds {
+		in <- model.Packet{Time: suite.at(e), Data: e}
+	}
+	close(in)
+	g.SetInput(in)
+
+	res := make([]int, 0)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for p := range g.Output() {
+			sec := int(p.Time.Sub(suite.start) / time.Second)
+			if model.IsWatermark(p) {
+				sec = -sec
+			}
+			res = append(res, sec)
+		}
+	}()
+
+	err = g.Execute()
+	<-done
+	return res, err
+}
+
+func (suite *GeneratorTestSuite) TestGenerator_ShouldSendWatermarkAfterPacketAdvancingIt() {
+	//act
+	res, err := suite.run(job.UserParams{watermark.DelayParam: "2s"}, 3, 4, 2, 6, 5, 9)
+
+	//assert
+	suite.NoError(err)
+	suite.Equal([]int{3, -1, 4, -2, 2, 6, -4, 5, 9, -7}, res)
+}
+
+func (suite *GeneratorTestSuite) TestNew_ShouldReturnError_WhenDelayIsInvalid() {
+	for _, params := range []job.UserParams{
+		{watermark.DelayParam: "soon"},
+		{watermark.DelayParam: "-1s"},
+	} {
+		//act
+		_, err := watermark.New(&params)
+
+		//assert
+		suite.Error(err, params)
+	}
+}
+
+func TestGenerator(t *testing.T) {
+	suite.Run(t, new(GeneratorTestSuite))
+}
@@ -5,3 +5,79 @@ package model
 type ExampleAnnotation struct {
 	Description string `name:"description"`
 }
+
+// DataQualitySummary reports the result of validating the input data of a pipeline.
+type DataQualitySummary struct {
+	// Checked is the number of data items that are validated.
+	Checked int `name:"checked"`
+	// Dropped is the number of data items that are dropped because of violations.
+	Dropped int `name:"dropped"`
+	// Repaired is the number of data items that are repaired.
+	Repaired int `name:"repaired"`
+	// Violations is the number of detected violations keyed by the type of violation.
+	Violations map[string]int `name:"violations"`
+}
+
+// JoinSummary reports the result of joining the model outputs with the reference data of a pipeline.
+type JoinSummary struct {
+	// Joined is the number of model packets paired with a reference packet.
+	Joined int `name:"joined"`
+	// UnmatchedModel is the number of model packets that have no reference packet to pair with.
+	UnmatchedModel int `name:"unmatchedModel"`
+	// UnmatchedRef is the number of reference packets that are not paired with any model packet.
+	UnmatchedRef int `name:"unmatchedRef"`
+	// Evicted is the number of packets evicted from a full buffer before they are paired.
+	Evicted int `name:"evicted"`
+	// Late is the number of packets arrived after the watermark of their input and routed to the side output.
+	Late int `name:"late"`
+}
+
+// LateRecord reports a packet that arrived after the watermark of its input and was routed to the side output of a job.
+type LateRecord struct {
+	// Input is the name of the input the packet arrived at.
+	Input string `name:"input"`
+	// Time and Watermark are the time of the packet and the watermark of the input in unix milliseconds.
+	Time      int64 `name:"time"`
+	Watermark int64 `name:"watermark"`
+}
+
+// CircuitBreakerStateChange reports that the circuit breaker around the inference of a model changed its state.
+type CircuitBreakerStateChange struct {
+	// From and To are the states before and after the change. "closed"|"open"|"halfOpen"
+	From string `name:"from"`
+	To   string `name:"to"`
+	// Failures is the number of consecutive failed inferences when the state changed.
+	Failures int `name:"failures"`
+}
+
+// InferenceFallback reports that a model output is replaced by the fallback policy because the inference failed.
+type InferenceFallback struct {
+	// Policy is the applied fallback policy. "skip"|"lastKnown"
+	Policy string `name:"policy"`
+	// Reason is the error of the inference.
+	Reason string `name:"reason"`
+}
+
+// ShadowComparison compares the predictions of the primary and the shadow model made at a point
+// with the actual close of the next bar, together with the cumulative metrics up to the point.
+type ShadowComparison struct {
+	// PrimaryPrediction and ShadowPrediction are the predicted closes of the next bar.
+	PrimaryPrediction float32 `name:"primaryPrediction"`
+	ShadowPrediction  float32 `name:"shadowPrediction"`
+	// Actual is the close of the next bar.
+	Actual float32 `name:"actual"`
+	// DirectionAgreement is whether both models predict the same direction from the current close.
+	DirectionAgreement bool `name:"directionAgreement"`
+
+	// Count is the number of compared points so far.
+	Count       int     `name:"count"`
+	PrimaryMAE  float64 `name:"primaryMAE"`
+	ShadowMAE   float64 `name:"shadowMAE"`
+	PrimaryRMSE float64 `name:"primaryRMSE"`
+	ShadowRMSE  float64 `name:"shadowRMSE"`
+	// PrimaryHitRate and ShadowHitRate are the ratios of the points whose direction is predicted correctly.
+	PrimaryHitRate float64 `name:"primaryHitRate"`
+	ShadowHitRate  float64 `name:"shadowHitRate"`
+	// AgreementRate is the ratio of the points where both models predict the same direction.
+	AgreementRate float64 `name:"agreementRate"`
+}
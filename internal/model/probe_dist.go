@@ -0,0 +1,31 @@
+package model
+
+import "strconv"
+
+// ProbeDist is a probability distribution over discrete classes or bins predicted by a model,
+// such as the direction of the next bar or the bin of its return.
+type ProbeDist struct {
+	// Labels names the class or bin of each probability. It is nil if the model has no labels.
+	Labels []string
+	// Probs is the probability of each class, which sums to 1.
+	Probs []float32
+}
+
+// Argmax returns the index of the most probable class. It returns -1 if Probs is empty.
+func (d *ProbeDist) Argmax() int {
+	res := -1
+	for i, e := range d.Probs {
+		if res == -1 || e > d.Probs[res] {
+			res = i
+		}
+	}
+	return res
+}
+
+// Label returns the label of the class at index i, or the index itself if there is no label.
+func (d *ProbeDist) Label(i int) string {
+	if i < len(d.Labels) {
+		return d.Labels[i]
+	}
+	return strconv.Itoa(i)
+}
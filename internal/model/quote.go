@@ -0,0 +1,55 @@
+package model
+
+import (
+	"time"
+)
+
+// Quote is the top of the order book of a product at a specific point in time.
+type Quote struct {
+	Time     time.Time
+	BidPrice float32
+	BidSize  float32
+	AskPrice float32
+	AskSize  float32
+}
+
+// Features derives the features of liquidity from the quote.
+func (q *Quote) Features() *QuoteFeatures {
+	var imbalance float32
+	if q.BidSize+q.AskSize > 0 {
+		imbalance = (q.BidSize - q.AskSize) / (q.BidSize + q.AskSize)
+	}
+
+	return &QuoteFeatures{
+		Time:      q.Time,
+		Mid:       (q.BidPrice + q.AskPrice) / 2,
+		Spread:    q.AskPrice - q.BidPrice,
+		Imbalance: imbalance,
+	}
+}
+
+// QuoteFeatures are the features of liquidity derived from a quote.
+type QuoteFeatures struct {
+	Time time.Time
+	// Mid is the average of the best bid and the best ask.
+	Mid float32
+	// Spread is the best ask minus the best bid.
+	Spread float32
+	// Imbalance is (bid size - ask size) / (bid size + ask size) in the range of [-1, 1].
+	// Positive values mean that buy orders dominate.
+	Imbalance float32
+}
+
+// AsQuoteFeatures returns the features of liquidity carried by data.
+// The features of a quote are derived from it.
+// It reports false if data carries neither Quote nor QuoteFeatures.
+func AsQuoteFeatures(data any) (*QuoteFeatures, bool) {
+	switch v := data.(type) {
+	case *QuoteFeatures:
+		return v, true
+	case *Quote:
+		return v.Features(), true
+	default:
+		return nil, false
+	}
+}
@@ -9,3 +9,28 @@ type StockAggregate struct {
 	Low        float32
 	Volume     float32
 }
+
+// MultiTimeFrameAggregate is the trading data of the primary time frame
+// delivered together with the bars of additional, longer time frames of the same product.
+type MultiTimeFrameAggregate struct {
+	// Primary is the bar of the primary time frame.
+	Primary *StockAggregate
+
+	// TimeFrames maps each additional time frame (e.g. "1h") to its latest bar
+	// closed no later than Primary.ClosedTime.
+	// The entry is nil until the first complete bar of the time frame closes.
+	TimeFrames map[string]*StockAggregate
+}
+
+// AsStockAggregate returns the bar of the primary time frame carried by data.
+// It reports false if data carries no StockAggregate.
+func AsStockAggregate(data any) (*StockAggregate, bool) {
+	switch v := data.(type) {
+	case *StockAggregate:
+		return v, true
+	case *MultiTimeFrameAggregate:
+		return v.Primary, v.Primary != nil
+	default:
+		return nil, false
+	}
+}
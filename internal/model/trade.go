@@ -0,0 +1,10 @@
+package model
+
+import "time"
+
+// Trade is a single trade print of a product.
+type Trade struct {
+	Time  time.Time
+	Price float32
+	Size  float32
+}
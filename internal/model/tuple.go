@@ -0,0 +1,6 @@
+package model
+
+// Tuple is a set of data that share a point in time, keyed by the name of the stream each data comes from.
+// It is used to pass together the outputs of several models and the auxiliary data such as news or fundamentals.
+// A stream that has no data at the point has no key.
+type Tuple map[string]any
@@ -0,0 +1,4 @@
+package model
+
+// ValueList is a list of values predicted by a model, such as the forecasts of the following steps.
+type ValueList []float32
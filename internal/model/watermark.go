@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// Watermark marks the progress of event time in a stream of packets.
+// A packet whose Data is Watermark asserts that the following packets of the stream
+// have no Time before the Time of the watermark packet. Packets breaking the assertion are late.
+//
+// Jobs that do not use watermarks pass them through in the order they are received.
+type Watermark struct{}
+
+// IsWatermark reports whether p is a watermark packet.
+func IsWatermark(p Packet) bool {
+	_, ok := p.Data.(Watermark)
+	return ok
+}
+
+// LatePacket is a packet that arrived after the event time of its input had passed it.
+// It is routed to the side output of the job instead of being processed.
+type LatePacket struct {
+	// Input is the name of the input the packet arrived at.
+	Input string
+	// Watermark is the watermark of the input when the packet arrived.
+	Watermark time.Time
+	// Data is the Data of the late packet.
+	Data any
+}
@@ -0,0 +1,78 @@
+package pipeline
+
+import (
+	"fmt"
+
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/model"
+	"github.com/Goboolean/core-system.worker/internal/util/chanutil"
+)
+
+// mergeAnnotations merges the annotations of the jobs implementing job.AnnotationEmitter into out.
+//
+// It returns out itself and a nil demux if no job emits annotations.
+// Otherwise, the returned demux MUST be executed to forward data to the returned channel.
+func mergeAnnotations(out job.DataChan, jobs ...any) (job.DataChan, *chanutil.ChannelDeMux[model.Packet]) {
+	emitters := make([]job.AnnotationEmitter, 0)
+	for _, e := range jobs {
+		if emitter, ok := e.(job.AnnotationEmitter); ok {
+			emitters = append(emitters, emitter)
+		}
+	}
+
+	if len(emitters) == 0 {
+		return out, nil
+	}
+
+	demux := chanutil.NewChannelDeMux[model.Packet]()
+	demux.AddInput(out)
+	for _, e := range emitters {
+		demux.AddInput(e.Annotations())
+	}
+
+	return demux.Output(), demux
+}
+
+// lateRecorder converts the late packets of a job implementing job.LateEmitter into model.LateRecord annotations,
+// so that they are dispatched with the other annotations.
+type lateRecorder struct {
+	in  job.DataChan
+	out job.DataChan
+}
+
+func newLateRecorder(emitter job.LateEmitter) *lateRecorder {
+	return &lateRecorder{
+		in:  emitter.Late(),
+		out: make(job.DataChan),
+	}
+}
+
+// Execute converts the late packets until the side output of the job is closed.
+// DO NOT CALL Execute() TWICE.
+func (r *lateRecorder) Execute() error {
+	defer close(r.out)
+	defer func() {
+		go chanutil.DummyChannelConsumer(r.in)
+	}()
+
+	for p := range r.in {
+		late, ok := p.Data.(*model.LatePacket)
+		if !ok {
+			return fmt.Errorf("record late packet: %w: expected *model.LatePacket, got %T", ErrTypeNotMatch, p.Data)
+		}
+
+		r.out <- model.Packet{
+			Time: p.Time,
+			Data: model.LateRecord{
+				Input:     late.Input,
+				Time:      p.Time.UnixMilli(),
+				Watermark: late.Watermark.UnixMilli(),
+			},
+		}
+	}
+	return nil
+}
+
+func (r *lateRecorder) Annotations() job.DataChan {
+	return r.out
+}
@@ -12,9 +12,13 @@ import (
 	"github.com/Goboolean/core-system.worker/internal/job/adapter"
 	"github.com/Goboolean/core-system.worker/internal/job/analyzer"
 	"github.com/Goboolean/core-system.worker/internal/job/executer"
+	"github.com/Goboolean/core-system.worker/internal/job/executer/feature"
 	"github.com/Goboolean/core-system.worker/internal/job/fetcher"
 	"github.com/Goboolean/core-system.worker/internal/job/joiner"
+	"github.com/Goboolean/core-system.worker/internal/job/shadow"
 	v1 "github.com/Goboolean/core-system.worker/internal/job/transmitter/v1"
+	"github.com/Goboolean/core-system.worker/internal/job/validator"
+	"github.com/Goboolean/core-system.worker/internal/job/watermark"
 	log "github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v3"
 )
@@ -55,14 +59,21 @@ func Build(config configuration.AppConfig) (Pipeline, error) {
 		return nil, fmt.Errorf("build pipeline: %w", err)
 	}
 
+	var p Pipeline
 	switch t {
 	case NormalPipeline:
-		return buildNormal(config)
+		p, err = buildNormal(config)
 	case PipelineWithoutModel:
-		return buildWithoutModel(config)
+		p, err = buildWithoutModel(config)
 	default:
 		return nil, ErrNotImplemented
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	log.WithField("plan", p.Plan()).Info("pipeline is built")
+	return p, nil
 }
 
 // selectPipeline determine which pipeline the user desires by verifying omitted or additional settings.
@@ -82,10 +93,17 @@ func selectPipeline(config configuration.AppConfig) (PipelineType, error) {
 
 // buildNormal builds normal pipeline
 func buildNormal(config configuration.AppConfig) (*Normal, error) {
-	p := extractUserParams(config)
+	if err := validateJoinInputs(config); err != nil {
+		return nil, fmt.Errorf("build normal pipeline: %w", err)
+	}
+
+	p, err := extractUserParams(config)
+	if err != nil {
+		return nil, fmt.Errorf("build normal pipeline: %w", err)
+	}
 
 	//job객체를 factory로부터 생성
-	fetcher, err := fetcher.Create(extractFetcherSpec(config), &p)
+	fetcher, err := createFetcher(config, &p)
 	if err != nil {
 		return nil, fmt.Errorf("build normal pipeline: %w", err)
 	}
@@ -93,7 +111,10 @@ func buildNormal(config configuration.AppConfig) (*Normal, error) {
 	if err != nil {
 		return nil, fmt.Errorf("build normal pipeline: %w", err)
 	}
-	joiner, err := joiner.NewByTime(&p)
+	joinSpec := extractJoinerSpec(config)
+	// multi join은 model의 출력을 변환하지 않고 그대로 tuple에 담는다.
+	isAdapterRequired := joinSpec.Mode != joiner.ModeMulti && config.Model.OutputType != config.Strategy.InputType
+	joiner, err := joiner.Create(joinSpec, &p)
 	if err != nil {
 		return nil, fmt.Errorf("build normal pipeline: %w", err)
 	}
@@ -107,7 +128,7 @@ func buildNormal(config configuration.AppConfig) (*Normal, error) {
 		return nil, fmt.Errorf("build normal pipeline: %w", err)
 	}
 
-	isAdapterRequired := config.Model.OutputType != config.Strategy.InputType
+	var n *Normal
 	if isAdapterRequired {
 		adapter, err := adapter.Create(adapter.Spec{
 			InputType:  config.Model.OutputType,
@@ -116,7 +137,7 @@ func buildNormal(config configuration.AppConfig) (*Normal, error) {
 		if err != nil {
 			return nil, fmt.Errorf("build normal pipeline: %w", err)
 		}
-		return NewNormalWithAdapter(
+		n, err = NewNormalWithAdapter(
 			fetcher,
 			joiner,
 			modelExecuter,
@@ -124,24 +145,96 @@ func buildNormal(config configuration.AppConfig) (*Normal, error) {
 			analyzer,
 			transmitter,
 		)
+		if err != nil {
+			return nil, fmt.Errorf("build normal pipeline: %w", err)
+		}
 	} else {
-		return NewNormalWithoutAdapter(
+		n, err = NewNormalWithoutAdapter(
 			fetcher,
 			joiner,
 			modelExecuter,
 			analyzer,
 			transmitter,
 		)
+		if err != nil {
+			return nil, fmt.Errorf("build normal pipeline: %w", err)
+		}
+	}
+
+	if config.Model.Shadow != nil {
+		shadowExecuter, comparator, err := createShadow(config, p)
+		if err != nil {
+			return nil, fmt.Errorf("build normal pipeline: %w", err)
+		}
+		n.AttachShadow(shadowExecuter, comparator)
+	}
+
+	if config.Join != nil {
+		for _, e := range config.Join.Inputs {
+			modelExecuter, err := createModelExecuter(config, p, e.ID, e.Runtime)
+			if err != nil {
+				return nil, fmt.Errorf("build normal pipeline: input %q: %w", e.Name, err)
+			}
+
+			if err := n.AttachModel(e.Name, modelExecuter); err != nil {
+				return nil, fmt.Errorf("build normal pipeline: %w", err)
+			}
+		}
+	}
+
+	if config.DataOrigin.Watermark != nil {
+		generator, err := watermark.New(&p)
+		if err != nil {
+			return nil, fmt.Errorf("build normal pipeline: %w", err)
+		}
+		n.AttachWatermarks(generator)
+	}
+
+	return n, nil
+}
+
+// createShadow creates the executer of the shadow model and the comparator of the shadow model.
+// The shadow model receives the same params as the model except its ID.
+func createShadow(config configuration.AppConfig, p job.UserParams) (executer.ModelExecutor, *shadow.Comparator, error) {
+	shadowExecuter, err := createModelExecuter(config, p, config.Model.Shadow.ID, config.Model.Shadow.Runtime)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create shadow model: %w", err)
+	}
+
+	comparator, err := shadow.NewComparator(&p)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create shadow model: %w", err)
+	}
+
+	return shadowExecuter, comparator, nil
+}
+
+// createModelExecuter creates the executer of another model with the output type of the model.
+// It receives the same params as the model except its ID. An empty runtime means the runtime of the model.
+func createModelExecuter(config configuration.AppConfig, p job.UserParams, id, runtime string) (executer.ModelExecutor, error) {
+	params := make(job.UserParams, len(p))
+	for k, v := range p {
+		params[k] = v
+	}
+	params[job.ModelID] = id
+
+	spec := extractModelExecuterSpec(config)
+	if runtime != "" {
+		spec.Runtime = runtime
 	}
 
+	return executer.Create(spec, &params)
 }
 
 // buildWithoutModel builds pipeline without model
 func buildWithoutModel(config configuration.AppConfig) (*WithoutModel, error) {
-	p := extractUserParams(config)
+	p, err := extractUserParams(config)
+	if err != nil {
+		return nil, fmt.Errorf("build normal pipeline: %w", err)
+	}
 
 	//job객체를 factory로부터 생성
-	fetcher, err := fetcher.Create(extractFetcherSpec(config), &p)
+	fetcher, err := createFetcher(config, &p)
 	if err != nil {
 		return nil, fmt.Errorf("build normal pipeline: %w", err)
 	}
@@ -180,12 +273,39 @@ func buildWithoutModel(config configuration.AppConfig) (*WithoutModel, error) {
 
 }
 
+// createFetcher creates the fetcher of the pipeline.
+// If validation is configured, the fetcher is wrapped by a validator.
+// If additional time frames are configured, the fetcher is wrapped to deliver them alongside.
+func createFetcher(config configuration.AppConfig, p *job.UserParams) (fetcher.Fetcher, error) {
+	f, err := fetcher.Create(extractFetcherSpec(config), p)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.DataOrigin.Validation != nil {
+		f, err = validator.New(f, p)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(config.DataOrigin.AdditionalTimeFrames) > 0 {
+		f, err = fetcher.NewMultiTimeFrame(f, p)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return f, nil
+}
+
 func extractFetcherSpec(config configuration.AppConfig) fetcher.Spec {
 
 	var spec fetcher.Spec
 
 	spec.ProductType = config.DataOrigin.ProductType
 	spec.Task = config.Task
+	spec.Replay = config.DataOrigin.ReplaySpeed != ""
 	return spec
 }
 
@@ -194,6 +314,43 @@ func extractModelExecuterSpec(config configuration.AppConfig) executer.Spec {
 	var spec executer.Spec
 
 	spec.OutputType = config.Model.OutputType
+	spec.Runtime = config.Model.Runtime
+	if spec.Runtime == "" {
+		spec.Runtime = executer.RuntimeKServe
+	}
+	return spec
+}
+
+// validateJoinInputs checks the names of the additional join inputs before any job is created.
+// A name must not be empty, reserved for the reference and the model input, or duplicated.
+func validateJoinInputs(config configuration.AppConfig) error {
+	if config.Join == nil {
+		return nil
+	}
+
+	names := make(map[string]struct{}, len(config.Join.Inputs))
+	for i, e := range config.Join.Inputs {
+		switch e.Name {
+		case "":
+			return fmt.Errorf("validate join inputs: name of input %d is empty", i)
+		case joiner.RefInput, joiner.ModelInput:
+			return fmt.Errorf("validate join inputs: name %q is reserved", e.Name)
+		}
+
+		if _, ok := names[e.Name]; ok {
+			return fmt.Errorf("validate join inputs: duplicated name %q", e.Name)
+		}
+		names[e.Name] = struct{}{}
+	}
+	return nil
+}
+
+func extractJoinerSpec(config configuration.AppConfig) joiner.Spec {
+
+	spec := joiner.Spec{Mode: joiner.ModeExact}
+	if config.Join != nil && config.Join.Mode != "" {
+		spec.Mode = config.Join.Mode
+	}
 	return spec
 }
 
@@ -207,28 +364,185 @@ func extractAnalyzerSpec(config configuration.AppConfig) analyzer.Spec {
 	return spec
 }
 
-func extractUserParams(config configuration.AppConfig) job.UserParams {
+func extractUserParams(config configuration.AppConfig) (job.UserParams, error) {
 
 	var p = job.UserParams{
-		job.StartDate: fmt.Sprint(config.DataOrigin.StartTimestamp),
-		job.EndDate:   fmt.Sprint(config.DataOrigin.EndTimestamp),
 		job.BatchSize: fmt.Sprint(config.Model.BatchSize),
 		job.ProductID: config.DataOrigin.ProductID,
+		job.ModelID:   config.Model.ID,
+		job.Task:      config.Task,
 		job.TaskID:    config.TaskID,
 	}
 
+	// 생략된 timestamp는 0이므로 fetcher가 기본값을 사용하도록 전달하지 않는다.
+	if config.DataOrigin.StartTimestamp != 0 {
+		p[job.StartDate] = fmt.Sprint(config.DataOrigin.StartTimestamp)
+	}
+
+	if config.DataOrigin.EndTimestamp != 0 {
+		p[job.EndDate] = fmt.Sprint(config.DataOrigin.EndTimestamp)
+	}
+
+	if config.Model.Concurrency > 0 {
+		p[job.Concurrency] = fmt.Sprint(config.Model.Concurrency)
+	}
+
+	if len(config.Model.Labels) > 0 {
+		p[job.OutputLabels] = strings.Join(config.Model.Labels, ",")
+	}
+
+	if config.Model.Softmax {
+		p[job.OutputSoftmax] = "true"
+	}
+
+	if config.Model.BypassCache {
+		p[job.BypassInferenceCache] = "true"
+	}
+
+	if breaker := config.Model.Breaker; breaker != nil {
+		p[executer.BreakerFailureThresholdParam] = fmt.Sprint(breaker.FailureThreshold)
+
+		if breaker.OpenTimeout != "" {
+			p[executer.BreakerOpenTimeoutParam] = breaker.OpenTimeout
+		}
+	}
+
+	if config.Model.Fallback != "" {
+		p[executer.FallbackParam] = config.Model.Fallback
+	}
+
+	if features := config.Model.Features; features != nil {
+		if len(features.Fields) > 0 {
+			p[feature.FieldsParam] = strings.Join(features.Fields, ",")
+		}
+
+		if features.Window > 0 {
+			p[feature.WindowParam] = fmt.Sprint(features.Window)
+		}
+
+		if features.Stride > 0 {
+			p[feature.StrideParam] = fmt.Sprint(features.Stride)
+		}
+
+		if features.Layout != "" {
+			p[feature.LayoutParam] = features.Layout
+		}
+
+		for k, v := range features.Normalization {
+			p[feature.NormalizationParamPrefix+k] = v
+		}
+	}
+
+	if config.DataOrigin.ReplaySpeed != "" {
+		p[job.ReplaySpeed] = config.DataOrigin.ReplaySpeed
+	}
+
+	if config.DataOrigin.BypassCache {
+		p[job.BypassCache] = "true"
+	}
+
+	synthetic := config.DataOrigin.Synthetic
+	if synthetic.Process != "" {
+		p[job.SyntheticProcess] = synthetic.Process
+	}
+
+	if synthetic.Seed != nil {
+		p[job.Seed] = fmt.Sprint(*synthetic.Seed)
+	}
+
+	if synthetic.NumOfGeneration > 0 {
+		p[job.NumOfGeneration] = fmt.Sprint(synthetic.NumOfGeneration)
+	}
+
+	for k, v := range synthetic.Params {
+		p[strings.Join([]string{"synthetic", k}, ".")] = strconv.FormatFloat(v, 'f', -1, 64)
+	}
+
+	if config.DataOrigin.Bar.Type != "" {
+		p[job.BarType] = config.DataOrigin.Bar.Type
+	}
+
+	if config.DataOrigin.Bar.Threshold > 0 {
+		p[job.BarThreshold] = strconv.FormatFloat(config.DataOrigin.Bar.Threshold, 'f', -1, 64)
+	}
+
+	if validation := config.DataOrigin.Validation; validation != nil {
+		for k, v := range validation.Policies {
+			p[validator.PolicyParamPrefix+k] = v
+		}
+
+		if validation.SpikeZScore > 0 {
+			p[validator.SpikeZScore] = strconv.FormatFloat(validation.SpikeZScore, 'f', -1, 64)
+		}
+
+		if validation.SpikeWindow > 0 {
+			p[validator.SpikeWindow] = fmt.Sprint(validation.SpikeWindow)
+		}
+	}
+
+	if wm := config.DataOrigin.Watermark; wm != nil && wm.Delay != "" {
+		p[watermark.DelayParam] = wm.Delay
+	}
+
+	if join := config.Join; join != nil {
+		if join.Strategy != "" {
+			p[joiner.StrategyParam] = join.Strategy
+		}
+
+		if join.Tolerance != "" {
+			p[joiner.ToleranceParam] = join.Tolerance
+		}
+
+		if join.Unmatched != "" {
+			p[joiner.UnmatchedParam] = join.Unmatched
+		}
+
+		if join.MaxBuffer > 0 {
+			p[joiner.MaxBufferParam] = fmt.Sprint(join.MaxBuffer)
+		}
+
+		if join.Overflow != "" {
+			p[joiner.OverflowParam] = join.Overflow
+		}
+
+		if join.AllowedLateness != "" {
+			p[joiner.AllowedLatenessParam] = join.AllowedLateness
+		}
+	}
+
 	for k, v := range config.Model.Params {
-		p[strings.Join([]string{"model", k}, ".")] = strconv.FormatFloat(float64(v), 'f', -1, 32)
+		val, err := executer.EncodeModelParam(v)
+		if err != nil {
+			return nil, fmt.Errorf("model param %q: %w", k, err)
+		}
+		p[executer.ModelParamPrefix+k] = val
 	}
 
 	for k, v := range config.Strategy.Params {
 		p[strings.Join([]string{"strategy", k}, ".")] = strconv.FormatFloat(float64(v), 'f', -1, 32)
 	}
 
-	timeFrame := (time.Duration(config.DataOrigin.TimeFrame.Seconds) * time.Second).String()
+	if config.Strategy.InputWindow > 0 {
+		p[adapter.WindowParam] = fmt.Sprint(config.Strategy.InputWindow)
+	}
+
+	p[job.TimeFrame] = formatTimeFrame(config.DataOrigin.TimeFrame)
+
+	if len(config.DataOrigin.AdditionalTimeFrames) > 0 {
+		timeFrames := make([]string, len(config.DataOrigin.AdditionalTimeFrames))
+		for i, e := range config.DataOrigin.AdditionalTimeFrames {
+			timeFrames[i] = formatTimeFrame(e)
+		}
+		p[job.AdditionalTimeFrames] = strings.Join(timeFrames, ",")
+	}
+
+	return p, nil
+}
+
+// formatTimeFrame formats the time frame in the form of "1m", "1h".
+func formatTimeFrame(t configuration.TimeFrame) string {
+	timeFrame := (time.Duration(t.Seconds) * time.Second).String()
 	timeFrame = strings.Replace(timeFrame, "m0s", "m", 1)
 	timeFrame = strings.Replace(timeFrame, "h0m", "h", 1)
-
-	p[job.TimeFrame] = fmt.Sprint(timeFrame)
-	return p
+	return timeFrame
 }
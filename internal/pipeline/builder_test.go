@@ -6,8 +6,12 @@ import (
 	"testing"
 
 	"github.com/Goboolean/core-system.worker/configuration"
+	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/job/fetcher"
+	"github.com/Goboolean/core-system.worker/internal/job/joiner"
 	"github.com/Goboolean/core-system.worker/test/container"
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/sync/errgroup"
 )
 
 type BuildTestSuite struct {
@@ -63,6 +67,105 @@ func (suite *BuildTestSuite) TestBuild_ShouldBuildWithoutNormalPipeline_WhenGive
 
 }
 
+type UserParamsTestSuite struct {
+	suite.Suite
+}
+
+func (suite *UserParamsTestSuite) TestExtractUserParams_ShouldGenerateNumOfGeneration_WhenEndTimestampIsOmitted() {
+	//arrange
+	seed := int64(0)
+	cfg := configuration.AppConfig{
+		Task: "backTest",
+		DataOrigin: configuration.DataOrigin{
+			ProductType: "synthetic",
+			TimeFrame:   configuration.TimeFrame{Seconds: 60},
+			Synthetic:   configuration.SyntheticConfig{Seed: &seed, NumOfGeneration: 5},
+		},
+	}
+
+	//act
+	p, err := extractUserParams(cfg)
+	suite.Require().NoError(err)
+	f, err := fetcher.NewSynthetic(&p)
+	suite.Require().NoError(err)
+
+	num := 0
+	g := errgroup.Group{}
+	g.Go(func() error {
+		for range f.Output() {
+			num++
+		}
+		return nil
+	})
+	g.Go(f.Execute)
+
+	//assert
+	suite.Require().NoError(g.Wait())
+	suite.NotContains(p, job.StartDate)
+	suite.NotContains(p, job.EndDate)
+	suite.Equal(5, num)
+}
+
+func (suite *UserParamsTestSuite) TestExtractUserParams_ShouldPassTimestamps_WhenTheyAreGiven() {
+	//arrange
+	cfg := configuration.AppConfig{
+		DataOrigin: configuration.DataOrigin{StartTimestamp: 1720396800, EndTimestamp: 1720400400},
+	}
+
+	//act
+	p, err := extractUserParams(cfg)
+
+	//assert
+	suite.Require().NoError(err)
+	suite.Equal("1720396800", p[job.StartDate])
+	suite.Equal("1720400400", p[job.EndDate])
+}
+
+func (suite *UserParamsTestSuite) TestExtractUserParams_ShouldPassJoinStrategy_WhenModeIsMulti() {
+	//arrange
+	cfg := configuration.AppConfig{
+		Join: &configuration.JoinConfig{
+			Mode:      joiner.ModeMulti,
+			Strategy:  joiner.StrategyAsOf,
+			Tolerance: "30s",
+			Inputs:    []configuration.JoinInputConfig{{Name: "second", ID: "goooo-v2"}},
+		},
+	}
+
+	//act
+	p, err := extractUserParams(cfg)
+
+	//assert
+	suite.Require().NoError(err)
+	suite.Equal(joiner.StrategyAsOf, p[joiner.StrategyParam])
+	suite.Equal("30s", p[joiner.ToleranceParam])
+	suite.Equal(joiner.Spec{Mode: joiner.ModeMulti}, extractJoinerSpec(cfg))
+}
+
+func (suite *UserParamsTestSuite) TestBuildNormal_ShouldReturnError_WhenJoinInputNamesAreInvalid() {
+	for name, inputs := range map[string][]configuration.JoinInputConfig{
+		"empty":      {{Name: "", ID: "goooo-v2"}},
+		"reference":  {{Name: joiner.RefInput, ID: "goooo-v2"}},
+		"model":      {{Name: joiner.ModelInput, ID: "goooo-v2"}},
+		"duplicated": {{Name: "second", ID: "goooo-v2"}, {Name: "second", ID: "goooo-v3"}},
+	} {
+		//arrange
+		cfg := configuration.AppConfig{
+			Join: &configuration.JoinConfig{Mode: joiner.ModeMulti, Inputs: inputs},
+		}
+
+		//act
+		_, err := buildNormal(cfg)
+
+		//assert
+		suite.Error(err, name)
+	}
+}
+
+func TestUserParams(t *testing.T) {
+	suite.Run(t, new(UserParamsTestSuite))
+}
+
 func TestBuilder(t *testing.T) {
 	suite.Run(t, new(BuildTestSuite))
 }
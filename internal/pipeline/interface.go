@@ -7,4 +7,7 @@ type Pipeline interface {
 	// Executes the pipeline. It takes a context.Context parameter to support cancellation and deadline propagation.
 	// It returns an error if any step of the pipeline fails.
 	Run(ctx context.Context) error
+
+	// Plan describes the stages of the pipeline and the job of each stage in the order the data flows through them.
+	Plan() string
 }
@@ -3,13 +3,17 @@ package pipeline
 import (
 	"context"
 	"errors"
+	"fmt"
 
+	"github.com/Goboolean/core-system.worker/internal/job"
 	"github.com/Goboolean/core-system.worker/internal/job/adapter"
 	"github.com/Goboolean/core-system.worker/internal/job/analyzer"
 	"github.com/Goboolean/core-system.worker/internal/job/executer"
 	"github.com/Goboolean/core-system.worker/internal/job/fetcher"
 	"github.com/Goboolean/core-system.worker/internal/job/joiner"
+	"github.com/Goboolean/core-system.worker/internal/job/shadow"
 	"github.com/Goboolean/core-system.worker/internal/job/transmitter"
+	"github.com/Goboolean/core-system.worker/internal/job/watermark"
 	"github.com/Goboolean/core-system.worker/internal/model"
 	"github.com/Goboolean/core-system.worker/internal/util"
 	"github.com/Goboolean/core-system.worker/internal/util/chanutil"
@@ -19,6 +23,12 @@ import (
 
 var ErrTypeNotMatch = errors.New("pipeline: cannot build a pipeline because the types are not compatible between the jobs")
 
+// namedModel is the executer of an additional model and the name of its output in the joined tuple.
+type namedModel struct {
+	name     string
+	executer executer.ModelExecutor
+}
+
 // Normal orchestrates a normal pipeline of data processing stages.
 // It uses interfaces (Fetcher, Joiner, etc.) to abstract each stage, making it flexible and modular.
 type Normal struct {
@@ -30,9 +40,26 @@ type Normal struct {
 	resAnalyzer   analyzer.Analyzer
 	transmitter   transmitter.Transmitter
 
+	//shadow jobs, which are nil unless a shadow model is attached
+	shadowExecuter executer.ModelExecutor
+	comparator     *shadow.Comparator
+
+	//watermarks inserts watermarks into the fetched stream. It is nil unless watermarks are attached.
+	watermarks *watermark.Generator
+
+	//models are the additional models whose outputs are joined by name. It is empty unless models are attached.
+	models []namedModel
+
 	//utils
 	//mux used to pass duplicated trade data to model executer and joiner
 	mux *chanutil.ChannelMux[model.Packet]
+	//primaryMux passes the output of model executer to the comparator as well. It is nil unless a shadow model is attached.
+	primaryMux *chanutil.ChannelMux[model.Packet]
+	//late converts the late packets of joiner into annotations. It is nil unless joiner emits late packets.
+	late *lateRecorder
+	//annotations merges the annotations of jobs into the input of transmitter.
+	//It is nil if no job emits annotations.
+	annotations *chanutil.ChannelDeMux[model.Packet]
 	// done is used to signal completion or termination of the pipeline.
 	done *util.StopNotifier
 }
@@ -64,7 +91,8 @@ func NewNormalWithAdapter(
 	instance.joiner.SetModelInput(instance.adapter.Output())
 	instance.joiner.SetRefInput(instance.mux.Output())
 	instance.resAnalyzer.SetInput(instance.joiner.Output())
-	instance.transmitter.SetInput(instance.resAnalyzer.Output())
+
+	instance.connectTransmitter()
 
 	return &instance, nil
 }
@@ -93,12 +121,116 @@ func NewNormalWithoutAdapter(
 	instance.joiner.SetModelInput(instance.modelExecuter.Output())
 	instance.joiner.SetRefInput(instance.mux.Output())
 	instance.resAnalyzer.SetInput(instance.joiner.Output())
-	instance.transmitter.SetInput(instance.resAnalyzer.Output())
+
+	instance.connectTransmitter()
 
 	return &instance, nil
 
 }
 
+// AttachShadow runs shadowExecuter on the same fetched stream as the model executer.
+//
+// The output of the shadow model never reaches the analyzer.
+// comparator compares it with the output of the model executer and the fetched data,
+// and the comparisons are dispatched as annotations.
+// Both models consume the same fetched stream, so a slow shadow model slows down the pipeline as well.
+// AttachShadow MUST be called before Run.
+func (n *Normal) AttachShadow(shadowExecuter executer.ModelExecutor, comparator *shadow.Comparator) {
+	n.shadowExecuter = shadowExecuter
+	n.comparator = comparator
+
+	n.primaryMux = chanutil.NewChannelMux[model.Packet]()
+	n.primaryMux.SetInput(n.modelExecuter.Output())
+	if n.adapter != nil {
+		n.adapter.SetInput(n.primaryMux.Output())
+	} else {
+		n.joiner.SetModelInput(n.primaryMux.Output())
+	}
+
+	n.shadowExecuter.SetInput(n.mux.Output())
+	n.comparator.SetRefInput(n.mux.Output())
+	n.comparator.SetPrimaryInput(n.primaryMux.Output())
+	n.comparator.SetShadowInput(n.shadowExecuter.Output())
+
+	n.connectTransmitter()
+}
+
+// AttachModel runs modelExecuter on the same fetched stream as the model executer
+// and joins its output with the others under name.
+// The joiner must be a joiner.MultiJoiner, which passes model.Tuple objects to the analyzer.
+// AttachModel MUST be called before Run.
+func (n *Normal) AttachModel(name string, modelExecuter executer.ModelExecutor) error {
+	multi, ok := n.joiner.(joiner.MultiJoiner)
+	if !ok {
+		return fmt.Errorf("attach model %q: %w: joiner %T cannot join additional inputs", name, ErrTypeNotMatch, n.joiner)
+	}
+
+	modelExecuter.SetInput(n.mux.Output())
+	multi.AddInput(name, modelExecuter.Output())
+	n.models = append(n.models, namedModel{name: name, executer: modelExecuter})
+
+	n.connectTransmitter()
+	return nil
+}
+
+// AttachWatermarks inserts the watermarks of generator into the fetched stream,
+// so that they flow through the model executer and the adapter to both inputs of the joiner.
+// AttachWatermarks MUST be called before Run.
+func (n *Normal) AttachWatermarks(generator *watermark.Generator) {
+	n.watermarks = generator
+
+	n.watermarks.SetInput(n.fetcher.Output())
+	n.mux.SetInput(n.watermarks.Output())
+}
+
+// connectTransmitter sets the input of transmitter to the output of analyzer merged with the annotations of the jobs.
+func (n *Normal) connectTransmitter() {
+	jobs := []any{n.fetcher, n.joiner, n.modelExecuter, n.adapter, n.resAnalyzer}
+	if emitter, ok := n.joiner.(job.LateEmitter); ok {
+		if n.late == nil {
+			n.late = newLateRecorder(emitter)
+		}
+		jobs = append(jobs, n.late)
+	}
+	for _, e := range n.models {
+		jobs = append(jobs, e.executer)
+	}
+	if n.comparator != nil {
+		jobs = append(jobs, n.shadowExecuter, n.comparator)
+	}
+
+	var transmitterInput job.DataChan
+	transmitterInput, n.annotations = mergeAnnotations(n.resAnalyzer.Output(), jobs...)
+	n.transmitter.SetInput(transmitterInput)
+}
+
+// Plan describes the stages of the pipeline and the job of each stage.
+// The jobs of the shadow model follow the main stages.
+func (n *Normal) Plan() string {
+	stages := []stage{{"fetch", n.fetcher}}
+	if n.watermarks != nil {
+		stages = append(stages, stage{"watermark", n.watermarks})
+	}
+	stages = append(stages, stage{"execute", n.modelExecuter})
+	for _, e := range n.models {
+		stages = append(stages, stage{"execute " + e.name, e.executer})
+	}
+	if n.adapter != nil {
+		stages = append(stages, stage{"adapt", n.adapter})
+	}
+	stages = append(stages,
+		stage{"join", n.joiner},
+		stage{"analyze", n.resAnalyzer},
+		stage{"transmit", n.transmitter})
+	if n.comparator != nil {
+		stages = append(stages,
+			stage{"shadow execute", n.shadowExecuter},
+			stage{"compare", n.comparator})
+	}
+
+	return formatPlan(stages)
+}
+
 // Executes the entire pipeline in a structured and concurrent manner.
 func (n *Normal) Run(ctx context.Context) error {
 	g := errgroup.Group{}
@@ -120,6 +252,12 @@ func (n *Normal) Run(ctx context.Context) error {
 	}()
 
 	n.mux.Execute()
+	if n.primaryMux != nil {
+		n.primaryMux.Execute()
+	}
+	if n.annotations != nil {
+		n.annotations.Execute()
+	}
 
 	g.Go(func() error {
 		err := n.fetcher.Execute()
@@ -127,6 +265,30 @@ func (n *Normal) Run(ctx context.Context) error {
 		return err
 	})
 
+	g.Go(func() error {
+		if n.watermarks == nil {
+			return nil
+		}
+
+		err := n.watermarks.Execute()
+		log.Debug("watermark job is completed")
+		return err
+	})
+
+	// 늦게 도착한 packet은 버리지 않고 annotation으로 기록한다.
+	g.Go(func() error {
+		if n.late == nil {
+			return nil
+		}
+
+		err := n.late.Execute()
+		if err != nil {
+			stop.NotifyStop()
+		}
+		log.Debug("record late packet job is completed")
+		return err
+	})
+
 	g.Go(func() error {
 		err := n.joiner.Execute()
 		if err != nil {
@@ -145,6 +307,42 @@ func (n *Normal) Run(ctx context.Context) error {
 		return err
 	})
 
+	for _, e := range n.models {
+		g.Go(func() error {
+			err := e.executer.Execute()
+			if err != nil {
+				stop.NotifyStop()
+			}
+			log.WithField("name", e.name).Debug("execute job of additional model is completed")
+			return err
+		})
+	}
+
+	// shadow model의 실패는 파이프라인을 중단하지 않는다.
+	g.Go(func() error {
+		if n.shadowExecuter == nil {
+			return nil
+		}
+
+		if err := n.shadowExecuter.Execute(); err != nil {
+			log.WithError(err).Warn("shadow execute job is failed")
+		}
+		log.Debug("shadow execute job is completed")
+		return nil
+	})
+
+	g.Go(func() error {
+		if n.comparator == nil {
+			return nil
+		}
+
+		if err := n.comparator.Execute(); err != nil {
+			log.WithError(err).Warn("compare shadow job is failed")
+		}
+		log.Debug("compare shadow job is completed")
+		return nil
+	})
+
 	g.Go(func() error {
 		if n.adapter == nil {
 			return nil
@@ -5,16 +5,21 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"sort"
 	"syscall"
 	"testing"
+	"time"
 
 	"github.com/Goboolean/core-system.worker/internal/job"
 	"github.com/Goboolean/core-system.worker/internal/job/analyzer"
 	"github.com/Goboolean/core-system.worker/internal/job/executer"
 	"github.com/Goboolean/core-system.worker/internal/job/fetcher"
 	"github.com/Goboolean/core-system.worker/internal/job/joiner"
+	"github.com/Goboolean/core-system.worker/internal/job/shadow"
 	"github.com/Goboolean/core-system.worker/internal/job/transmitter"
 	v1 "github.com/Goboolean/core-system.worker/internal/job/transmitter/v1"
+	"github.com/Goboolean/core-system.worker/internal/job/watermark"
+	"github.com/Goboolean/core-system.worker/internal/model"
 	"github.com/Goboolean/core-system.worker/internal/pipeline"
 	"github.com/Goboolean/core-system.worker/internal/util"
 	"github.com/stretchr/testify/suite"
@@ -51,7 +56,8 @@ func (suite *NormalTestSuite) TestNormal_ShouldFlowDataBetweenJobs_WhenJobInject
 	mockOrderEventDispatcher.EXPECT().Dispatch(gomock.Any(), gomock.Any()).Times(num)
 	mockOrderEventDispatcher.EXPECT().Close().Times(1)
 
-	mockAnnotationDispatcher.EXPECT().Dispatch(gomock.Any(), gomock.Any(), gomock.Any()).Times(num)
+	mockAnnotationDispatcher.EXPECT().Dispatch(gomock.Any(), gomock.AssignableToTypeOf(model.ExampleAnnotation{}), gomock.Any()).Times(num)
+	mockAnnotationDispatcher.EXPECT().Dispatch(gomock.Any(), model.JoinSummary{Joined: num}, gomock.Any()).Times(1)
 	mockAnnotationDispatcher.EXPECT().Close().Times(1)
 
 	transmitJob, err := v1.NewCommon(mockAnnotationDispatcher,
@@ -99,6 +105,357 @@ func (suite *NormalTestSuite) TestNormal_ShouldFlowDataBetweenJobs_WhenJobInject
 	suite.Equal(0, stat)
 }
 
+func (suite *NormalTestSuite) TestNormal_ShouldDispatchShadowComparisons_WhenShadowModelIsAttached() {
+	//arrange
+	num := 100
+
+	fetchJob, err := fetcher.NewStockStub(&job.UserParams{
+		"numOfGeneration":            fmt.Sprint(num),
+		"maxRandomDelayMilliseconds": fmt.Sprint(1)})
+	suite.Require().NoError(err)
+
+	executeJob, err := executer.NewStub(&job.UserParams{})
+	suite.Require().NoError(err)
+
+	shadowJob, err := executer.NewStub(&job.UserParams{})
+	suite.Require().NoError(err)
+
+	comparator, err := shadow.NewComparator(&job.UserParams{})
+	suite.Require().NoError(err)
+
+	analyzeJob, err := analyzer.NewStub(&job.UserParams{})
+	suite.Require().NoError(err)
+
+	joinJob, err := joiner.NewByTime(&job.UserParams{})
+	suite.Require().NoError(err)
+
+	ctrl := gomock.NewController(suite.T())
+
+	mockOrderEventDispatcher := transmitter.NewMockOrderEventDispatcher(ctrl)
+	mockAnnotationDispatcher := transmitter.NewMockAnnotationDispatcher(ctrl)
+
+	// shadow model의 출력은 analyzer로 전달되지 않는다.
+	mockOrderEventDispatcher.EXPECT().Dispatch(gomock.Any(), gomock.Any()).Times(num)
+	mockOrderEventDispatcher.EXPECT().Close().Times(1)
+
+	// 마지막 bar의 예측은 다음 bar가 없으므로 비교하지 않는다.
+	mockAnnotationDispatcher.EXPECT().Dispatch(gomock.Any(), gomock.AssignableToTypeOf(model.ShadowComparison{}), gomock.Any()).Times(num - 1)
+	mockAnnotationDispatcher.EXPECT().Dispatch(gomock.Any(), gomock.AssignableToTypeOf(model.ExampleAnnotation{}), gomock.Any()).Times(num)
+	mockAnnotationDispatcher.EXPECT().Dispatch(gomock.Any(), model.JoinSummary{Joined: num}, gomock.Any()).Times(1)
+	mockAnnotationDispatcher.EXPECT().Close().Times(1)
+
+	transmitJob, err := v1.NewCommon(mockAnnotationDispatcher,
+		mockOrderEventDispatcher,
+		&job.UserParams{
+			job.TaskID: "2023-3240985",
+		})
+	suite.Require().NoError(err)
+
+	p, err := pipeline.NewNormalWithoutAdapter(
+		fetchJob,
+		joinJob,
+		executeJob,
+		analyzeJob,
+		transmitJob,
+	)
+	suite.Require().NoError(err)
+	p.AttachShadow(shadowJob, comparator)
+
+	//act
+	err = p.Run(context.Background())
+
+	//assert
+	suite.NoError(err)
+}
+
+func (suite *NormalTestSuite) TestNormal_ShouldJoinEveryPacket_WhenWatermarksAreAttached() {
+	//arrange
+	num := 100
+
+	fetchJob, err := fetcher.NewStockStub(&job.UserParams{
+		"numOfGeneration":            fmt.Sprint(num),
+		"maxRandomDelayMilliseconds": fmt.Sprint(1)})
+	suite.Require().NoError(err)
+
+	generator, err := watermark.New(&job.UserParams{watermark.DelayParam: "1s"})
+	suite.Require().NoError(err)
+
+	executeJob, err := executer.NewStub(&job.UserParams{})
+	suite.Require().NoError(err)
+
+	analyzeJob, err := analyzer.NewStub(&job.UserParams{})
+	suite.Require().NoError(err)
+
+	joinJob, err := joiner.NewByTime(&job.UserParams{})
+	suite.Require().NoError(err)
+
+	ctrl := gomock.NewController(suite.T())
+
+	mockOrderEventDispatcher := transmitter.NewMockOrderEventDispatcher(ctrl)
+	mockAnnotationDispatcher := transmitter.NewMockAnnotationDispatcher(ctrl)
+
+	// watermark는 join job에서 소비되어 analyzer와 transmitter에 전달되지 않는다.
+	mockOrderEventDispatcher.EXPECT().Dispatch(gomock.Any(), gomock.Any()).Times(num)
+	mockOrderEventDispatcher.EXPECT().Close().Times(1)
+
+	mockAnnotationDispatcher.EXPECT().Dispatch(gomock.Any(), gomock.AssignableToTypeOf(model.ExampleAnnotation{}), gomock.Any()).Times(num)
+	mockAnnotationDispatcher.EXPECT().Dispatch(gomock.Any(), model.JoinSummary{Joined: num}, gomock.Any()).Times(1)
+	mockAnnotationDispatcher.EXPECT().Close().Times(1)
+
+	transmitJob, err := v1.NewCommon(mockAnnotationDispatcher,
+		mockOrderEventDispatcher,
+		&job.UserParams{
+			job.TaskID: "2023-3240985",
+		})
+	suite.Require().NoError(err)
+
+	p, err := pipeline.NewNormalWithoutAdapter(
+		fetchJob,
+		joinJob,
+		executeJob,
+		analyzeJob,
+		transmitJob,
+	)
+	suite.Require().NoError(err)
+	p.AttachWatermarks(generator)
+
+	//act
+	err = p.Run(context.Background())
+
+	//assert
+	suite.NoError(err)
+}
+
+// tupleAnalyzer records the keys of the tuples it receives and sends a trade command for each of them.
+type tupleAnalyzer struct {
+	in   job.DataChan
+	out  job.DataChan
+	keys [][]string
+}
+
+func (a *tupleAnalyzer) Execute() error {
+	defer close(a.out)
+
+	for p := range a.in {
+		tuple, ok := p.Data.(model.Tuple)
+		if !ok {
+			return job.ErrTypeMismatch
+		}
+
+		keys := make([]string, 0, len(tuple))
+		for k := range tuple {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		a.keys = append(a.keys, keys)
+
+		a.out <- model.Packet{
+			Time: p.Time,
+			Data: &model.TradeCommand{Action: model.Sell},
+		}
+	}
+	return nil
+}
+
+func (a *tupleAnalyzer) SetInput(in job.DataChan) {
+	a.in = in
+}
+
+func (a *tupleAnalyzer) Output() job.DataChan {
+	return a.out
+}
+
+func (suite *NormalTestSuite) TestNormal_ShouldJoinOutputsOfEveryModel_WhenModelsAreAttached() {
+	//arrange
+	num := 100
+
+	fetchJob, err := fetcher.NewStockStub(&job.UserParams{
+		"numOfGeneration":            fmt.Sprint(num),
+		"maxRandomDelayMilliseconds": fmt.Sprint(1)})
+	suite.Require().NoError(err)
+
+	executeJob, err := executer.NewStub(&job.UserParams{})
+	suite.Require().NoError(err)
+
+	secondJob, err := executer.NewStub(&job.UserParams{})
+	suite.Require().NoError(err)
+
+	joinJob, err := joiner.NewMulti(&job.UserParams{joiner.StrategyParam: joiner.StrategyLeft})
+	suite.Require().NoError(err)
+
+	analyzeJob := &tupleAnalyzer{out: make(job.DataChan)}
+
+	ctrl := gomock.NewController(suite.T())
+
+	mockOrderEventDispatcher := transmitter.NewMockOrderEventDispatcher(ctrl)
+	mockAnnotationDispatcher := transmitter.NewMockAnnotationDispatcher(ctrl)
+
+	mockOrderEventDispatcher.EXPECT().Dispatch(gomock.Any(), gomock.Any()).Times(num)
+	mockOrderEventDispatcher.EXPECT().Close().Times(1)
+
+	mockAnnotationDispatcher.EXPECT().Dispatch(gomock.Any(), model.JoinSummary{Joined: num}, gomock.Any()).Times(1)
+	mockAnnotationDispatcher.EXPECT().Close().Times(1)
+
+	transmitJob, err := v1.NewCommon(mockAnnotationDispatcher,
+		mockOrderEventDispatcher,
+		&job.UserParams{
+			job.TaskID: "2023-3240985",
+		})
+	suite.Require().NoError(err)
+
+	p, err := pipeline.NewNormalWithoutAdapter(
+		fetchJob,
+		joinJob,
+		executeJob,
+		analyzeJob,
+		transmitJob,
+	)
+	suite.Require().NoError(err)
+	suite.Require().NoError(p.AttachModel("second", secondJob))
+
+	//act
+	err = p.Run(context.Background())
+
+	//assert
+	suite.NoError(err)
+	suite.Len(analyzeJob.keys, num)
+	for _, e := range analyzeJob.keys {
+		suite.Equal([]string{"model", "ref", "second"}, e)
+	}
+	suite.Contains(p.Plan(), "execute second: *executer.Stub")
+}
+
+func (suite *NormalTestSuite) TestAttachModel_ShouldReturnError_WhenJoinerCannotJoinAdditionalInputs() {
+	//arrange
+	fetchJob, err := fetcher.NewStockStub(&job.UserParams{})
+	suite.Require().NoError(err)
+
+	executeJob, err := executer.NewStub(&job.UserParams{})
+	suite.Require().NoError(err)
+
+	secondJob, err := executer.NewStub(&job.UserParams{})
+	suite.Require().NoError(err)
+
+	analyzeJob, err := analyzer.NewStub(&job.UserParams{})
+	suite.Require().NoError(err)
+
+	joinJob, err := joiner.NewByTime(&job.UserParams{})
+	suite.Require().NoError(err)
+
+	transmitJob, err := v1.NewCommon(transmitter.NewMockAnnotationDispatcher(gomock.NewController(suite.T())),
+		transmitter.NewMockOrderEventDispatcher(gomock.NewController(suite.T())),
+		&job.UserParams{})
+	suite.Require().NoError(err)
+
+	p, err := pipeline.NewNormalWithoutAdapter(
+		fetchJob,
+		joinJob,
+		executeJob,
+		analyzeJob,
+		transmitJob,
+	)
+	suite.Require().NoError(err)
+
+	//act
+	err = p.AttachModel("second", secondJob)
+
+	//assert
+	suite.ErrorIs(err, pipeline.ErrTypeNotMatch)
+}
+
+// packetFetcher sends the given packets as they are.
+type packetFetcher struct {
+	packets []model.Packet
+	out     job.DataChan
+}
+
+func (f *packetFetcher) Execute() error {
+	defer close(f.out)
+	for _, p := range f.packets {
+		f.out <- p
+	}
+	return nil
+}
+
+func (f *packetFetcher) Output() job.DataChan {
+	return f.out
+}
+
+func (f *packetFetcher) NotifyStop() {}
+
+func (suite *NormalTestSuite) TestNormal_ShouldDispatchLateRecords_WhenPacketsArriveAfterWatermark() {
+	//arrange
+	at := func(seconds int) time.Time {
+		return time.Unix(int64(1720396800+seconds), 0)
+	}
+	bar := func(seconds int) model.Packet {
+		return model.Packet{Time: at(seconds), Data: &model.StockAggregate{
+			OpenTime:   at(seconds).Unix(),
+			ClosedTime: at(seconds + 1).Unix(),
+		}}
+	}
+
+	fetchJob := &packetFetcher{
+		packets: []model.Packet{
+			bar(0), bar(1), bar(2),
+			{Time: at(3), Data: model.Watermark{}},
+			// watermark보다 이전 시각의 packet은 늦게 도착한 것으로 처리된다.
+			bar(1),
+		},
+		out: make(job.DataChan),
+	}
+
+	executeJob, err := executer.NewStub(&job.UserParams{})
+	suite.Require().NoError(err)
+
+	analyzeJob, err := analyzer.NewStub(&job.UserParams{})
+	suite.Require().NoError(err)
+
+	joinJob, err := joiner.NewByTime(&job.UserParams{})
+	suite.Require().NoError(err)
+
+	ctrl := gomock.NewController(suite.T())
+
+	mockOrderEventDispatcher := transmitter.NewMockOrderEventDispatcher(ctrl)
+	mockAnnotationDispatcher := transmitter.NewMockAnnotationDispatcher(ctrl)
+
+	mockOrderEventDispatcher.EXPECT().Dispatch(gomock.Any(), gomock.Any()).Times(3)
+	mockOrderEventDispatcher.EXPECT().Close().Times(1)
+
+	mockAnnotationDispatcher.EXPECT().Dispatch(gomock.Any(), gomock.AssignableToTypeOf(model.ExampleAnnotation{}), gomock.Any()).Times(3)
+	mockAnnotationDispatcher.EXPECT().Dispatch(gomock.Any(), model.JoinSummary{Joined: 3, Late: 2}, gomock.Any()).Times(1)
+	for _, input := range []string{joiner.RefInput, joiner.ModelInput} {
+		mockAnnotationDispatcher.EXPECT().Dispatch(gomock.Any(), model.LateRecord{
+			Input:     input,
+			Time:      at(1).UnixMilli(),
+			Watermark: at(3).UnixMilli(),
+		}, at(1)).Times(1)
+	}
+	mockAnnotationDispatcher.EXPECT().Close().Times(1)
+
+	transmitJob, err := v1.NewCommon(mockAnnotationDispatcher,
+		mockOrderEventDispatcher,
+		&job.UserParams{
+			job.TaskID: "2023-3240985",
+		})
+	suite.Require().NoError(err)
+
+	p, err := pipeline.NewNormalWithoutAdapter(
+		fetchJob,
+		joinJob,
+		executeJob,
+		analyzeJob,
+		transmitJob,
+	)
+	suite.Require().NoError(err)
+
+	//act
+	err = p.Run(context.Background())
+
+	//assert
+	suite.NoError(err)
+}
+
 func TestNormal(t *testing.T) {
 	suite.Run(t, new(NormalTestSuite))
 }
@@ -0,0 +1,30 @@
+package pipeline
+
+import (
+	"fmt"
+	"strings"
+)
+
+// stage is a stage of a pipeline shown in its plan.
+type stage struct {
+	name string
+	job  any
+}
+
+// String shows the job by its type, followed by its String method if it implements fmt.Stringer.
+// Example: "adapt: *adapter.Chain(probeDist -> valueList -> candlestick)"
+func (s stage) String() string {
+	if v, ok := s.job.(fmt.Stringer); ok {
+		return fmt.Sprintf("%s: %T(%s)", s.name, s.job, v)
+	}
+	return fmt.Sprintf("%s: %T", s.name, s.job)
+}
+
+// formatPlan formats the stages of a pipeline in the order the data flows through them.
+func formatPlan(stages []stage) string {
+	res := make([]string, len(stages))
+	for i, e := range stages {
+		res[i] = e.String()
+	}
+	return strings.Join(res, ", ")
+}
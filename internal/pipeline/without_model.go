@@ -3,11 +3,14 @@ package pipeline
 import (
 	"context"
 
+	"github.com/Goboolean/core-system.worker/internal/job"
 	"github.com/Goboolean/core-system.worker/internal/job/adapter"
 	"github.com/Goboolean/core-system.worker/internal/job/analyzer"
 	"github.com/Goboolean/core-system.worker/internal/job/fetcher"
 	"github.com/Goboolean/core-system.worker/internal/job/transmitter"
+	"github.com/Goboolean/core-system.worker/internal/model"
 	"github.com/Goboolean/core-system.worker/internal/util"
+	"github.com/Goboolean/core-system.worker/internal/util/chanutil"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -19,7 +22,10 @@ type WithoutModel struct {
 	analyzer    analyzer.Analyzer
 	transmitter transmitter.Transmitter
 
-	done *util.StopNotifier
+	//annotations merges the annotations of jobs into the input of transmitter.
+	//It is nil if no job emits annotations.
+	annotations *chanutil.ChannelDeMux[model.Packet]
+	done        *util.StopNotifier
 }
 
 // NewNormalWithAdapter Initializes a WithoutModel instance with all required components, including an adapter.
@@ -40,7 +46,15 @@ func NewWithoutModelWithAdapter(
 
 	instance.adapter.SetInput(instance.fetcher.Output())
 	instance.analyzer.SetInput(instance.adapter.Output())
-	instance.transmitter.SetInput(instance.analyzer.Output())
+
+	var transmitterInput job.DataChan
+	transmitterInput, instance.annotations = mergeAnnotations(
+		instance.analyzer.Output(),
+		instance.fetcher,
+		instance.adapter,
+		instance.analyzer,
+	)
+	instance.transmitter.SetInput(transmitterInput)
 
 	return &instance, nil
 }
@@ -59,11 +73,31 @@ func NewWithoutModelWithoutAdapter(
 	}
 
 	instance.analyzer.SetInput(instance.fetcher.Output())
-	instance.transmitter.SetInput(instance.analyzer.Output())
+
+	var transmitterInput job.DataChan
+	transmitterInput, instance.annotations = mergeAnnotations(
+		instance.analyzer.Output(),
+		instance.fetcher,
+		instance.analyzer,
+	)
+	instance.transmitter.SetInput(transmitterInput)
 
 	return &instance, nil
 }
 
+// Plan describes the stages of the pipeline and the job of each stage.
+func (wom *WithoutModel) Plan() string {
+	stages := []stage{{"fetch", wom.fetcher}}
+	if wom.adapter != nil {
+		stages = append(stages, stage{"adapt", wom.adapter})
+	}
+	stages = append(stages,
+		stage{"analyze", wom.analyzer},
+		stage{"transmit", wom.transmitter})
+
+	return formatPlan(stages)
+}
+
 // Executes the entire pipeline in a structured and concurrent manner.
 func (wom *WithoutModel) Run(ctx context.Context) error {
 	g := errgroup.Group{}
@@ -81,6 +115,10 @@ func (wom *WithoutModel) Run(ctx context.Context) error {
 		}
 	}()
 
+	if wom.annotations != nil {
+		wom.annotations.Execute()
+	}
+
 	g.Go(func() error {
 		return wom.fetcher.Execute()
 	})
@@ -9,10 +9,13 @@ import (
 	"testing"
 
 	"github.com/Goboolean/core-system.worker/internal/job"
+	"github.com/Goboolean/core-system.worker/internal/job/adapter"
 	"github.com/Goboolean/core-system.worker/internal/job/analyzer"
 	"github.com/Goboolean/core-system.worker/internal/job/fetcher"
 	"github.com/Goboolean/core-system.worker/internal/job/transmitter"
 	v1 "github.com/Goboolean/core-system.worker/internal/job/transmitter/v1"
+	"github.com/Goboolean/core-system.worker/internal/job/validator"
+	"github.com/Goboolean/core-system.worker/internal/model"
 	"github.com/Goboolean/core-system.worker/internal/pipeline"
 	"github.com/Goboolean/core-system.worker/internal/util"
 	"github.com/stretchr/testify/suite"
@@ -86,6 +89,88 @@ func (suite *WithoutModelTestSuite) TestNormal_ShouldFlowDataBetweenJobs_WhenJob
 	suite.Equal(0, stat)
 }
 
+func (suite *WithoutModelTestSuite) TestWithoutModel_ShouldTransmitDataQualitySummary_WhenFetcherIsValidated() {
+	//arrange
+	num := 10
+	stubJob, err := fetcher.NewStockStub(&job.UserParams{
+		"numOfGeneration": fmt.Sprint(num)})
+	suite.Require().NoError(err)
+
+	// StockStub의 데이터는 모두 같은 시간이므로 첫 번째 데이터를 제외하고 모두 제거된다.
+	fetchJob, err := validator.New(stubJob, &job.UserParams{})
+	suite.Require().NoError(err)
+
+	analyzeJob, err := analyzer.NewStub(&job.UserParams{})
+	suite.Require().NoError(err)
+
+	ctrl := gomock.NewController(suite.T())
+	mockOrderEventDispatcher := transmitter.NewMockOrderEventDispatcher(ctrl)
+	mockAnnotationDispatcher := transmitter.NewMockAnnotationDispatcher(ctrl)
+
+	mockOrderEventDispatcher.EXPECT().Dispatch(gomock.Any(), gomock.Any()).Times(1)
+	mockOrderEventDispatcher.EXPECT().Close().Times(1)
+
+	mockAnnotationDispatcher.EXPECT().Dispatch(gomock.Any(), model.DataQualitySummary{
+		Checked:    num,
+		Dropped:    num - 1,
+		Violations: map[string]int{validator.ViolationDuplicate: num - 1},
+	}, gomock.Any()).Times(1)
+	mockAnnotationDispatcher.EXPECT().Dispatch(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+	mockAnnotationDispatcher.EXPECT().Close().Times(1)
+
+	transmitJob, err := v1.NewCommon(mockAnnotationDispatcher,
+		mockOrderEventDispatcher,
+		&job.UserParams{
+			job.TaskID: "2023-3240985",
+		})
+	suite.Require().NoError(err)
+
+	p, err := pipeline.NewWithoutModelWithoutAdapter(
+		fetchJob,
+		analyzeJob,
+		transmitJob,
+	)
+	suite.Require().NoError(err)
+
+	//act
+	err = p.Run(context.Background())
+
+	//assert
+	suite.NoError(err)
+}
+
+func (suite *WithoutModelTestSuite) TestWithoutModel_ShouldShowAdapterChainInPlan_WhenAdapterIsChain() {
+	//arrange
+	fetchJob, err := fetcher.NewStockStub(&job.UserParams{})
+	suite.Require().NoError(err)
+
+	adaptJob, err := adapter.Create(adapter.Spec{InputType: "probeDist", OutputType: "candlestick"}, &job.UserParams{job.TimeFrame: "1m"})
+	suite.Require().NoError(err)
+
+	analyzeJob, err := analyzer.NewStub(&job.UserParams{})
+	suite.Require().NoError(err)
+
+	ctrl := gomock.NewController(suite.T())
+	transmitJob, err := v1.NewCommon(transmitter.NewMockAnnotationDispatcher(ctrl),
+		transmitter.NewMockOrderEventDispatcher(ctrl),
+		&job.UserParams{
+			job.TaskID: "2023-3240985",
+		})
+	suite.Require().NoError(err)
+
+	p, err := pipeline.NewWithoutModelWithAdapter(fetchJob, adaptJob, analyzeJob, transmitJob)
+	suite.Require().NoError(err)
+
+	//act
+	plan := p.Plan()
+
+	//assert
+	suite.Equal("fetch: *fetcher.StockStub, "+
+		"adapt: *adapter.Chain(probeDist -> valueList -> candlestick), "+
+		"analyze: *analyzer.Stub, "+
+		"transmit: *v1.Common", plan)
+}
+
 func TestWithoutModel(t *testing.T) {
 	suite.Run(t, new(WithoutModelTestSuite))
 }